@@ -0,0 +1,191 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// Access nodes serve secure gRPC connections with a self-signed libp2p TLS certificate, which carries
+// the network public key of the node and a signature of the certificate key made with it.
+// See https://github.com/libp2p/specs/blob/master/tls/tls.md.
+var (
+	libp2pExtensionID     = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 53594, 1, 1}
+	libp2pSignaturePrefix = []byte("libp2p-tls-handshake:")
+)
+
+// libp2pKeyTypeECDSA is the libp2p key type of ECDSA keys, whose data is the PKIX encoding of the key.
+const libp2pKeyTypeECDSA = 3
+
+// ErrUnexpectedNetworkKey is returned when an access node does not authenticate with the expected network key.
+var ErrUnexpectedNetworkKey = errors.New("access node did not authenticate with the expected network key")
+
+// WithSecureConnection configures the client to connect to the access node over TLS, authenticating
+// the node with its network public key.
+//
+// Access nodes use ECDSA P-256 network keys; the key is available in the node's network configuration,
+// e.g. the key field of a flow.json network.
+func WithSecureConnection(networkKey crypto.PublicKey) ClientOption {
+	return func(opts *options) {
+		opts.dialOptions = append(opts.dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			MinVersion: tls.VersionTLS13,
+			// the certificate is self-signed and verified against the network key instead
+			InsecureSkipVerify: true,
+			VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+				return verifyNetworkKey(rawCerts, networkKey)
+			},
+		})))
+	}
+}
+
+// signedKey is the libp2p certificate extension.
+type signedKey struct {
+	PubKey    []byte
+	Signature []byte
+}
+
+// verifyNetworkKey checks that the certificate presented by an access node is signed with the given network key.
+func verifyNetworkKey(rawCerts [][]byte, networkKey crypto.PublicKey) error {
+	if len(rawCerts) != 1 {
+		return fmt.Errorf("expected one certificate, got %d", len(rawCerts))
+	}
+
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+
+	var extension []byte
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(libp2pExtensionID) {
+			extension = ext.Value
+			break
+		}
+	}
+	if extension == nil {
+		return errors.New("certificate has no libp2p extension")
+	}
+
+	unhandled := cert.UnhandledCriticalExtensions[:0]
+	for _, id := range cert.UnhandledCriticalExtensions {
+		if !id.Equal(libp2pExtensionID) {
+			unhandled = append(unhandled, id)
+		}
+	}
+	cert.UnhandledCriticalExtensions = unhandled
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: roots}); err != nil {
+		return fmt.Errorf("invalid certificate: %w", err)
+	}
+
+	var signed signedKey
+	if _, err := asn1.Unmarshal(extension, &signed); err != nil {
+		return fmt.Errorf("invalid libp2p extension: %w", err)
+	}
+
+	key, err := parseLibp2pKey(signed.PubKey)
+	if err != nil {
+		return err
+	}
+
+	if networkKey.Algorithm() != crypto.ECDSA_P256 ||
+		!bytes.Equal(networkKey.Encode(), append(key.X.FillBytes(make([]byte, 32)), key.Y.FillBytes(make([]byte, 32))...)) {
+		return fmt.Errorf("%w: expected %s", ErrUnexpectedNetworkKey, networkKey)
+	}
+
+	certKey, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	message := make([]byte, 0, len(libp2pSignaturePrefix)+len(certKey))
+	message = append(append(message, libp2pSignaturePrefix...), certKey...)
+	digest := sha256.Sum256(message)
+	if !ecdsa.VerifyASN1(key, digest[:], signed.Signature) {
+		return errors.New("invalid libp2p extension signature")
+	}
+
+	return nil
+}
+
+// parseLibp2pKey parses a protobuf-encoded libp2p ECDSA P-256 public key.
+func parseLibp2pKey(b []byte) (*ecdsa.PublicKey, error) {
+	var (
+		keyType uint64
+		data    []byte
+	)
+
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errors.New("invalid libp2p public key")
+		}
+		b = b[n:]
+
+		switch tag {
+		case 0x08: // field 1, Type: varint
+			keyType, n = binary.Uvarint(b)
+			if n <= 0 {
+				return nil, errors.New("invalid libp2p public key type")
+			}
+			b = b[n:]
+		case 0x12: // field 2, Data: length-delimited
+			length, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < length {
+				return nil, errors.New("invalid libp2p public key data")
+			}
+			data = b[n : n+int(length)]
+			b = b[n+int(length):]
+		default:
+			return nil, fmt.Errorf("unexpected libp2p public key field %d", tag>>3)
+		}
+	}
+
+	if keyType != libp2pKeyTypeECDSA {
+		return nil, fmt.Errorf("%w: unsupported libp2p key type %d", ErrUnexpectedNetworkKey, keyType)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid libp2p public key: %w", err)
+	}
+
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok || ecdsaKey.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("%w: not an ECDSA P-256 key", ErrUnexpectedNetworkKey)
+	}
+
+	return ecdsaKey, nil
+}
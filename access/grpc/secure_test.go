@@ -0,0 +1,143 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// newNetworkKey generates an ECDSA P-256 network key.
+func newNetworkKey(t *testing.T) (*ecdsa.PrivateKey, crypto.PublicKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	publicKey, err := crypto.DecodePublicKey(
+		crypto.ECDSA_P256,
+		append(key.X.FillBytes(make([]byte, 32)), key.Y.FillBytes(make([]byte, 32))...),
+	)
+	require.NoError(t, err)
+
+	return key, publicKey
+}
+
+// newLibp2pCertificate creates a self-signed libp2p TLS certificate signed with the network key,
+// the way access nodes do.
+func newLibp2pCertificate(t *testing.T, networkKey *ecdsa.PrivateKey) tls.Certificate {
+	certKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	certPublicKey, err := x509.MarshalPKIXPublicKey(&certKey.PublicKey)
+	require.NoError(t, err)
+	digest := sha256.Sum256(append([]byte("libp2p-tls-handshake:"), certPublicKey...))
+	signature, err := ecdsa.SignASN1(rand.Reader, networkKey, digest[:])
+	require.NoError(t, err)
+
+	networkPublicKey, err := x509.MarshalPKIXPublicKey(&networkKey.PublicKey)
+	require.NoError(t, err)
+	// protobuf encoding of the libp2p public key: Type ECDSA and the PKIX key as Data
+	libp2pKey := append([]byte{0x08, libp2pKeyTypeECDSA, 0x12, byte(len(networkPublicKey))}, networkPublicKey...)
+
+	extension, err := asn1.Marshal(signedKey{PubKey: libp2pKey, Signature: signature})
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{{Id: libp2pExtensionID, Critical: true, Value: extension}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &certKey.PublicKey, certKey)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: certKey}
+}
+
+func TestWithSecureConnection(t *testing.T) {
+	networkKey, networkPublicKey := newNetworkKey(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	cert := newLibp2pCertificate(t, networkKey)
+	server := grpc.NewServer(grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	ping := func(t *testing.T, key crypto.PublicKey) error {
+		client, err := NewClient(listener.Addr().String(), WithSecureConnection(key))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return client.Ping(ctx)
+	}
+
+	t.Run("Expected key", func(t *testing.T) {
+		// the handshake succeeds, and the server does not implement the access API
+		err := ping(t, networkPublicKey)
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("Unexpected key", func(t *testing.T) {
+		_, otherKey := newNetworkKey(t)
+
+		err := ping(t, otherKey)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.ErrorContains(t, err, ErrUnexpectedNetworkKey.Error())
+	})
+
+	t.Run("Insecure server", func(t *testing.T) {
+		insecureListener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		insecureServer := grpc.NewServer()
+		go func() { _ = insecureServer.Serve(insecureListener) }()
+		t.Cleanup(insecureServer.Stop)
+
+		client, err := NewClient(insecureListener.Addr().String(), WithSecureConnection(networkPublicKey))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.Equal(t, codes.Unavailable, status.Code(client.Ping(ctx)))
+	})
+}
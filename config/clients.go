@@ -0,0 +1,94 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/onflow/flow-go-sdk/access/http"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// ErrNoHTTPHost is returned when the REST endpoint of a network is not known.
+var ErrNoHTTPHost = errors.New("config: no HTTP host known for network")
+
+// httpHosts maps the gRPC hosts of the well-known networks to their REST endpoints,
+// which flow.json does not record.
+var httpHosts = map[string]string{
+	grpc.EmulatorHost:  http.EmulatorHost,
+	"localhost:3569":   http.EmulatorHost,
+	grpc.TestnetHost:   http.TestnetHost,
+	grpc.MainnetHost:   http.MainnetHost,
+	grpc.CanarynetHost: http.CanarynetHost,
+}
+
+// GRPCClient returns a gRPC access client connected to the host of the given network.
+//
+// Networks with a key are connected to securely, authenticating the access node with its ECDSA P-256
+// network public key; the connection to other networks is insecure. Options passed to GRPCClient are
+// applied last and can override the transport credentials.
+func (c *Config) GRPCClient(network string, opts ...grpc.ClientOption) (*grpc.Client, error) {
+	n, err := c.Network(network)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.Key != "" {
+		key, err := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, strings.TrimPrefix(n.Key, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("config: invalid key of network %s: %w", network, err)
+		}
+		opts = append([]grpc.ClientOption{grpc.WithSecureConnection(key)}, opts...)
+	}
+
+	client, err := grpc.NewClient(n.Host, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to create client for network %s: %w", network, err)
+	}
+
+	return client, nil
+}
+
+// HTTPClient returns a REST access client for the given network.
+//
+// flow.json only records gRPC hosts, so the REST endpoint is derived from the gRPC host of the
+// network and is only known for the emulator, testnet, mainnet and canarynet access nodes.
+// ErrNoHTTPHost is returned for networks connecting to any other host; create their client
+// with http.NewClient instead.
+func (c *Config) HTTPClient(network string, opts ...http.ClientOption) (*http.Client, error) {
+	n, err := c.Network(network)
+	if err != nil {
+		return nil, err
+	}
+
+	host, ok := httpHosts[n.Host]
+	if !ok {
+		return nil, fmt.Errorf("%w %s: no REST endpoint known for gRPC host %s", ErrNoHTTPHost, network, n.Host)
+	}
+
+	client, err := http.NewClient(host, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to create client for network %s: %w", network, err)
+	}
+
+	return client, nil
+}
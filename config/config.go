@@ -0,0 +1,409 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config loads Flow project configuration files (flow.json) into SDK types.
+//
+// The configuration format is the one used by the Flow CLI. It describes the networks a project
+// connects to, the accounts and keys it signs with, and the contracts it deploys or imports.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/imports"
+)
+
+// DefaultPath is the default name of the configuration file.
+const DefaultPath = "flow.json"
+
+var (
+	// ErrNetworkNotFound is returned when a network is not defined in the configuration.
+	ErrNetworkNotFound = errors.New("config: network not found")
+
+	// ErrAccountNotFound is returned when an account is not defined in the configuration.
+	ErrAccountNotFound = errors.New("config: account not found")
+
+	// ErrContractNotFound is returned when a contract is not defined in the configuration.
+	ErrContractNotFound = errors.New("config: contract not found")
+)
+
+// Config is a parsed flow.json configuration.
+type Config struct {
+	Networks    map[string]Network
+	Accounts    map[string]Account
+	Contracts   map[string]Contract
+	Deployments []Deployment
+	Emulators   map[string]Emulator
+
+	// dir is the directory relative paths in the configuration are resolved against.
+	dir string
+	// kms holds the KMS clients shared by the KMS keys of all accounts.
+	kms *kmsClient
+}
+
+// A Network is an access node a project connects to.
+type Network struct {
+	Name string
+	// Host is the gRPC address of the access node.
+	Host string
+	// Key is the hex-encoded ECDSA P-256 network public key of the access node, used for secure connections.
+	// It is empty for insecure connections.
+	Key string
+}
+
+// An Account is an account the project signs transactions with.
+type Account struct {
+	Name    string
+	Address flow.Address
+	Key     AccountKey
+}
+
+// A Contract is a contract the project deploys or imports.
+type Contract struct {
+	Name string
+	// Source is the path of the contract source code, relative to the configuration file.
+	Source string
+	// Aliases are the addresses the contract is already deployed at, by network name.
+	Aliases map[string]flow.Address
+}
+
+// Alias returns the address the contract is deployed at on the given network, if any.
+func (c Contract) Alias(network string) (flow.Address, bool) {
+	address, ok := c.Aliases[network]
+	return address, ok
+}
+
+// A Deployment lists the contracts to deploy to an account on a network.
+type Deployment struct {
+	Network   string
+	Account   string
+	Contracts []DeploymentContract
+}
+
+// A DeploymentContract is a contract to deploy, with its initializer arguments.
+type DeploymentContract struct {
+	Name string
+	Args []cadence.Value
+}
+
+// An Emulator is an emulator configuration.
+type Emulator struct {
+	Name           string
+	Port           int
+	ServiceAccount string
+}
+
+// Load reads and parses the configuration file at path.
+//
+// Relative paths in the configuration are resolved against the directory of the file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to read %s: %w", path, err)
+	}
+
+	config, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	config.dir = filepath.Dir(path)
+	for name, account := range config.Accounts {
+		account.Key.dir = config.dir
+		config.Accounts[name] = account
+	}
+
+	return config, nil
+}
+
+// Parse parses a configuration.
+//
+// Relative paths in the configuration are resolved against the working directory.
+func Parse(data []byte) (*Config, error) {
+	var raw struct {
+		Networks    map[string]json.RawMessage              `json:"networks"`
+		Accounts    map[string]rawAccount                   `json:"accounts"`
+		Contracts   map[string]json.RawMessage              `json:"contracts"`
+		Deployments map[string]map[string][]json.RawMessage `json:"deployments"`
+		Emulators   map[string]struct {
+			Port           int    `json:"port"`
+			ServiceAccount string `json:"serviceAccount"`
+		} `json:"emulators"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("config: failed to parse configuration: %w", err)
+	}
+
+	config := &Config{
+		Networks:  make(map[string]Network, len(raw.Networks)),
+		Accounts:  make(map[string]Account, len(raw.Accounts)),
+		Contracts: make(map[string]Contract, len(raw.Contracts)),
+		Emulators: make(map[string]Emulator, len(raw.Emulators)),
+		kms:       &kmsClient{},
+	}
+
+	for name, value := range raw.Networks {
+		network, err := parseNetwork(name, value)
+		if err != nil {
+			return nil, err
+		}
+		config.Networks[name] = network
+	}
+
+	for name, value := range raw.Accounts {
+		account, err := value.account(name)
+		if err != nil {
+			return nil, err
+		}
+		account.Key.kms = config.kms
+		config.Accounts[name] = account
+	}
+
+	for name, value := range raw.Contracts {
+		contract, err := parseContract(name, value)
+		if err != nil {
+			return nil, err
+		}
+		config.Contracts[name] = contract
+	}
+
+	for _, network := range sortedKeys(raw.Deployments) {
+		accounts := raw.Deployments[network]
+		for _, account := range sortedKeys(accounts) {
+			deployment, err := parseDeployment(network, account, accounts[account])
+			if err != nil {
+				return nil, err
+			}
+			config.Deployments = append(config.Deployments, deployment)
+		}
+	}
+
+	for name, value := range raw.Emulators {
+		config.Emulators[name] = Emulator{
+			Name:           name,
+			Port:           value.Port,
+			ServiceAccount: value.ServiceAccount,
+		}
+	}
+
+	return config, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseNetwork parses a network given either as a host string or as an object with a host and key.
+func parseNetwork(name string, value json.RawMessage) (Network, error) {
+	network := Network{Name: name}
+
+	if err := json.Unmarshal(value, &network.Host); err == nil {
+		return network, nil
+	}
+
+	var advanced struct {
+		Host string `json:"host"`
+		Key  string `json:"key"`
+	}
+	if err := json.Unmarshal(value, &advanced); err != nil {
+		return Network{}, fmt.Errorf("config: invalid network %s: %w", name, err)
+	}
+
+	network.Host = advanced.Host
+	network.Key = advanced.Key
+
+	return network, nil
+}
+
+// parseContract parses a contract given either as a source path or as an object with a source and aliases.
+func parseContract(name string, value json.RawMessage) (Contract, error) {
+	contract := Contract{Name: name}
+
+	if err := json.Unmarshal(value, &contract.Source); err == nil {
+		return contract, nil
+	}
+
+	var advanced struct {
+		Source  string            `json:"source"`
+		Aliases map[string]string `json:"aliases"`
+	}
+	if err := json.Unmarshal(value, &advanced); err != nil {
+		return Contract{}, fmt.Errorf("config: invalid contract %s: %w", name, err)
+	}
+
+	contract.Source = advanced.Source
+	contract.Aliases = make(map[string]flow.Address, len(advanced.Aliases))
+
+	for network, alias := range advanced.Aliases {
+		address, err := parseAddress(alias)
+		if err != nil {
+			return Contract{}, fmt.Errorf("config: invalid alias of contract %s on %s: %w", name, network, err)
+		}
+		contract.Aliases[network] = address
+	}
+
+	return contract, nil
+}
+
+// parseDeployment parses the contracts deployed to an account, each given either as a name or
+// as an object with a name and JSON-CDC encoded initializer arguments.
+func parseDeployment(network string, account string, values []json.RawMessage) (Deployment, error) {
+	deployment := Deployment{
+		Network: network,
+		Account: account,
+	}
+
+	for _, value := range values {
+		var contract DeploymentContract
+
+		if err := json.Unmarshal(value, &contract.Name); err != nil {
+			var advanced struct {
+				Name string            `json:"name"`
+				Args []json.RawMessage `json:"args"`
+			}
+			if err := json.Unmarshal(value, &advanced); err != nil {
+				return Deployment{}, fmt.Errorf("config: invalid deployment to %s on %s: %w", account, network, err)
+			}
+
+			contract.Name = advanced.Name
+			for i, arg := range advanced.Args {
+				decoded, err := jsoncdc.Decode(nil, arg)
+				if err != nil {
+					return Deployment{}, fmt.Errorf(
+						"config: invalid argument %d of contract %s deployed to %s on %s: %w",
+						i, contract.Name, account, network, err,
+					)
+				}
+				contract.Args = append(contract.Args, decoded)
+			}
+		}
+
+		deployment.Contracts = append(deployment.Contracts, contract)
+	}
+
+	return deployment, nil
+}
+
+var addressPattern = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{1,16}$`)
+
+func parseAddress(s string) (flow.Address, error) {
+	if !addressPattern.MatchString(s) {
+		return flow.EmptyAddress, fmt.Errorf("invalid address %q", s)
+	}
+	return flow.HexToAddress(s), nil
+}
+
+// Network returns the network with the given name.
+func (c *Config) Network(name string) (Network, error) {
+	network, ok := c.Networks[name]
+	if !ok {
+		return Network{}, fmt.Errorf("%w: %s", ErrNetworkNotFound, name)
+	}
+	return network, nil
+}
+
+// Account returns the account with the given name.
+func (c *Config) Account(name string) (Account, error) {
+	account, ok := c.Accounts[name]
+	if !ok {
+		return Account{}, fmt.Errorf("%w: %s", ErrAccountNotFound, name)
+	}
+	return account, nil
+}
+
+// Contract returns the contract with the given name.
+func (c *Config) Contract(name string) (Contract, error) {
+	contract, ok := c.Contracts[name]
+	if !ok {
+		return Contract{}, fmt.Errorf("%w: %s", ErrContractNotFound, name)
+	}
+	return contract, nil
+}
+
+// ContractSource reads the source code of the contract with the given name.
+func (c *Config) ContractSource(name string) ([]byte, error) {
+	contract, err := c.Contract(name)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := os.ReadFile(c.path(contract.Source))
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to read source of contract %s: %w", name, err)
+	}
+
+	return source, nil
+}
+
+// DeploymentsOn returns the deployments on the given network.
+func (c *Config) DeploymentsOn(network string) []Deployment {
+	var deployments []Deployment
+	for _, deployment := range c.Deployments {
+		if deployment.Network == network {
+			deployments = append(deployments, deployment)
+		}
+	}
+	return deployments
+}
+
+// Register adds the contract addresses known on a network to an import registry for the given chain.
+//
+// Aliases are registered, as are contracts deployed on the network to an account of the configuration.
+func (c *Config) Register(registry *imports.Registry, network string, chain flow.ChainID) *imports.Registry {
+	for _, deployment := range c.DeploymentsOn(network) {
+		account, ok := c.Accounts[deployment.Account]
+		if !ok {
+			continue
+		}
+		for _, contract := range deployment.Contracts {
+			registry.Register(chain, contract.Name, account.Address)
+		}
+	}
+
+	for name, contract := range c.Contracts {
+		if address, ok := contract.Alias(network); ok {
+			registry.Register(chain, name, address)
+		}
+	}
+
+	return registry
+}
+
+// path resolves a path from the configuration against the configuration directory.
+func (c *Config) path(path string) string {
+	if filepath.IsAbs(path) || c.dir == "" {
+		return path
+	}
+	return filepath.Join(c.dir, path)
+}
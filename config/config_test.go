@@ -0,0 +1,355 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/config"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/imports"
+)
+
+const privateKey = "b89228aafd909a353d89efb8d1d487b12903d84d4af26b9dfe63a9ef75d11297"
+
+const configuration = `{
+	"emulators": {
+		"default": {
+			"port": 3569,
+			"serviceAccount": "emulator-account"
+		}
+	},
+	"contracts": {
+		"Hello": "./contracts/Hello.cdc",
+		"FungibleToken": {
+			"source": "./contracts/FungibleToken.cdc",
+			"aliases": {
+				"emulator": "ee82856bf20e2aa6",
+				"testnet": "0x9a0766d93b6608b7"
+			}
+		}
+	},
+	"networks": {
+		"emulator": "127.0.0.1:3569",
+		"secure": {
+			"host": "access.example.org:9000",
+			"key": "ba69f7d2e82b9edf25b103c195cd371cf0cc047ef8884a9bbe331e62982d46daeebf836f7445a2ac16741013b192959d8ad26998aff12f2adc67a99e1eb2988d"
+		}
+	},
+	"accounts": {
+		"emulator-account": {
+			"address": "f8d6e0586b0a20c7",
+			"key": "` + privateKey + `"
+		},
+		"advanced": {
+			"address": "0x01cf0e2f2f715450",
+			"key": {
+				"type": "hex",
+				"index": 2,
+				"signatureAlgorithm": "ECDSA_secp256k1",
+				"hashAlgorithm": "SHA2_256",
+				"privateKey": "$CONFIG_TEST_PRIVATE_KEY"
+			}
+		},
+		"file": {
+			"address": "179b6b1cb6755e31",
+			"key": {
+				"type": "file",
+				"location": "./emulator.pkey"
+			}
+		},
+		"kms": {
+			"address": "f3fcd2c1a78f5eee",
+			"key": {
+				"type": "google-kms",
+				"index": 0,
+				"resourceID": "projects/my-project/locations/global/keyRings/flow/cryptoKeys/my-key/cryptoKeyVersions/1"
+			}
+		},
+		"aws-kms": {
+			"address": "e03daebed8ca0615",
+			"key": {
+				"type": "aws-kms",
+				"resourceID": "arn:aws:kms:us-west-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+			}
+		}
+	},
+	"deployments": {
+		"emulator": {
+			"emulator-account": [
+				"Hello",
+				{
+					"name": "Greeter",
+					"args": [{"type": "String", "value": "Hi"}]
+				}
+			]
+		}
+	}
+}`
+
+func load(t *testing.T) *config.Config {
+	dir := t.TempDir()
+	path := filepath.Join(dir, config.DefaultPath)
+
+	require.NoError(t, os.WriteFile(path, []byte(configuration), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "emulator.pkey"), []byte(privateKey+"\n"), 0o600))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	return cfg
+}
+
+func TestLoad(t *testing.T) {
+	cfg := load(t)
+
+	t.Run("Networks", func(t *testing.T) {
+		network, err := cfg.Network("emulator")
+		require.NoError(t, err)
+		assert.Equal(t, config.Network{Name: "emulator", Host: "127.0.0.1:3569"}, network)
+
+		secure, err := cfg.Network("secure")
+		require.NoError(t, err)
+		assert.Equal(t, "access.example.org:9000", secure.Host)
+		assert.NotEmpty(t, secure.Key)
+
+		_, err = cfg.Network("unknown")
+		assert.ErrorIs(t, err, config.ErrNetworkNotFound)
+	})
+
+	t.Run("Accounts", func(t *testing.T) {
+		account, err := cfg.Account("emulator-account")
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress("f8d6e0586b0a20c7"), account.Address)
+		assert.Equal(t, config.KeyTypeHex, account.Key.Type)
+		assert.Equal(t, crypto.ECDSA_P256, account.Key.SigAlgo)
+		assert.Equal(t, crypto.SHA3_256, account.Key.HashAlgo)
+
+		advanced, err := cfg.Account("advanced")
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress("01cf0e2f2f715450"), advanced.Address)
+		assert.Equal(t, uint32(2), advanced.Key.Index)
+		assert.Equal(t, crypto.ECDSA_secp256k1, advanced.Key.SigAlgo)
+		assert.Equal(t, crypto.SHA2_256, advanced.Key.HashAlgo)
+
+		kms, err := cfg.Account("kms")
+		require.NoError(t, err)
+		assert.Equal(t, config.KeyTypeGoogleKMS, kms.Key.Type)
+		assert.Equal(t, crypto.SHA2_256, kms.Key.HashAlgo)
+
+		awsKMS, err := cfg.Account("aws-kms")
+		require.NoError(t, err)
+		assert.Equal(t, config.KeyTypeAWSKMS, awsKMS.Key.Type)
+		assert.Equal(t, crypto.SHA2_256, awsKMS.Key.HashAlgo)
+
+		_, err = cfg.Account("unknown")
+		assert.ErrorIs(t, err, config.ErrAccountNotFound)
+	})
+
+	t.Run("Contracts", func(t *testing.T) {
+		hello, err := cfg.Contract("Hello")
+		require.NoError(t, err)
+		assert.Equal(t, "./contracts/Hello.cdc", hello.Source)
+
+		ft, err := cfg.Contract("FungibleToken")
+		require.NoError(t, err)
+
+		alias, ok := ft.Alias("testnet")
+		assert.True(t, ok)
+		assert.Equal(t, flow.HexToAddress("9a0766d93b6608b7"), alias)
+
+		_, ok = ft.Alias("mainnet")
+		assert.False(t, ok)
+	})
+
+	t.Run("Deployments", func(t *testing.T) {
+		deployments := cfg.DeploymentsOn("emulator")
+		require.Len(t, deployments, 1)
+
+		assert.Equal(t, "emulator-account", deployments[0].Account)
+		assert.Equal(t, []config.DeploymentContract{
+			{Name: "Hello"},
+			{Name: "Greeter", Args: []cadence.Value{cadence.String("Hi")}},
+		}, deployments[0].Contracts)
+	})
+
+	t.Run("Emulators", func(t *testing.T) {
+		assert.Equal(t, config.Emulator{
+			Name:           "default",
+			Port:           3569,
+			ServiceAccount: "emulator-account",
+		}, cfg.Emulators["default"])
+	})
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"Syntax":           `{`,
+		"Address":          `{"accounts": {"a": {"address": "xyz", "key": "00"}}}`,
+		"Alias":            `{"contracts": {"A": {"source": "a.cdc", "aliases": {"emulator": "xyz"}}}}`,
+		"Hash algorithm":   `{"accounts": {"a": {"address": "01", "key": {"type": "hex", "hashAlgorithm": "MD5"}}}}`,
+		"Deployment value": `{"deployments": {"emulator": {"a": [{"name": "A", "args": [{"type": "Nope"}]}]}}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := config.Parse([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConfig_Signer(t *testing.T) {
+	cfg := load(t)
+	ctx := context.Background()
+
+	expected, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, privateKey)
+	require.NoError(t, err)
+
+	t.Run("Hex key", func(t *testing.T) {
+		signer, err := cfg.Signer(ctx, "emulator-account")
+		require.NoError(t, err)
+		assert.Equal(t, expected.PublicKey(), signer.PublicKey())
+	})
+
+	t.Run("File key", func(t *testing.T) {
+		signer, err := cfg.Signer(ctx, "file")
+		require.NoError(t, err)
+		assert.Equal(t, expected.PublicKey(), signer.PublicKey())
+	})
+
+	t.Run("Environment key", func(t *testing.T) {
+		secp, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_secp256k1, privateKey)
+		require.NoError(t, err)
+
+		t.Setenv("CONFIG_TEST_PRIVATE_KEY", privateKey)
+
+		account, err := cfg.Account("advanced")
+		require.NoError(t, err)
+
+		key, signer, err := account.AccountKey(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, secp.PublicKey(), signer.PublicKey())
+		assert.Equal(t, uint32(2), key.Index)
+		assert.Equal(t, crypto.ECDSA_secp256k1, key.SigAlgo)
+		assert.Equal(t, crypto.SHA2_256, key.HashAlgo)
+	})
+
+	t.Run("Unknown account", func(t *testing.T) {
+		_, err := cfg.Signer(ctx, "unknown")
+		assert.ErrorIs(t, err, config.ErrAccountNotFound)
+	})
+
+	t.Run("KMS key without configuration", func(t *testing.T) {
+		key := config.AccountKey{
+			Type:       config.KeyTypeGoogleKMS,
+			ResourceID: "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1",
+		}
+
+		_, err := key.Signer(ctx)
+		assert.ErrorIs(t, err, config.ErrNoKMSClient)
+
+		key = config.AccountKey{
+			Type:       config.KeyTypeAWSKMS,
+			ResourceID: "arn:aws:kms:us-west-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		}
+
+		_, err = key.Signer(ctx)
+		assert.ErrorIs(t, err, config.ErrNoKMSClient)
+	})
+
+	t.Run("Invalid AWS KMS key ARN", func(t *testing.T) {
+		account, err := cfg.Account("aws-kms")
+		require.NoError(t, err)
+
+		account.Key.ResourceID = "arn:aws:kms:us-west-2:111122223333:1234abcd"
+		_, err = account.Signer(ctx)
+		assert.ErrorContains(t, err, "wrong format for the resourceARN")
+	})
+
+	t.Run("Unsupported key type", func(t *testing.T) {
+		_, err := config.AccountKey{Type: "bip44"}.Signer(ctx)
+		assert.ErrorIs(t, err, config.ErrUnsupportedKeyType)
+	})
+
+	t.Run("Close without KMS client", func(t *testing.T) {
+		assert.NoError(t, cfg.Close())
+	})
+}
+
+func TestConfig_Register(t *testing.T) {
+	cfg := load(t)
+
+	registry := cfg.Register(imports.NewRegistry(), "emulator", flow.Emulator)
+
+	hello, ok := registry.Address(flow.Emulator, "Hello")
+	assert.True(t, ok)
+	assert.Equal(t, flow.HexToAddress("f8d6e0586b0a20c7"), hello)
+
+	ft, ok := registry.Address(flow.Emulator, "FungibleToken")
+	assert.True(t, ok)
+	assert.Equal(t, flow.HexToAddress("ee82856bf20e2aa6"), ft)
+}
+
+func TestConfig_Clients(t *testing.T) {
+	cfg := load(t)
+
+	grpcClient, err := cfg.GRPCClient("emulator")
+	require.NoError(t, err)
+	assert.NotNil(t, grpcClient)
+	require.NoError(t, grpcClient.Close())
+
+	httpClient, err := cfg.HTTPClient("emulator")
+	require.NoError(t, err)
+	assert.NotNil(t, httpClient)
+
+	_, err = cfg.HTTPClient("secure")
+	assert.ErrorIs(t, err, config.ErrNoHTTPHost)
+	assert.ErrorContains(t, err, "access.example.org:9000")
+
+	_, err = cfg.GRPCClient("unknown")
+	assert.ErrorIs(t, err, config.ErrNetworkNotFound)
+
+	t.Run("Secure", func(t *testing.T) {
+		secureClient, err := cfg.GRPCClient("secure")
+		require.NoError(t, err)
+		require.NoError(t, secureClient.Close())
+
+		cfg.Networks["invalid-key"] = config.Network{Name: "invalid-key", Host: "access.example.org:9000", Key: "0x1234"}
+		_, err = cfg.GRPCClient("invalid-key")
+		assert.ErrorContains(t, err, "invalid key of network invalid-key")
+	})
+}
+
+func TestLoad_Examples(t *testing.T) {
+	cfg, err := config.Load(filepath.Join("..", "examples", config.DefaultPath))
+	require.NoError(t, err)
+
+	signer, err := cfg.Signer(context.Background(), "emulator-account")
+	require.NoError(t, err)
+	assert.NotNil(t, signer)
+
+	assert.Equal(t, "access.mainnet.nodes.onflow.org:9000", cfg.Networks["mainnet"].Host)
+}
@@ -0,0 +1,379 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/awskms"
+	"github.com/onflow/flow-go-sdk/crypto/cloudkms"
+)
+
+// KeyType is the source of an account key.
+type KeyType string
+
+const (
+	// KeyTypeHex is a hex-encoded private key stored in the configuration.
+	KeyTypeHex KeyType = "hex"
+	// KeyTypeFile is a hex-encoded private key stored in a separate file.
+	KeyTypeFile KeyType = "file"
+	// KeyTypeGoogleKMS is a Google Cloud KMS asymmetric signing key.
+	KeyTypeGoogleKMS KeyType = "google-kms"
+	// KeyTypeAWSKMS is an AWS KMS asymmetric signing key.
+	KeyTypeAWSKMS KeyType = "aws-kms"
+)
+
+var (
+	// ErrUnsupportedKeyType is returned for account keys whose source is not supported.
+	ErrUnsupportedKeyType = errors.New("config: unsupported key type")
+
+	// ErrNoKMSClient is returned for KMS keys that were not loaded from a configuration, which owns the KMS client.
+	ErrNoKMSClient = errors.New("config: KMS key has no client")
+)
+
+// An AccountKey describes where the private key of an account key is stored.
+type AccountKey struct {
+	Type     KeyType
+	Index    uint32
+	SigAlgo  crypto.SignatureAlgorithm
+	HashAlgo crypto.HashAlgorithm
+
+	// PrivateKey is the hex-encoded private key of a hex key.
+	//
+	// Values of the form $NAME or ${NAME} are read from the environment variable NAME.
+	PrivateKey string
+	// Location is the path of the file holding the private key of a file key, relative to the configuration file.
+	Location string
+	// ResourceID is the resource ID of a Google Cloud KMS key, or the ARN of an AWS KMS key.
+	ResourceID string
+
+	// dir is the directory Location is resolved against.
+	dir string
+	// kms is the KMS client of the configuration the key was loaded from.
+	kms *kmsClient
+}
+
+// kmsClient lazily creates the KMS clients shared by the keys of a configuration: a single Google Cloud
+// KMS client, and an AWS KMS client per region.
+type kmsClient struct {
+	mu     sync.Mutex
+	client *cloudkms.Client
+	aws    map[string]*awskms.Client
+}
+
+func (c *kmsClient) get(ctx context.Context) (*cloudkms.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.client == nil {
+		client, err := cloudkms.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.client = client
+	}
+
+	return c.client, nil
+}
+
+// getAWS returns the AWS KMS client of the given region, configured from the default AWS credential chain.
+func (c *kmsClient) getAWS(ctx context.Context, region string) (*awskms.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.aws[region]; ok {
+		return client, nil
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.aws == nil {
+		c.aws = make(map[string]*awskms.Client)
+	}
+	client := awskms.NewClient(cfg)
+	c.aws[region] = client
+
+	return client, nil
+}
+
+func (c *kmsClient) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// AWS KMS clients hold no connections
+	c.aws = nil
+
+	if c.client == nil {
+		return nil
+	}
+
+	err := c.client.KMSClient().Close()
+	c.client = nil
+	return err
+}
+
+type rawAccount struct {
+	Address string          `json:"address"`
+	Key     json.RawMessage `json:"key"`
+}
+
+type rawAccountKey struct {
+	Type       KeyType `json:"type"`
+	Index      uint32  `json:"index"`
+	SigAlgo    string  `json:"signatureAlgorithm"`
+	HashAlgo   string  `json:"hashAlgorithm"`
+	PrivateKey string  `json:"privateKey"`
+	Location   string  `json:"location"`
+	ResourceID string  `json:"resourceID"`
+}
+
+// account parses an account whose key is given either as a hex-encoded private key or as an object
+// describing the key source.
+func (a rawAccount) account(name string) (Account, error) {
+	address, err := parseAddress(a.Address)
+	if err != nil {
+		return Account{}, fmt.Errorf("config: invalid address of account %s: %w", name, err)
+	}
+
+	key := rawAccountKey{Type: KeyTypeHex}
+	if err := json.Unmarshal(a.Key, &key.PrivateKey); err != nil {
+		if err := json.Unmarshal(a.Key, &key); err != nil {
+			return Account{}, fmt.Errorf("config: invalid key of account %s: %w", name, err)
+		}
+	}
+
+	accountKey := AccountKey{
+		Type:       key.Type,
+		Index:      key.Index,
+		SigAlgo:    crypto.ECDSA_P256,
+		HashAlgo:   crypto.SHA3_256,
+		PrivateKey: key.PrivateKey,
+		Location:   key.Location,
+		ResourceID: key.ResourceID,
+	}
+
+	if accountKey.Type == "" {
+		accountKey.Type = KeyTypeHex
+	}
+
+	// KMS keys only support SHA2-256
+	if accountKey.Type == KeyTypeGoogleKMS || accountKey.Type == KeyTypeAWSKMS {
+		accountKey.HashAlgo = crypto.SHA2_256
+	}
+
+	if key.SigAlgo != "" {
+		accountKey.SigAlgo = parseSignatureAlgorithm(key.SigAlgo)
+		if accountKey.SigAlgo == crypto.UnknownSignatureAlgorithm {
+			return Account{}, fmt.Errorf("config: unknown signature algorithm %s of account %s", key.SigAlgo, name)
+		}
+	}
+
+	if key.HashAlgo != "" {
+		accountKey.HashAlgo = parseHashAlgorithm(key.HashAlgo)
+		if accountKey.HashAlgo == crypto.UnknownHashAlgorithm {
+			return Account{}, fmt.Errorf("config: unknown hash algorithm %s of account %s", key.HashAlgo, name)
+		}
+	}
+
+	return Account{
+		Name:    name,
+		Address: address,
+		Key:     accountKey,
+	}, nil
+}
+
+// parseSignatureAlgorithm parses a signature algorithm name, ignoring case.
+func parseSignatureAlgorithm(s string) crypto.SignatureAlgorithm {
+	for _, algo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1, crypto.BLS_BLS12_381} {
+		if strings.EqualFold(s, algo.String()) {
+			return algo
+		}
+	}
+	return crypto.UnknownSignatureAlgorithm
+}
+
+// parseHashAlgorithm parses a hash algorithm name, ignoring case.
+func parseHashAlgorithm(s string) crypto.HashAlgorithm {
+	for _, algo := range []crypto.HashAlgorithm{crypto.SHA2_256, crypto.SHA3_256, crypto.SHA2_384, crypto.SHA3_384, crypto.Keccak256, crypto.KMAC128} {
+		if strings.EqualFold(s, algo.String()) {
+			return algo
+		}
+	}
+	return crypto.UnknownHashAlgorithm
+}
+
+// Signer returns a signer for the key.
+//
+// For KMS keys, ctx is used to fetch the public key and for signatures made through crypto.Signer.Sign.
+// The KMS client is created on first use and shared by all keys of the configuration the key was
+// loaded from. It is owned by that configuration: Google Cloud KMS signers stop working once Config.Close
+// is called. AWS KMS clients use the default AWS credential chain and the region of the key ARN.
+// ErrNoKMSClient is returned for KMS keys that were not loaded with Load or Parse.
+func (k AccountKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	switch k.Type {
+	case KeyTypeHex:
+		return k.inMemorySigner(expandEnv(k.PrivateKey))
+
+	case KeyTypeFile:
+		location := k.Location
+		if k.dir != "" && !filepath.IsAbs(location) {
+			location = filepath.Join(k.dir, location)
+		}
+
+		data, err := os.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("config: failed to read key file %s: %w", k.Location, err)
+		}
+		return k.inMemorySigner(strings.TrimSpace(string(data)))
+
+	case KeyTypeGoogleKMS:
+		key, err := cloudkms.KeyFromResourceID(k.ResourceID)
+		if err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+
+		if k.kms == nil {
+			return nil, ErrNoKMSClient
+		}
+
+		client, err := k.kms.get(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+
+		signer, err := client.SignerForKey(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+		return signer, nil
+
+	case KeyTypeAWSKMS:
+		key, err := awskms.KeyFromResourceARN(k.ResourceID)
+		if err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+
+		if k.kms == nil {
+			return nil, ErrNoKMSClient
+		}
+
+		client, err := k.kms.getAWS(ctx, key.Region)
+		if err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+
+		signer, err := client.SignerForKey(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+		return signer, nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyType, k.Type)
+	}
+}
+
+func (k AccountKey) inMemorySigner(privateKey string) (crypto.Signer, error) {
+	key, err := crypto.DecodePrivateKeyHex(k.SigAlgo, strings.TrimPrefix(privateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("config: invalid private key: %w", err)
+	}
+
+	signer, err := crypto.NewInMemorySigner(key, k.HashAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	return signer, nil
+}
+
+// expandEnv replaces a value of the form $NAME or ${NAME} with the value of the environment variable NAME.
+func expandEnv(value string) string {
+	if strings.HasPrefix(value, "$") {
+		return os.ExpandEnv(value)
+	}
+	return value
+}
+
+// Signer returns a signer for the account key.
+func (a Account) Signer(ctx context.Context) (crypto.Signer, error) {
+	signer, err := a.Key.Signer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w (account %s)", err, a.Name)
+	}
+	return signer, nil
+}
+
+// AccountKey returns the account key of the account, with its public key derived from its signer.
+//
+// The sequence number and weight are not known from the configuration: the sequence number is left
+// at zero and the weight is assumed to be the full signing threshold. Fetch the key from chain, e.g.
+// with GetAccount, before using it as the proposal key of a transaction or relying on its weight.
+func (a Account) AccountKey(ctx context.Context) (*flow.AccountKey, crypto.Signer, error) {
+	signer, err := a.Signer(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &flow.AccountKey{
+		Index:     a.Key.Index,
+		PublicKey: signer.PublicKey(),
+		SigAlgo:   signer.PublicKey().Algorithm(),
+		HashAlgo:  a.Key.HashAlgo,
+		Weight:    flow.AccountKeyWeightThreshold,
+	}, signer, nil
+}
+
+// Close closes the Google Cloud KMS client used by the signers of KMS keys, if one was created.
+//
+// Signers of Google Cloud KMS keys obtained from the configuration cannot be used after Close.
+func (c *Config) Close() error {
+	if c.kms == nil {
+		return nil
+	}
+
+	if err := c.kms.close(); err != nil {
+		return fmt.Errorf("config: failed to close KMS client: %w", err)
+	}
+
+	return nil
+}
+
+// Signer returns a signer for the account with the given name.
+func (c *Config) Signer(ctx context.Context, account string) (crypto.Signer, error) {
+	a, err := c.Account(account)
+	if err != nil {
+		return nil, err
+	}
+	return a.Signer(ctx)
+}
@@ -0,0 +1,283 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package contracts records where the Flow core and system contracts are deployed on each network.
+package contracts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/imports"
+)
+
+// Names of the core contracts.
+const (
+	FungibleToken              = "FungibleToken"
+	FungibleTokenMetadataViews = "FungibleTokenMetadataViews"
+	FungibleTokenSwitchboard   = "FungibleTokenSwitchboard"
+	Burner                     = "Burner"
+	FlowToken                  = "FlowToken"
+	FlowFees                   = "FlowFees"
+	FlowServiceAccount         = "FlowServiceAccount"
+	FlowStorageFees            = "FlowStorageFees"
+	NonFungibleToken           = "NonFungibleToken"
+	MetadataViews              = "MetadataViews"
+	ViewResolver               = "ViewResolver"
+	FlowIDTableStaking         = "FlowIDTableStaking"
+	FlowEpoch                  = "FlowEpoch"
+	FlowClusterQC              = "FlowClusterQC"
+	FlowDKG                    = "FlowDKG"
+	NodeVersionBeacon          = "NodeVersionBeacon"
+	RandomBeaconHistory        = "RandomBeaconHistory"
+	EVM                        = "EVM"
+	LockedTokens               = "LockedTokens"
+	StakingProxy               = "StakingProxy"
+	FlowStakingCollection      = "FlowStakingCollection"
+)
+
+// ErrUnsupportedChain is returned for chains the core contract addresses are not known for.
+var ErrUnsupportedChain = errors.New("contracts: unsupported chain")
+
+// Addressing state indices of the accounts created when a network is bootstrapped.
+const (
+	fungibleTokenAddressIndex = 2
+	flowTokenAddressIndex     = 3
+	flowFeesAddressIndex      = 4
+)
+
+// Accounts the core contracts are deployed to on long-lived networks, which are not generated from the
+// addressing state during bootstrapping.
+var (
+	epochAddresses = map[flow.ChainID]flow.Address{
+		flow.Mainnet: flow.HexToAddress("8624b52f9ddcd04a"),
+		flow.Testnet: flow.HexToAddress("9eca2b38b18b5dfe"),
+	}
+
+	nonFungibleTokenAddresses = map[flow.ChainID]flow.Address{
+		flow.Mainnet: flow.HexToAddress("1d7e57aa55817448"),
+		flow.Testnet: flow.HexToAddress("631e88ae7f1d7c20"),
+	}
+
+	stakingCollectionAddresses = map[flow.ChainID]flow.Address{
+		flow.Mainnet: flow.HexToAddress("8d0e87b65159ae63"),
+		flow.Testnet: flow.HexToAddress("95e019a17d0e23d7"),
+	}
+
+	stakingProxyAddresses = map[flow.ChainID]flow.Address{
+		flow.Mainnet: flow.HexToAddress("62430cf28c26d095"),
+		flow.Testnet: flow.HexToAddress("7aad92e5a0715d21"),
+	}
+)
+
+// A Contract is a contract deployed to an account.
+type Contract struct {
+	Name    string
+	Address flow.Address
+}
+
+// EventType returns the fully-qualified type of an event defined by the contract,
+// for example A.1654653399040a61.FlowToken.TokensDeposited.
+func (c Contract) EventType(event string) string {
+	return flow.NewEventTypeFactory().
+		WithAddress(c.Address).
+		WithContractName(c.Name).
+		WithEventName(event).
+		String()
+}
+
+// String returns the contract name and address, for example FlowToken (0x1654653399040a61).
+func (c Contract) String() string {
+	return fmt.Sprintf("%s (%s)", c.Name, c.Address.HexWithPrefix())
+}
+
+// CoreContracts are the core and system contracts deployed on a network.
+//
+// LockedTokens, StakingProxy and FlowStakingCollection are only deployed on Mainnet and Testnet;
+// on other networks their address is empty.
+type CoreContracts struct {
+	Chain flow.ChainID
+
+	FungibleToken              Contract
+	FungibleTokenMetadataViews Contract
+	FungibleTokenSwitchboard   Contract
+	Burner                     Contract
+	FlowToken                  Contract
+	FlowFees                   Contract
+	FlowServiceAccount         Contract
+	FlowStorageFees            Contract
+	NonFungibleToken           Contract
+	MetadataViews              Contract
+	ViewResolver               Contract
+	FlowIDTableStaking         Contract
+	FlowEpoch                  Contract
+	FlowClusterQC              Contract
+	FlowDKG                    Contract
+	NodeVersionBeacon          Contract
+	RandomBeaconHistory        Contract
+	EVM                        Contract
+	LockedTokens               Contract
+	StakingProxy               Contract
+	FlowStakingCollection      Contract
+}
+
+// SupportedChains are the chains core contract addresses are known for.
+var SupportedChains = []flow.ChainID{
+	flow.Mainnet,
+	flow.Testnet,
+	flow.Emulator,
+	flow.Localnet,
+	flow.Benchnet,
+	flow.BftTestnet,
+}
+
+// ForChain returns the core contracts of the given chain.
+//
+// ErrUnsupportedChain is returned if the chain is not one of SupportedChains.
+func ForChain(chain flow.ChainID) (*CoreContracts, error) {
+	if !isSupported(chain) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedChain, chain)
+	}
+
+	service := flow.ServiceAddress(chain)
+	fungibleToken := generatedAddress(chain, fungibleTokenAddressIndex)
+
+	// transient networks deploy the epoch and NFT contracts to the service account
+	epoch, ok := epochAddresses[chain]
+	if !ok {
+		epoch = service
+	}
+	nonFungibleToken, ok := nonFungibleTokenAddresses[chain]
+	if !ok {
+		nonFungibleToken = service
+	}
+	stakingCollection := stakingCollectionAddresses[chain]
+
+	return &CoreContracts{
+		Chain:                      chain,
+		FungibleToken:              Contract{FungibleToken, fungibleToken},
+		FungibleTokenMetadataViews: Contract{FungibleTokenMetadataViews, fungibleToken},
+		FungibleTokenSwitchboard:   Contract{FungibleTokenSwitchboard, fungibleToken},
+		Burner:                     Contract{Burner, fungibleToken},
+		FlowToken:                  Contract{FlowToken, generatedAddress(chain, flowTokenAddressIndex)},
+		FlowFees:                   Contract{FlowFees, generatedAddress(chain, flowFeesAddressIndex)},
+		FlowServiceAccount:         Contract{FlowServiceAccount, service},
+		FlowStorageFees:            Contract{FlowStorageFees, service},
+		NonFungibleToken:           Contract{NonFungibleToken, nonFungibleToken},
+		MetadataViews:              Contract{MetadataViews, nonFungibleToken},
+		ViewResolver:               Contract{ViewResolver, nonFungibleToken},
+		FlowIDTableStaking:         Contract{FlowIDTableStaking, epoch},
+		FlowEpoch:                  Contract{FlowEpoch, epoch},
+		FlowClusterQC:              Contract{FlowClusterQC, epoch},
+		FlowDKG:                    Contract{FlowDKG, epoch},
+		NodeVersionBeacon:          Contract{NodeVersionBeacon, service},
+		RandomBeaconHistory:        Contract{RandomBeaconHistory, service},
+		EVM:                        Contract{EVM, service},
+		LockedTokens:               Contract{LockedTokens, stakingCollection},
+		StakingProxy:               Contract{StakingProxy, stakingProxyAddresses[chain]},
+		FlowStakingCollection:      Contract{FlowStakingCollection, stakingCollection},
+	}, nil
+}
+
+// MustForChain returns the core contracts of the given chain, and panics if the chain is not supported.
+func MustForChain(chain flow.ChainID) *CoreContracts {
+	contracts, err := ForChain(chain)
+	if err != nil {
+		panic(err)
+	}
+	return contracts
+}
+
+func isSupported(chain flow.ChainID) bool {
+	for _, supported := range SupportedChains {
+		if chain == supported {
+			return true
+		}
+	}
+	return false
+}
+
+func generatedAddress(chain flow.ChainID, index uint) flow.Address {
+	return flow.NewAddressGenerator(chain).SetIndex(index).Address()
+}
+
+// All returns the contracts deployed on the chain, omitting those with an empty address.
+func (c *CoreContracts) All() []Contract {
+	all := []Contract{
+		c.FungibleToken,
+		c.FungibleTokenMetadataViews,
+		c.FungibleTokenSwitchboard,
+		c.Burner,
+		c.FlowToken,
+		c.FlowFees,
+		c.FlowServiceAccount,
+		c.FlowStorageFees,
+		c.NonFungibleToken,
+		c.MetadataViews,
+		c.ViewResolver,
+		c.FlowIDTableStaking,
+		c.FlowEpoch,
+		c.FlowClusterQC,
+		c.FlowDKG,
+		c.NodeVersionBeacon,
+		c.RandomBeaconHistory,
+		c.EVM,
+		c.LockedTokens,
+		c.StakingProxy,
+		c.FlowStakingCollection,
+	}
+
+	deployed := all[:0]
+	for _, contract := range all {
+		if contract.Address != flow.EmptyAddress {
+			deployed = append(deployed, contract)
+		}
+	}
+
+	return deployed
+}
+
+// ByName returns the contract with the given name, if it is deployed on the chain.
+func (c *CoreContracts) ByName(name string) (Contract, bool) {
+	for _, contract := range c.All() {
+		if contract.Name == name {
+			return contract, true
+		}
+	}
+	return Contract{}, false
+}
+
+// Register adds the contracts deployed on the chain to an import registry.
+func (c *CoreContracts) Register(registry *imports.Registry) *imports.Registry {
+	for _, contract := range c.All() {
+		registry.Register(c.Chain, contract.Name, contract.Address)
+	}
+	return registry
+}
+
+// ImportRegistry returns an import registry holding the core contracts of all supported chains.
+//
+// Additional contracts can be registered on the returned registry.
+func ImportRegistry() *imports.Registry {
+	registry := imports.NewRegistry()
+	for _, chain := range SupportedChains {
+		MustForChain(chain).Register(registry)
+	}
+	return registry
+}
@@ -0,0 +1,164 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package contracts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
+	"github.com/onflow/flow-go-sdk/imports"
+)
+
+func TestForChain(t *testing.T) {
+	tests := []struct {
+		chain         flow.ChainID
+		service       string
+		fungibleToken string
+		flowToken     string
+		flowFees      string
+		nft           string
+		epoch         string
+		staking       string
+		stakingProxy  string
+	}{
+		{
+			chain:         flow.Mainnet,
+			service:       "e467b9dd11fa00df",
+			fungibleToken: "f233dcee88fe0abe",
+			flowToken:     "1654653399040a61",
+			flowFees:      "f919ee77447b7497",
+			nft:           "1d7e57aa55817448",
+			epoch:         "8624b52f9ddcd04a",
+			staking:       "8d0e87b65159ae63",
+			stakingProxy:  "62430cf28c26d095",
+		},
+		{
+			chain:         flow.Testnet,
+			service:       "8c5303eaa26202d6",
+			fungibleToken: "9a0766d93b6608b7",
+			flowToken:     "7e60df042a9c0868",
+			flowFees:      "912d5440f7e3769e",
+			nft:           "631e88ae7f1d7c20",
+			epoch:         "9eca2b38b18b5dfe",
+			staking:       "95e019a17d0e23d7",
+			stakingProxy:  "7aad92e5a0715d21",
+		},
+		{
+			chain:         flow.Emulator,
+			service:       "f8d6e0586b0a20c7",
+			fungibleToken: "ee82856bf20e2aa6",
+			flowToken:     "0ae53cb6e3f42a79",
+			flowFees:      "e5a8b7f23e8b548f",
+			nft:           "f8d6e0586b0a20c7",
+			epoch:         "f8d6e0586b0a20c7",
+			staking:       "0000000000000000",
+			stakingProxy:  "0000000000000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.chain.String(), func(t *testing.T) {
+			core, err := contracts.ForChain(tt.chain)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.chain, core.Chain)
+			assert.Equal(t, flow.ServiceAddress(tt.chain), core.FlowServiceAccount.Address)
+			assert.Equal(t, tt.service, core.FlowServiceAccount.Address.Hex())
+			assert.Equal(t, tt.service, core.EVM.Address.Hex())
+			assert.Equal(t, tt.fungibleToken, core.FungibleToken.Address.Hex())
+			assert.Equal(t, tt.flowToken, core.FlowToken.Address.Hex())
+			assert.Equal(t, tt.flowFees, core.FlowFees.Address.Hex())
+			assert.Equal(t, tt.nft, core.NonFungibleToken.Address.Hex())
+			assert.Equal(t, tt.nft, core.MetadataViews.Address.Hex())
+			assert.Equal(t, tt.epoch, core.FlowEpoch.Address.Hex())
+			assert.Equal(t, tt.epoch, core.FlowIDTableStaking.Address.Hex())
+			assert.Equal(t, tt.staking, core.FlowStakingCollection.Address.Hex())
+			assert.Equal(t, tt.staking, core.LockedTokens.Address.Hex())
+			assert.Equal(t, tt.stakingProxy, core.StakingProxy.Address.Hex())
+		})
+	}
+
+	t.Run("Localnet", func(t *testing.T) {
+		core, err := contracts.ForChain(flow.Localnet)
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.HexToAddress("ee82856bf20e2aa6"), core.FungibleToken.Address)
+		assert.Equal(t, flow.EmptyAddress, core.FlowStakingCollection.Address)
+
+		_, ok := core.ByName(contracts.FlowStakingCollection)
+		assert.False(t, ok)
+	})
+
+	t.Run("Unsupported chain", func(t *testing.T) {
+		_, err := contracts.ForChain(flow.MonotonicEmulator)
+		assert.ErrorIs(t, err, contracts.ErrUnsupportedChain)
+
+		assert.Panics(t, func() { contracts.MustForChain("flow-unknown") })
+	})
+}
+
+func TestCoreContracts_EventTypes(t *testing.T) {
+	events := contracts.MustForChain(flow.Mainnet).EventTypes()
+
+	assert.Equal(t, "A.1654653399040a61.FlowToken.TokensDeposited", events.FlowTokenTokensDeposited)
+	assert.Equal(t, "A.f919ee77447b7497.FlowFees.FeesDeducted", events.FlowFeesFeesDeducted)
+	assert.Equal(t, "A.f233dcee88fe0abe.FungibleToken.Withdrawn", events.FungibleTokenWithdrawn)
+	assert.Equal(t, "A.e467b9dd11fa00df.EVM.TransactionExecuted", events.EVMTransactionExecuted)
+
+	testnet := contracts.MustForChain(flow.Testnet)
+	assert.Equal(t, "A.9eca2b38b18b5dfe.FlowEpoch.EpochSetup", testnet.EventTypes().FlowEpochEpochSetup)
+	assert.Equal(t, "A.7e60df042a9c0868.FlowToken.TokensMinted", testnet.FlowToken.EventType("TokensMinted"))
+}
+
+func TestCoreContracts_ByName(t *testing.T) {
+	core := contracts.MustForChain(flow.Mainnet)
+
+	contract, ok := core.ByName(contracts.FlowToken)
+	require.True(t, ok)
+	assert.Equal(t, core.FlowToken, contract)
+	assert.Equal(t, "FlowToken (0x1654653399040a61)", contract.String())
+
+	_, ok = core.ByName("Unknown")
+	assert.False(t, ok)
+
+	assert.Len(t, core.All(), 21)
+}
+
+func TestImportRegistry(t *testing.T) {
+	resolver := imports.NewResolver(flow.Testnet, contracts.ImportRegistry())
+
+	resolved, err := resolver.Resolve([]byte(`import "FlowToken"
+import "NonFungibleToken"`))
+	require.NoError(t, err)
+
+	assert.Equal(t, `import FlowToken from 0x7e60df042a9c0868
+import NonFungibleToken from 0x631e88ae7f1d7c20`, string(resolved))
+
+	address, ok := contracts.ImportRegistry().Address(flow.Mainnet, contracts.StakingProxy)
+	require.True(t, ok)
+	assert.Equal(t, "62430cf28c26d095", address.Hex())
+
+	resolved, err = imports.NewResolver(flow.Testnet, contracts.ImportRegistry()).Resolve([]byte(`import "StakingProxy"`))
+	require.NoError(t, err)
+	assert.Equal(t, `import StakingProxy from 0x7aad92e5a0715d21`, string(resolved))
+}
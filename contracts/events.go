@@ -0,0 +1,102 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package contracts
+
+// EventTypes are the fully-qualified types of frequently used core contract events on a chain.
+type EventTypes struct {
+	FungibleTokenDeposited string
+	FungibleTokenWithdrawn string
+	FungibleTokenBurned    string
+
+	FlowTokenTokensDeposited string
+	FlowTokenTokensWithdrawn string
+	FlowTokenTokensMinted    string
+	FlowTokenTokensBurned    string
+
+	FlowFeesFeesDeducted    string
+	FlowFeesTokensDeposited string
+	FlowFeesTokensWithdrawn string
+
+	NonFungibleTokenDeposited string
+	NonFungibleTokenWithdrawn string
+	NonFungibleTokenUpdated   string
+
+	FlowIDTableStakingTokensCommitted      string
+	FlowIDTableStakingTokensStaked         string
+	FlowIDTableStakingTokensUnstaked       string
+	FlowIDTableStakingRewardsPaid          string
+	FlowIDTableStakingDelegatorRewardsPaid string
+	FlowIDTableStakingNewDelegatorCreated  string
+
+	FlowEpochEpochSetup   string
+	FlowEpochEpochCommit  string
+	FlowEpochEpochRecover string
+
+	NodeVersionBeaconVersionBeacon string
+
+	EVMTransactionExecuted        string
+	EVMBlockExecuted              string
+	EVMCadenceOwnedAccountCreated string
+	EVMFLOWTokensDeposited        string
+	EVMFLOWTokensWithdrawn        string
+}
+
+// EventTypes returns the types of frequently used core contract events on the chain,
+// for example A.1654653399040a61.FlowToken.TokensDeposited on Mainnet.
+//
+// Types of other events can be derived with Contract.EventType.
+func (c *CoreContracts) EventTypes() EventTypes {
+	return EventTypes{
+		FungibleTokenDeposited: c.FungibleToken.EventType("Deposited"),
+		FungibleTokenWithdrawn: c.FungibleToken.EventType("Withdrawn"),
+		FungibleTokenBurned:    c.FungibleToken.EventType("Burned"),
+
+		FlowTokenTokensDeposited: c.FlowToken.EventType("TokensDeposited"),
+		FlowTokenTokensWithdrawn: c.FlowToken.EventType("TokensWithdrawn"),
+		FlowTokenTokensMinted:    c.FlowToken.EventType("TokensMinted"),
+		FlowTokenTokensBurned:    c.FlowToken.EventType("TokensBurned"),
+
+		FlowFeesFeesDeducted:    c.FlowFees.EventType("FeesDeducted"),
+		FlowFeesTokensDeposited: c.FlowFees.EventType("TokensDeposited"),
+		FlowFeesTokensWithdrawn: c.FlowFees.EventType("TokensWithdrawn"),
+
+		NonFungibleTokenDeposited: c.NonFungibleToken.EventType("Deposited"),
+		NonFungibleTokenWithdrawn: c.NonFungibleToken.EventType("Withdrawn"),
+		NonFungibleTokenUpdated:   c.NonFungibleToken.EventType("Updated"),
+
+		FlowIDTableStakingTokensCommitted:      c.FlowIDTableStaking.EventType("TokensCommitted"),
+		FlowIDTableStakingTokensStaked:         c.FlowIDTableStaking.EventType("TokensStaked"),
+		FlowIDTableStakingTokensUnstaked:       c.FlowIDTableStaking.EventType("TokensUnstaked"),
+		FlowIDTableStakingRewardsPaid:          c.FlowIDTableStaking.EventType("RewardsPaid"),
+		FlowIDTableStakingDelegatorRewardsPaid: c.FlowIDTableStaking.EventType("DelegatorRewardsPaid"),
+		FlowIDTableStakingNewDelegatorCreated:  c.FlowIDTableStaking.EventType("NewDelegatorCreated"),
+
+		FlowEpochEpochSetup:   c.FlowEpoch.EventType("EpochSetup"),
+		FlowEpochEpochCommit:  c.FlowEpoch.EventType("EpochCommit"),
+		FlowEpochEpochRecover: c.FlowEpoch.EventType("EpochRecover"),
+
+		NodeVersionBeaconVersionBeacon: c.NodeVersionBeacon.EventType("VersionBeacon"),
+
+		EVMTransactionExecuted:        c.EVM.EventType("TransactionExecuted"),
+		EVMBlockExecuted:              c.EVM.EventType("BlockExecuted"),
+		EVMCadenceOwnedAccountCreated: c.EVM.EventType("CadenceOwnedAccountCreated"),
+		EVMFLOWTokensDeposited:        c.EVM.EventType("FLOWTokensDeposited"),
+		EVMFLOWTokensWithdrawn:        c.EVM.EventType("FLOWTokensWithdrawn"),
+	}
+}
@@ -71,8 +71,13 @@ func KeyFromResourceARN(resourceARN string) (Key, error) {
 		return key, fmt.Errorf("awskms: wrong format for the resourceARN: %s", resourceARN)
 	}
 
+	resource := strings.Split(spiltedARN[5], "/")
+	if len(resource) != 2 {
+		return key, fmt.Errorf("awskms: wrong format for the resourceARN: %s", resourceARN)
+	}
+
 	key.Region, key.Account = spiltedARN[3], spiltedARN[4]
-	key.KeyID = strings.Split(spiltedARN[5], "/")[1]
+	key.KeyID = resource[1]
 
 	return key, nil
 }
@@ -145,4 +145,11 @@ func TestManualKMSSigning(t *testing.T) {
 		signAndVerify(t, kmsPreHashLimit+1)
 	})
 
+	t.Run("cancelled context", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := signer.SignWithContext(cancelled, make([]byte, 32))
+		assert.Error(t, err)
+	})
 }
@@ -31,9 +31,9 @@ import (
 	"github.com/onflow/flow-go-sdk/crypto/internal"
 )
 
-var _ crypto.Signer = (*Signer)(nil)
+var _ crypto.ContextSigner = (*Signer)(nil)
 
-// Signer is a Google Cloud KMS implementation of crypto.Signer.
+// Signer is a Google Cloud KMS implementation of crypto.Signer and crypto.ContextSigner.
 type Signer struct {
 	ctx    context.Context
 	client *kms.KeyManagementClient
@@ -71,8 +71,17 @@ func (c *Client) SignerForKey(
 
 // Sign signs the given message using the KMS signing key for this signer.
 //
-// Reference: https://cloud.google.com/kms/docs/create-validate-signatures
+// The request to KMS uses the context passed to SignerForKey. Use SignWithContext to
+// apply a deadline or cancellation to an individual signature.
 func (s *Signer) Sign(message []byte) ([]byte, error) {
+	return s.SignWithContext(s.ctx, message)
+}
+
+// SignWithContext signs the given message using the KMS signing key for this signer,
+// using ctx for the request to KMS.
+//
+// Reference: https://cloud.google.com/kms/docs/create-validate-signatures
+func (s *Signer) SignWithContext(ctx context.Context, message []byte) ([]byte, error) {
 
 	// Google KMS supports signing messages without pre-hashing
 	// up to 65536 bytes. Beyond that limit, messages must be
@@ -102,7 +111,7 @@ func (s *Signer) Sign(message []byte) ([]byte, error) {
 			DigestCrc32C: checksum(hash),
 		}
 	}
-	result, err := s.client.AsymmetricSign(s.ctx, request)
+	result, err := s.client.AsymmetricSign(ctx, request)
 	if err != nil {
 		return nil, fmt.Errorf("cloudkms: failed to sign: %w", err)
 	}
@@ -19,6 +19,7 @@
 package crypto
 
 import (
+	"context"
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/hex"
@@ -93,6 +94,65 @@ type Signer interface {
 	PublicKey() PublicKey
 }
 
+// A ContextSigner is a Signer whose signing operation can be bound to a context.
+//
+// Signers that perform network calls, such as remote or KMS signers, should implement ContextSigner
+// so that callers can apply deadlines and cancellation to each signature.
+type ContextSigner interface {
+	Signer
+	// SignWithContext signs the given message with this signer, aborting if ctx is done.
+	SignWithContext(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// NewContextSigner adapts a Signer to the ContextSigner interface.
+//
+// If the signer already implements ContextSigner it is returned unchanged. Otherwise the returned signer
+// checks the context before signing, but cannot interrupt a signature in progress.
+func NewContextSigner(signer Signer) ContextSigner {
+	if contextSigner, ok := signer.(ContextSigner); ok {
+		return contextSigner
+	}
+
+	return contextSigner{signer}
+}
+
+type contextSigner struct {
+	Signer
+}
+
+func (s contextSigner) SignWithContext(ctx context.Context, message []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.Sign(message)
+}
+
+// NewSignerWithContext adapts a ContextSigner to the plain Signer interface by binding it to ctx.
+//
+// Every call to Sign on the returned signer uses ctx.
+func NewSignerWithContext(ctx context.Context, signer ContextSigner) Signer {
+	return boundSigner{ctx: ctx, signer: signer}
+}
+
+type boundSigner struct {
+	ctx    context.Context
+	signer ContextSigner
+}
+
+func (s boundSigner) Sign(message []byte) ([]byte, error) {
+	return s.signer.SignWithContext(s.ctx, message)
+}
+
+func (s boundSigner) PublicKey() PublicKey {
+	return s.signer.PublicKey()
+}
+
+// SignWithContext signs message with signer, passing ctx through if the signer implements ContextSigner.
+func SignWithContext(ctx context.Context, signer Signer, message []byte) ([]byte, error) {
+	return NewContextSigner(signer).SignWithContext(ctx, message)
+}
+
 // An InMemorySigner is a signer that generates signatures using an in-memory private key.
 //
 // InMemorySigner implements simple signing that does not protect the private key against
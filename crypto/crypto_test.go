@@ -19,6 +19,7 @@
 package crypto_test
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -141,6 +142,78 @@ func makeSeed(t *testing.T, l int) []byte {
 	return seed
 }
 
+// recordingSigner is a ContextSigner that records the context it was called with.
+type recordingSigner struct {
+	crypto.Signer
+	ctx context.Context
+}
+
+func (s *recordingSigner) SignWithContext(ctx context.Context, message []byte) ([]byte, error) {
+	s.ctx = ctx
+	return s.Sign(message)
+}
+
+func TestContextSigner(t *testing.T) {
+	seed := make([]byte, crypto.MinSeedLength)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+
+	sk, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, seed)
+	require.NoError(t, err)
+	pk := sk.PublicKey()
+
+	signer, err := crypto.NewInMemorySigner(sk, crypto.SHA3_256)
+	require.NoError(t, err)
+
+	message := []byte("message")
+	hasher := crypto.NewSHA3_256()
+
+	t.Run("Plain signer", func(t *testing.T) {
+		contextSigner := crypto.NewContextSigner(signer)
+
+		sig, err := contextSigner.SignWithContext(context.Background(), message)
+		require.NoError(t, err)
+
+		valid, err := pk.Verify(sig, message, hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Plain signer with done context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := crypto.SignWithContext(ctx, signer, message)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Context signer", func(t *testing.T) {
+		recorder := &recordingSigner{Signer: signer}
+		assert.Same(t, recorder, crypto.NewContextSigner(recorder))
+
+		type key struct{}
+		ctx := context.WithValue(context.Background(), key{}, "value")
+
+		_, err := crypto.SignWithContext(ctx, recorder, message)
+		require.NoError(t, err)
+		assert.Equal(t, ctx, recorder.ctx)
+	})
+
+	t.Run("Bound context", func(t *testing.T) {
+		recorder := &recordingSigner{Signer: signer}
+
+		type key struct{}
+		ctx := context.WithValue(context.Background(), key{}, "value")
+
+		bound := crypto.NewSignerWithContext(ctx, recorder)
+		assert.Equal(t, pk, bound.PublicKey())
+
+		_, err := bound.Sign(message)
+		require.NoError(t, err)
+		assert.Equal(t, ctx, recorder.ctx)
+	})
+}
+
 func TestDecodePublicKeyPEM(t *testing.T) {
 
 	const pemECDSAKeySECP256K1 = `-----BEGIN -----
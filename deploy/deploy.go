@@ -0,0 +1,327 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package deploy plans the deployment of contracts that import each other to a single account.
+//
+// A Plan orders the contracts so every contract is deployed after the contracts it imports,
+// and decides for each contract whether it must be added, updated or left unchanged:
+//
+//	plan, err := deploy.NewPlan(ctx, client, address, contracts)
+//	for _, tx := range plan.Transactions() {
+//		// set the proposal key, payer and reference block, sign and send tx in order
+//	}
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/imports"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+var (
+	// ErrImportCycle is returned when contracts import each other in a cycle.
+	ErrImportCycle = errors.New("deploy: import cycle")
+
+	// ErrDependentBatch is returned when a single transaction is requested for contracts
+	// that import each other.
+	ErrDependentBatch = errors.New("deploy: contracts that import each other cannot be deployed in one transaction")
+)
+
+// AccountClient is the subset of the Access API used to read the deployed contracts.
+type AccountClient interface {
+	GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error)
+}
+
+// Action is what a plan does with a contract.
+type Action int
+
+const (
+	// ActionSkip leaves a deployed contract with unchanged code as is.
+	ActionSkip Action = iota
+	// ActionAdd deploys a new contract.
+	ActionAdd
+	// ActionUpdate replaces the code of a deployed contract.
+	ActionUpdate
+)
+
+// String returns the name of the action.
+func (a Action) String() string {
+	switch a {
+	case ActionSkip:
+		return "skip"
+	case ActionAdd:
+		return "add"
+	case ActionUpdate:
+		return "update"
+	default:
+		return fmt.Sprintf("Action(%d)", int(a))
+	}
+}
+
+// A Step is one contract of a plan.
+type Step struct {
+	// Contract holds the code that is deployed, with its imports resolved.
+	Contract templates.Contract
+	Action   Action
+	// Dependencies are the contracts of the plan the contract imports.
+	Dependencies []string
+}
+
+// A Plan is an ordered list of contract deployments to one account.
+type Plan struct {
+	Address flow.Address
+	// Steps are sorted so that every contract comes after its dependencies.
+	Steps []Step
+}
+
+// An Option configures NewPlan.
+type Option func(*config)
+
+type config struct {
+	registry *imports.Registry
+	chain    flow.ChainID
+}
+
+// WithImports resolves the imports of the contracts with the addresses registered for chain.
+//
+// Contracts of the plan are resolved to the plan's account, so they can import each other by name.
+func WithImports(registry *imports.Registry, chain flow.ChainID) Option {
+	return func(config *config) {
+		config.registry = registry
+		config.chain = chain
+	}
+}
+
+// Sort orders contracts so that every contract comes after the contracts of the set it imports.
+//
+// Contracts that do not depend on each other keep their relative order.
+// An error wrapping ErrImportCycle is returned if the contracts import each other in a cycle.
+func Sort(contracts []templates.Contract) ([]templates.Contract, error) {
+	steps, err := sortSteps(contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]templates.Contract, len(steps))
+	for i, step := range steps {
+		sorted[i] = step.Contract
+	}
+	return sorted, nil
+}
+
+func sortSteps(contracts []templates.Contract) ([]Step, error) {
+	index := make(map[string]int, len(contracts))
+	for i, contract := range contracts {
+		if _, ok := index[contract.Name]; ok {
+			return nil, fmt.Errorf("deploy: contract %s is listed more than once", contract.Name)
+		}
+		index[contract.Name] = i
+	}
+
+	dependencies := make([][]string, len(contracts))
+	for i, contract := range contracts {
+		for _, name := range imports.Imported(contract.SourceBytes()) {
+			if _, ok := index[name]; ok && name != contract.Name {
+				dependencies[i] = append(dependencies[i], name)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(contracts))
+	steps := make([]Step, 0, len(contracts))
+
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %s", ErrImportCycle, strings.Join(append(path, contracts[i].Name), " -> "))
+		}
+
+		state[i] = visiting
+		for _, name := range dependencies[i] {
+			if err := visit(index[name], append(path, contracts[i].Name)); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+
+		steps = append(steps, Step{Contract: contracts[i], Dependencies: dependencies[i]})
+		return nil
+	}
+
+	for i := range contracts {
+		if err := visit(i, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return steps, nil
+}
+
+// NewPlan orders the contracts by their imports and compares them with the contracts
+// deployed to the account to decide whether each one is added, updated or skipped.
+func NewPlan(
+	ctx context.Context,
+	client AccountClient,
+	address flow.Address,
+	contracts []templates.Contract,
+	opts ...Option,
+) (*Plan, error) {
+	config := config{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	steps, err := sortSteps(contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.registry != nil {
+		registry := imports.NewRegistry().RegisterAll(config.chain, config.registry.Contracts(config.chain))
+		for _, contract := range contracts {
+			registry.Register(config.chain, contract.Name, address)
+		}
+		resolver := imports.NewResolver(config.chain, registry)
+
+		for i := range steps {
+			code, err := resolver.Resolve(steps[i].Contract.SourceBytes())
+			if err != nil {
+				return nil, fmt.Errorf("deploy: cannot resolve imports of %s: %w", steps[i].Contract.Name, err)
+			}
+			steps[i].Contract.Source = string(code)
+		}
+	}
+
+	account, err := client.GetAccountAtLatestBlock(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("deploy: failed to get account %s: %w", address, err)
+	}
+
+	for i := range steps {
+		deployed, ok := account.Contracts[steps[i].Contract.Name]
+		switch {
+		case !ok:
+			steps[i].Action = ActionAdd
+		case bytes.Equal(deployed, steps[i].Contract.SourceBytes()):
+			steps[i].Action = ActionSkip
+		default:
+			steps[i].Action = ActionUpdate
+		}
+	}
+
+	return &Plan{Address: address, Steps: steps}, nil
+}
+
+// Pending returns the steps that add or update a contract.
+func (p *Plan) Pending() []Step {
+	var pending []Step
+	for _, step := range p.Steps {
+		if step.Action != ActionSkip {
+			pending = append(pending, step)
+		}
+	}
+	return pending
+}
+
+// Transactions returns one transaction per pending step, in deployment order.
+//
+// Each transaction must be sealed before the next one is sent, and the account must authorize all of them.
+func (p *Plan) Transactions() []*flow.Transaction {
+	pending := p.Pending()
+
+	txs := make([]*flow.Transaction, len(pending))
+	for i, step := range pending {
+		if step.Action == ActionAdd {
+			txs[i] = templates.AddAccountContract(p.Address, step.Contract)
+		} else {
+			txs[i] = templates.UpdateAccountContract(p.Address, step.Contract)
+		}
+	}
+	return txs
+}
+
+const deployContractsTemplate = `transaction(names: [String], codes: [String], updates: [Bool]) {
+    prepare(signer: auth(AddContract, UpdateContract) &Account) {
+        var i = 0
+        while i < names.length {
+            let code = codes[i].decodeHex()
+            if updates[i] {
+                signer.contracts.update(name: names[i], code: code)
+            } else {
+                signer.contracts.add(name: names[i], code: code)
+            }
+            i = i + 1
+        }
+    }
+}
+`
+
+// Transaction returns a single transaction performing all pending steps, or nil if there are none.
+//
+// Contracts deployed by a transaction cannot be imported until it is committed, so an error wrapping
+// ErrDependentBatch is returned if a pending contract imports another pending contract.
+func (p *Plan) Transaction() (*flow.Transaction, error) {
+	pending := p.Pending()
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	inBatch := make(map[string]struct{}, len(pending))
+	for _, step := range pending {
+		inBatch[step.Contract.Name] = struct{}{}
+	}
+
+	names := make([]cadence.Value, len(pending))
+	codes := make([]cadence.Value, len(pending))
+	updates := make([]cadence.Value, len(pending))
+
+	for i, step := range pending {
+		for _, dependency := range step.Dependencies {
+			if _, ok := inBatch[dependency]; ok {
+				return nil, fmt.Errorf("%w: %s imports %s", ErrDependentBatch, step.Contract.Name, dependency)
+			}
+		}
+
+		names[i] = cadence.String(step.Contract.Name)
+		codes[i] = cadence.String(step.Contract.SourceHex())
+		updates[i] = cadence.NewBool(step.Action == ActionUpdate)
+	}
+
+	return flow.NewTransaction().
+		SetScript([]byte(deployContractsTemplate)).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewArray(names))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewArray(codes))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewArray(updates))).
+		AddAuthorizer(p.Address), nil
+}
@@ -0,0 +1,205 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onflow/cadence/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/mocks"
+	"github.com/onflow/flow-go-sdk/deploy"
+	"github.com/onflow/flow-go-sdk/imports"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+var (
+	base = templates.Contract{
+		Name:   "Base",
+		Source: "access(all) contract Base {}",
+	}
+	middle = templates.Contract{
+		Name:   "Middle",
+		Source: "import \"Base\"\n\naccess(all) contract Middle {}",
+	}
+	top = templates.Contract{
+		Name:   "Top",
+		Source: "import \"Middle\"\nimport \"Base\"\nimport \"FungibleToken\"\n\naccess(all) contract Top {}",
+	}
+)
+
+func names(contracts []templates.Contract) []string {
+	result := make([]string, len(contracts))
+	for i, contract := range contracts {
+		result[i] = contract.Name
+	}
+	return result
+}
+
+func TestSort(t *testing.T) {
+	t.Run("Dependencies first", func(t *testing.T) {
+		sorted, err := deploy.Sort([]templates.Contract{top, middle, base})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Base", "Middle", "Top"}, names(sorted))
+	})
+
+	t.Run("Keeps independent order", func(t *testing.T) {
+		other := templates.Contract{Name: "Other", Source: "access(all) contract Other {}"}
+
+		sorted, err := deploy.Sort([]templates.Contract{other, base, middle})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Other", "Base", "Middle"}, names(sorted))
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		a := templates.Contract{Name: "A", Source: "import B from 0x01\naccess(all) contract A {}"}
+		b := templates.Contract{Name: "B", Source: "import \"A\"\naccess(all) contract B {}"}
+
+		_, err := deploy.Sort([]templates.Contract{a, b})
+		assert.ErrorIs(t, err, deploy.ErrImportCycle)
+		assert.ErrorContains(t, err, "A -> B -> A")
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		_, err := deploy.Sort([]templates.Contract{base, base})
+		assert.Error(t, err)
+	})
+}
+
+func TestNewPlan(t *testing.T) {
+	ctx := context.Background()
+	address := flow.HexToAddress("01")
+
+	client := new(mocks.Client)
+	client.On("GetAccountAtLatestBlock", mock.Anything, address).Return(&flow.Account{
+		Address: address,
+		Contracts: map[string][]byte{
+			"Base":   []byte(base.Source),
+			"Middle": []byte("access(all) contract Middle { access(all) let old: Bool }"),
+		},
+	}, nil)
+
+	plan, err := deploy.NewPlan(ctx, client, address, []templates.Contract{top, middle, base})
+	require.NoError(t, err)
+
+	require.Len(t, plan.Steps, 3)
+	assert.Equal(t, deploy.ActionSkip, plan.Steps[0].Action)
+	assert.Equal(t, deploy.ActionUpdate, plan.Steps[1].Action)
+	assert.Equal(t, []string{"Base"}, plan.Steps[1].Dependencies)
+	assert.Equal(t, deploy.ActionAdd, plan.Steps[2].Action)
+	assert.Equal(t, []string{"Middle", "Base"}, plan.Steps[2].Dependencies)
+
+	txs := plan.Transactions()
+	require.Len(t, txs, 2)
+	assert.Equal(t, templates.UpdateAccountContract(address, middle).Script, txs[0].Script)
+	assert.Equal(t, templates.AddAccountContract(address, top).Script, txs[1].Script)
+	assert.Equal(t, []flow.Address{address}, txs[1].Authorizers)
+
+	for _, tx := range txs {
+		assert.Empty(t, tx.NonCanonicalArguments())
+	}
+
+	_, err = plan.Transaction()
+	assert.ErrorIs(t, err, deploy.ErrDependentBatch)
+}
+
+func TestNewPlan_WithImports(t *testing.T) {
+	ctx := context.Background()
+	address := flow.HexToAddress("01")
+
+	client := new(mocks.Client)
+	client.On("GetAccountAtLatestBlock", mock.Anything, address).Return(&flow.Account{Address: address}, nil)
+
+	registry := imports.NewRegistry().Register(flow.Emulator, "FungibleToken", flow.HexToAddress("ee82856bf20e2aa6"))
+
+	plan, err := deploy.NewPlan(ctx, client, address, []templates.Contract{top, middle, base},
+		deploy.WithImports(registry, flow.Emulator))
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"import Middle from 0x0000000000000001\nimport Base from 0x0000000000000001\nimport FungibleToken from 0xee82856bf20e2aa6\n\naccess(all) contract Top {}",
+		plan.Steps[2].Contract.Source,
+	)
+	_, ok := registry.Address(flow.Emulator, "Base")
+	assert.False(t, ok, "the given registry must not be modified")
+
+	t.Run("Unresolved", func(t *testing.T) {
+		_, err := deploy.NewPlan(ctx, client, address, []templates.Contract{top, middle, base},
+			deploy.WithImports(imports.NewRegistry(), flow.Emulator))
+		assert.ErrorIs(t, err, imports.ErrUnresolvedImport)
+	})
+}
+
+func TestPlan_Transaction(t *testing.T) {
+	ctx := context.Background()
+	address := flow.HexToAddress("01")
+	other := templates.Contract{Name: "Other", Source: "access(all) contract Other {}"}
+
+	client := new(mocks.Client)
+	client.On("GetAccountAtLatestBlock", mock.Anything, address).Return(&flow.Account{
+		Address:   address,
+		Contracts: map[string][]byte{"Base": []byte("access(all) contract Base { }")},
+	}, nil)
+
+	plan, err := deploy.NewPlan(ctx, client, address, []templates.Contract{base, other})
+	require.NoError(t, err)
+
+	tx, err := plan.Transaction()
+	require.NoError(t, err)
+	require.NotNil(t, tx)
+	_, err = parser.ParseProgram(nil, tx.Script, parser.Config{})
+	require.NoError(t, err)
+	require.Len(t, tx.Arguments, 3)
+	assert.Empty(t, tx.NonCanonicalArguments())
+	assert.Equal(t, []flow.Address{address}, tx.Authorizers)
+
+	updates, err := tx.Argument(2)
+	require.NoError(t, err)
+	assert.Equal(t, `[true, false]`, updates.String())
+
+	t.Run("Nothing to deploy", func(t *testing.T) {
+		client := new(mocks.Client)
+		client.On("GetAccountAtLatestBlock", mock.Anything, address).Return(&flow.Account{
+			Address:   address,
+			Contracts: map[string][]byte{"Base": []byte(base.Source)},
+		}, nil)
+
+		plan, err := deploy.NewPlan(ctx, client, address, []templates.Contract{base})
+		require.NoError(t, err)
+		assert.Empty(t, plan.Transactions())
+
+		tx, err := plan.Transaction()
+		require.NoError(t, err)
+		assert.Nil(t, tx)
+	})
+
+	t.Run("Account error", func(t *testing.T) {
+		client := new(mocks.Client)
+		client.On("GetAccountAtLatestBlock", mock.Anything, address).Return(nil, errors.New("unavailable"))
+
+		_, err := deploy.NewPlan(ctx, client, address, []templates.Contract{base})
+		assert.ErrorContains(t, err, "unavailable")
+	})
+}
@@ -20,9 +20,12 @@ package flow
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/fixedpoint"
+	"github.com/onflow/cadence/sema"
 	"github.com/onflow/cadence/stdlib"
 	"github.com/onflow/crypto/hash"
 
@@ -37,6 +40,9 @@ const (
 	EventAccountContractAdded   string = "flow.AccountContractAdded"
 	EventAccountContractUpdated string = "flow.AccountContractUpdated"
 	EventAccountContractRemoved string = "flow.AccountContractRemoved"
+	EventInboxValuePublished    string = "flow.InboxValuePublished"
+	EventInboxValueUnpublished  string = "flow.InboxValueUnpublished"
+	EventInboxValueClaimed      string = "flow.InboxValueClaimed"
 )
 
 type Event struct {
@@ -126,12 +132,367 @@ func CalculateEventsHash(es []Event) (crypto.Hash, error) {
 type AccountCreatedEvent Event
 
 // Address returns the address of the newly-created account.
-func (evt AccountCreatedEvent) Address() Address {
-	address := cadence.SearchFieldByName(
-		evt.Value,
-		stdlib.AccountEventAddressParameter.Identifier,
-	).(cadence.Address)
-	return BytesToAddress(address.Bytes())
+//
+// An error is returned if the event has no address field.
+func (evt AccountCreatedEvent) Address() (Address, error) {
+	return eventAddressField(Event(evt), stdlib.AccountEventAddressParameter.Identifier)
+}
+
+// An AccountKeyAddedEvent is emitted when a key is added to a Flow account.
+//
+// This event contains the following fields:
+// - Address: Address
+// - PublicKey: PublicKey
+// - Weight: UFix64
+// - HashAlgorithm: HashAlgorithm
+// - KeyIndex: Int
+type AccountKeyAddedEvent Event
+
+// Address returns the address of the account the key was added to.
+func (evt AccountKeyAddedEvent) Address() (Address, error) {
+	return eventAddressField(Event(evt), stdlib.AccountEventAddressParameter.Identifier)
+}
+
+// AccountKey returns the added key, with its public key decoded.
+//
+// An error is returned if a field is missing or has an unexpected type, or if the public key or
+// its algorithms cannot be decoded. Fractional key weights are truncated, as they are by the FVM.
+func (evt AccountKeyAddedEvent) AccountKey() (*AccountKey, error) {
+	key, err := evt.accountKey()
+	if err != nil {
+		return nil, fmt.Errorf("account key added event: %w", err)
+	}
+	return key, nil
+}
+
+func (evt AccountKeyAddedEvent) accountKey() (*AccountKey, error) {
+	publicKey, err := eventFieldAs[cadence.Struct](Event(evt), stdlib.AccountEventPublicKeyParameterAsCompositeType.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	sigAlgoValue, ok := cadence.SearchFieldByName(publicKey, sema.PublicKeyTypeSignAlgoFieldName).(cadence.Enum)
+	if !ok {
+		return nil, fmt.Errorf("public key has no signature algorithm")
+	}
+	sigAlgoRawValue, err := enumRawValue(sigAlgoValue)
+	if err != nil {
+		return nil, err
+	}
+	sigAlgo := signatureAlgorithmFromRawValue(sigAlgoRawValue)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("unsupported signature algorithm %d", sigAlgoRawValue)
+	}
+
+	hashAlgoValue, err := eventFieldAs[cadence.Enum](Event(evt), stdlib.AccountEventHashAlgorithmParameter.Identifier)
+	if err != nil {
+		return nil, err
+	}
+	hashAlgoRawValue, err := enumRawValue(hashAlgoValue)
+	if err != nil {
+		return nil, err
+	}
+	hashAlgo := hashAlgorithmFromRawValue(hashAlgoRawValue)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("unsupported hash algorithm %d", hashAlgoRawValue)
+	}
+
+	encoded, err := bytesValue(cadence.SearchFieldByName(publicKey, sema.PublicKeyTypePublicKeyFieldName))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	decoded, err := crypto.DecodePublicKey(sigAlgo, encoded)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode public key: %w", err)
+	}
+
+	weight, err := eventFieldAs[cadence.UFix64](Event(evt), stdlib.AccountEventKeyWeightParameter.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := eventUInt32Field(Event(evt), stdlib.AccountEventKeyIndexParameter.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	// the FVM truncates fractional key weights
+	return &AccountKey{
+		Index:     index,
+		PublicKey: decoded,
+		SigAlgo:   sigAlgo,
+		HashAlgo:  hashAlgo,
+		Weight:    int(uint64(weight) / fixedpoint.Fix64Factor),
+	}, nil
+}
+
+// An AccountKeyRemovedEvent is emitted when a key is revoked from a Flow account.
+//
+// This event contains the following fields:
+// - Address: Address
+// - PublicKey: Int, the index of the revoked key
+type AccountKeyRemovedEvent Event
+
+// Address returns the address of the account the key was revoked from.
+func (evt AccountKeyRemovedEvent) Address() (Address, error) {
+	return eventAddressField(Event(evt), stdlib.AccountEventAddressParameter.Identifier)
+}
+
+// KeyIndex returns the index of the revoked key.
+func (evt AccountKeyRemovedEvent) KeyIndex() (uint32, error) {
+	return eventUInt32Field(Event(evt), stdlib.AccountEventPublicKeyIndexParameter.Identifier)
+}
+
+// An AccountContractAddedEvent is emitted when a contract is deployed to a Flow account.
+//
+// This event contains the following fields:
+// - Address: Address
+// - CodeHash: [UInt8; 32]
+// - Contract: String
+type AccountContractAddedEvent Event
+
+// Address returns the address of the account the contract was deployed to.
+func (evt AccountContractAddedEvent) Address() (Address, error) {
+	return eventAddressField(Event(evt), stdlib.AccountEventAddressParameter.Identifier)
+}
+
+// CodeHash returns the SHA3-256 hash of the contract code.
+func (evt AccountContractAddedEvent) CodeHash() (crypto.Hash, error) {
+	return eventCodeHash(Event(evt))
+}
+
+// Contract returns the name of the contract.
+func (evt AccountContractAddedEvent) Contract() (string, error) {
+	return eventStringField(Event(evt), stdlib.AccountEventContractParameter.Identifier)
+}
+
+// An AccountContractUpdatedEvent is emitted when a contract of a Flow account is updated.
+//
+// This event contains the following fields:
+// - Address: Address
+// - CodeHash: [UInt8; 32]
+// - Contract: String
+type AccountContractUpdatedEvent Event
+
+// Address returns the address of the account of the contract.
+func (evt AccountContractUpdatedEvent) Address() (Address, error) {
+	return eventAddressField(Event(evt), stdlib.AccountEventAddressParameter.Identifier)
+}
+
+// CodeHash returns the SHA3-256 hash of the updated contract code.
+func (evt AccountContractUpdatedEvent) CodeHash() (crypto.Hash, error) {
+	return eventCodeHash(Event(evt))
+}
+
+// Contract returns the name of the contract.
+func (evt AccountContractUpdatedEvent) Contract() (string, error) {
+	return eventStringField(Event(evt), stdlib.AccountEventContractParameter.Identifier)
+}
+
+// An AccountContractRemovedEvent is emitted when a contract is removed from a Flow account.
+//
+// This event contains the following fields:
+// - Address: Address
+// - CodeHash: [UInt8; 32]
+// - Contract: String
+type AccountContractRemovedEvent Event
+
+// Address returns the address of the account the contract was removed from.
+func (evt AccountContractRemovedEvent) Address() (Address, error) {
+	return eventAddressField(Event(evt), stdlib.AccountEventAddressParameter.Identifier)
+}
+
+// CodeHash returns the SHA3-256 hash of the removed contract code.
+func (evt AccountContractRemovedEvent) CodeHash() (crypto.Hash, error) {
+	return eventCodeHash(Event(evt))
+}
+
+// Contract returns the name of the contract.
+func (evt AccountContractRemovedEvent) Contract() (string, error) {
+	return eventStringField(Event(evt), stdlib.AccountEventContractParameter.Identifier)
+}
+
+// An InboxValuePublishedEvent is emitted when an account publishes a capability to the inbox of another account.
+//
+// This event contains the following fields:
+// - Provider: Address
+// - Recipient: Address
+// - Name: String
+// - Type: Type
+type InboxValuePublishedEvent Event
+
+// Provider returns the address of the account publishing the value.
+func (evt InboxValuePublishedEvent) Provider() (Address, error) {
+	return eventAddressField(Event(evt), stdlib.AccountEventProviderParameter.Identifier)
+}
+
+// Recipient returns the address of the account the value is published for.
+func (evt InboxValuePublishedEvent) Recipient() (Address, error) {
+	return eventAddressField(Event(evt), stdlib.AccountEventRecipientParameter.Identifier)
+}
+
+// Name returns the name the value is published under.
+func (evt InboxValuePublishedEvent) Name() (string, error) {
+	return eventStringField(Event(evt), stdlib.AccountEventNameParameter.Identifier)
+}
+
+// ValueType returns the type of the published value.
+func (evt InboxValuePublishedEvent) ValueType() (cadence.Type, error) {
+	value, err := eventFieldAs[cadence.TypeValue](Event(evt), stdlib.AccountEventTypeParameter.Identifier)
+	return value.StaticType, err
+}
+
+// An InboxValueUnpublishedEvent is emitted when an account unpublishes a value it published.
+//
+// This event contains the following fields:
+// - Provider: Address
+// - Name: String
+type InboxValueUnpublishedEvent Event
+
+// Provider returns the address of the account that published the value.
+func (evt InboxValueUnpublishedEvent) Provider() (Address, error) {
+	return eventAddressField(Event(evt), stdlib.AccountEventProviderParameter.Identifier)
+}
+
+// Name returns the name the value was published under.
+func (evt InboxValueUnpublishedEvent) Name() (string, error) {
+	return eventStringField(Event(evt), stdlib.AccountEventNameParameter.Identifier)
+}
+
+// An InboxValueClaimedEvent is emitted when an account claims a value published to it.
+//
+// This event contains the following fields:
+// - Provider: Address
+// - Recipient: Address
+// - Name: String
+type InboxValueClaimedEvent Event
+
+// Provider returns the address of the account that published the value.
+func (evt InboxValueClaimedEvent) Provider() (Address, error) {
+	return eventAddressField(Event(evt), stdlib.AccountEventProviderParameter.Identifier)
+}
+
+// Recipient returns the address of the account that claimed the value.
+func (evt InboxValueClaimedEvent) Recipient() (Address, error) {
+	return eventAddressField(Event(evt), stdlib.AccountEventRecipientParameter.Identifier)
+}
+
+// Name returns the name the value was published under.
+func (evt InboxValueClaimedEvent) Name() (string, error) {
+	return eventStringField(Event(evt), stdlib.AccountEventNameParameter.Identifier)
+}
+
+func eventField(evt Event, name string) cadence.Value {
+	return cadence.SearchFieldByName(evt.Value, name)
+}
+
+// eventFieldAs returns the field of an event with the given name, or an error if the field
+// is missing or is not of type T.
+func eventFieldAs[T cadence.Value](evt Event, name string) (T, error) {
+	value, ok := eventField(evt, name).(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("event %s has no %T field %s", evt.Type, zero, name)
+	}
+	return value, nil
+}
+
+func eventAddressField(evt Event, name string) (Address, error) {
+	address, err := eventFieldAs[cadence.Address](evt, name)
+	return Address(address), err
+}
+
+func eventStringField(evt Event, name string) (string, error) {
+	value, err := eventFieldAs[cadence.String](evt, name)
+	return string(value), err
+}
+
+// eventUInt32Field returns an Int field of an event, or an error if it does not fit in a uint32.
+func eventUInt32Field(evt Event, name string) (uint32, error) {
+	value, err := eventFieldAs[cadence.Int](evt, name)
+	if err != nil {
+		return 0, err
+	}
+	if !value.Value.IsUint64() || value.Value.Uint64() > math.MaxUint32 {
+		return 0, fmt.Errorf("event %s field %s is out of range: %s", evt.Type, name, value)
+	}
+	return uint32(value.Value.Uint64()), nil
+}
+
+func eventCodeHash(evt Event) (crypto.Hash, error) {
+	name := stdlib.AccountEventCodeHashParameter.Identifier
+	hash, err := bytesValue(eventField(evt, name))
+	if err != nil {
+		return nil, fmt.Errorf("event %s field %s: %w", evt.Type, name, err)
+	}
+	return hash, nil
+}
+
+// bytesValue converts an array of UInt8 values to bytes.
+func bytesValue(value cadence.Value) ([]byte, error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("expected an array of bytes, got %T", value)
+	}
+
+	bytes := make([]byte, len(array.Values))
+	for i, v := range array.Values {
+		b, ok := v.(cadence.UInt8)
+		if !ok {
+			return nil, fmt.Errorf("expected an array of bytes, got element %T", v)
+		}
+		bytes[i] = byte(b)
+	}
+
+	return bytes, nil
+}
+
+func enumRawValue(enum cadence.Enum) (uint8, error) {
+	rawValue, ok := cadence.SearchFieldByName(enum, sema.EnumRawValueFieldName).(cadence.UInt8)
+	if !ok {
+		return 0, fmt.Errorf("enum %s has no UInt8 raw value", typeID(enum))
+	}
+	return uint8(rawValue), nil
+}
+
+func typeID(value cadence.Value) string {
+	if value.Type() == nil {
+		return "<unknown>"
+	}
+	return value.Type().ID()
+}
+
+func signatureAlgorithmFromRawValue(rawValue uint8) crypto.SignatureAlgorithm {
+	switch sema.SignatureAlgorithm(rawValue) {
+	case sema.SignatureAlgorithmECDSA_P256:
+		return crypto.ECDSA_P256
+	case sema.SignatureAlgorithmECDSA_secp256k1:
+		return crypto.ECDSA_secp256k1
+	case sema.SignatureAlgorithmBLS_BLS12_381:
+		return crypto.BLS_BLS12_381
+	default:
+		return crypto.UnknownSignatureAlgorithm
+	}
+}
+
+func hashAlgorithmFromRawValue(rawValue uint8) crypto.HashAlgorithm {
+	switch sema.HashAlgorithm(rawValue) {
+	case sema.HashAlgorithmSHA2_256:
+		return crypto.SHA2_256
+	case sema.HashAlgorithmSHA2_384:
+		return crypto.SHA2_384
+	case sema.HashAlgorithmSHA3_256:
+		return crypto.SHA3_256
+	case sema.HashAlgorithmSHA3_384:
+		return crypto.SHA3_384
+	case sema.HashAlgorithmKMAC128_BLS_BLS12_381:
+		return crypto.KMAC128
+	case sema.HashAlgorithmKECCAK_256:
+		return crypto.Keccak256
+	default:
+		return crypto.UnknownHashAlgorithm
+	}
 }
 
 // EventFilter is used to filter events based on given parameters.
@@ -0,0 +1,490 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/ccf"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+// ErrUnregisteredEventType is returned by EventRegistry.Decode for events without a registered Go type.
+var ErrUnregisteredEventType = errors.New("event type is not registered")
+
+// DecodeEvent decodes the fields of an event into a Go struct of type T.
+//
+// Struct fields are mapped to event fields by their `cadence:"fieldName"` tag and decoded with
+// cadence.DecodeFields: optionals decode into pointers, arrays into slices, dictionaries into maps and
+// composites into tagged structs, while other values are converted to the field type, for example
+// cadence.UInt64 into uint64. Fields of Cadence types, such as cadence.UFix64 or cadence.Int, receive
+// the value as is.
+//
+// In addition, the following conversions are supported, also inside optionals, arrays, dictionaries
+// and nested structs:
+//   - addresses into Address, [8]byte or hex strings
+//   - Fix64 and UFix64 into decimal strings, float64 and exact *big.Rat values
+//   - integers into *big.Int
+//   - strings, characters and paths into strings
+//
+// An optional value decoded into a field that is not a pointer sets the field to its zero value for nil.
+//
+// If the event has no decoded Value, its Payload is decoded first. Both CCF and JSON-CDC payloads are supported.
+func DecodeEvent[T any](event Event) (T, error) {
+	var result T
+	err := decodeEventInto(event, &result)
+	return result, err
+}
+
+// An EventRegistry maps event types to the Go structs their events are decoded into.
+//
+// An EventRegistry is safe for concurrent use.
+type EventRegistry struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+}
+
+// NewEventRegistry returns an empty event registry.
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{
+		types: make(map[string]reflect.Type),
+	}
+}
+
+// RegisterEvent registers T as the Go type of the events with the given type ID,
+// for example "A.f233dcee88fe0abe.FungibleToken.Deposited".
+//
+// T must be a struct type with `cadence:"fieldName"` tags, see DecodeEvent.
+func RegisterEvent[T any](registry *EventRegistry, eventType string) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("flow: cannot register event %s: %s is not a struct type", eventType, t))
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.types[eventType] = t
+}
+
+// Types returns the registered event type IDs.
+func (r *EventRegistry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]string, 0, len(r.types))
+	for eventType := range r.types {
+		types = append(types, eventType)
+	}
+	return types
+}
+
+// Decode decodes an event into a new value of the Go type registered for its type,
+// and returns a pointer to it.
+//
+// An error wrapping ErrUnregisteredEventType is returned for events of unregistered types.
+func (r *EventRegistry) Decode(event Event) (any, error) {
+	r.mu.RLock()
+	t, ok := r.types[event.Type]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnregisteredEventType, event.Type)
+	}
+
+	result := reflect.New(t).Interface()
+	if err := decodeEventInto(event, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// decodeEventInto decodes the fields of an event into the struct pointed to by target.
+func decodeEventInto(event Event, target any) error {
+	value := event.Value
+	if value.EventType == nil {
+		if len(event.Payload) == 0 {
+			return fmt.Errorf("cannot decode event %s: event has neither a value nor a payload", event.Type)
+		}
+
+		decoded, err := decodeEventPayload(event.Payload)
+		if err != nil {
+			return fmt.Errorf("cannot decode payload of event %s: %w", event.Type, err)
+		}
+		value = decoded
+	}
+
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot decode event %s into %T: target must be a struct", event.Type, target)
+	}
+
+	if err := decodeComposite(value, v.Elem()); err != nil {
+		return fmt.Errorf("cannot decode event %s: %w", event.Type, err)
+	}
+	return nil
+}
+
+func decodeEventPayload(payload []byte) (cadence.Event, error) {
+	var (
+		value cadence.Value
+		err   error
+	)
+	if ccf.HasMsgPrefix(payload) {
+		value, err = ccf.Decode(nil, payload)
+	} else {
+		value, err = jsoncdc.Decode(nil, payload)
+	}
+	if err != nil {
+		return cadence.Event{}, err
+	}
+
+	event, ok := value.(cadence.Event)
+	if !ok {
+		return cadence.Event{}, fmt.Errorf("payload is a %T, not an event", value)
+	}
+	if event.EventType == nil {
+		return cadence.Event{}, fmt.Errorf("payload has no event type")
+	}
+	return event, nil
+}
+
+var (
+	cadenceValueType = reflect.TypeOf((*cadence.Value)(nil)).Elem()
+	bigIntType       = reflect.TypeOf((*big.Int)(nil))
+	bigRatType       = reflect.TypeOf((*big.Rat)(nil))
+)
+
+// fix64Scale is the number of fractional units of Fix64 and UFix64 values.
+const fix64Scale = 100_000_000
+
+// decodeComposite decodes the tagged fields of the struct target.
+//
+// Fields that need one of the conversions described in DecodeEvent are decoded by decodeValue,
+// all other fields are left to cadence.DecodeFields.
+func decodeComposite(composite cadence.Composite, target reflect.Value) error {
+	fields := cadence.FieldsMappedByName(composite)
+	t := target.Type()
+
+	var (
+		plainFields  []reflect.StructField
+		plainIndexes []int
+	)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name := field.Tag.Get("cadence")
+		if name == "" {
+			continue
+		}
+		if !field.IsExported() {
+			return fmt.Errorf("cannot set field %s", field.Name)
+		}
+
+		if !needsConversion(field.Type, nil) {
+			plainFields = append(plainFields, reflect.StructField{
+				Name: field.Name,
+				Type: field.Type,
+				Tag:  field.Tag,
+			})
+			plainIndexes = append(plainIndexes, i)
+			continue
+		}
+
+		value := fields[name]
+		if value == nil {
+			return fmt.Errorf("%s field not found", name)
+		}
+
+		decoded, err := decodeValue(value, field.Type)
+		if err != nil {
+			return fmt.Errorf("cannot convert Cadence field %s into Go field %s: %w", name, field.Name, err)
+		}
+		target.Field(i).Set(decoded)
+	}
+
+	if len(plainFields) == 0 {
+		return nil
+	}
+
+	plain := reflect.New(reflect.StructOf(plainFields))
+	if err := cadence.DecodeFields(composite, plain.Interface()); err != nil {
+		return err
+	}
+	for i, index := range plainIndexes {
+		target.Field(index).Set(plain.Elem().Field(i))
+	}
+	return nil
+}
+
+// needsConversion reports whether values of type t, or values nested in them,
+// cannot be decoded by cadence.DecodeFields.
+func needsConversion(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if t == cadenceValueType || t.Implements(cadenceValueType) {
+		return false
+	}
+
+	switch {
+	case t == bigIntType, t == bigRatType, isAddressType(t):
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return needsConversion(t.Elem(), visiting)
+	case reflect.Map:
+		return needsConversion(t.Key(), visiting) || needsConversion(t.Elem(), visiting)
+	case reflect.Struct:
+		if visiting[t] {
+			return false
+		}
+		if visiting == nil {
+			visiting = make(map[reflect.Type]bool)
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.Tag.Get("cadence") != "" && needsConversion(field.Type, visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isAddressType reports whether t is an array of AddressLength bytes, such as Address.
+func isAddressType(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Len() == AddressLength && t.Elem().Kind() == reflect.Uint8
+}
+
+// decodeValue decodes a Cadence value into a new Go value of type t.
+func decodeValue(value cadence.Value, t reflect.Type) (reflect.Value, error) {
+	if t == cadenceValueType || reflect.TypeOf(value) == t {
+		return reflect.ValueOf(value).Convert(t), nil
+	}
+
+	if optional, ok := value.(cadence.Optional); ok {
+		if optional.Value == nil {
+			return reflect.Zero(t), nil
+		}
+		value = optional.Value
+	}
+
+	switch {
+	case t == bigIntType:
+		i, ok := integerValue(value)
+		if !ok {
+			return reflect.Value{}, typeMismatch(value, t)
+		}
+		return reflect.ValueOf(i), nil
+
+	case t == bigRatType:
+		r, ok := decimalValue(value)
+		if !ok {
+			return reflect.Value{}, typeMismatch(value, t)
+		}
+		return reflect.ValueOf(r), nil
+
+	case isAddressType(t):
+		address, ok := value.(cadence.Address)
+		if !ok {
+			return reflect.Value{}, typeMismatch(value, t)
+		}
+		result := reflect.New(t).Elem()
+		reflect.Copy(result, reflect.ValueOf(address[:]))
+		return result, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		element, err := decodeValue(value, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result := reflect.New(t.Elem())
+		result.Elem().Set(element)
+		return result, nil
+
+	case reflect.String:
+		var s string
+		switch v := value.(type) {
+		case cadence.String:
+			s = string(v)
+		case cadence.Character:
+			s = string(v)
+		case cadence.Address:
+			s = Address(v).HexWithPrefix()
+		case cadence.Path:
+			s = v.String()
+		case cadence.UFix64, cadence.Fix64:
+			s = v.String()
+		default:
+			return reflect.Value{}, typeMismatch(value, t)
+		}
+		return reflect.ValueOf(s).Convert(t), nil
+
+	case reflect.Float32, reflect.Float64:
+		r, ok := decimalValue(value)
+		if !ok {
+			return reflect.Value{}, typeMismatch(value, t)
+		}
+		f, _ := r.Float64()
+		return reflect.ValueOf(f).Convert(t), nil
+
+	case reflect.Struct:
+		composite, ok := value.(cadence.Composite)
+		if !ok {
+			return reflect.Value{}, typeMismatch(value, t)
+		}
+		result := reflect.New(t).Elem()
+		if err := decodeComposite(composite, result); err != nil {
+			return reflect.Value{}, err
+		}
+		return result, nil
+
+	case reflect.Slice, reflect.Array:
+		array, ok := value.(cadence.Array)
+		if !ok {
+			return reflect.Value{}, typeMismatch(value, t)
+		}
+
+		var result reflect.Value
+		if t.Kind() == reflect.Slice {
+			result = reflect.MakeSlice(t, len(array.Values), len(array.Values))
+		} else if len(array.Values) == t.Len() {
+			result = reflect.New(t).Elem()
+		} else {
+			return reflect.Value{}, typeMismatch(value, t)
+		}
+
+		for i, element := range array.Values {
+			decoded, err := decodeValue(element, t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("cannot decode array element %d: %w", i, err)
+			}
+			result.Index(i).Set(decoded)
+		}
+		return result, nil
+
+	case reflect.Map:
+		dictionary, ok := value.(cadence.Dictionary)
+		if !ok {
+			return reflect.Value{}, typeMismatch(value, t)
+		}
+
+		result := reflect.MakeMapWithSize(t, len(dictionary.Pairs))
+		for _, pair := range dictionary.Pairs {
+			key, err := decodeValue(pair.Key, t.Key())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("cannot decode dictionary key: %w", err)
+			}
+			element, err := decodeValue(pair.Value, t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("cannot decode dictionary value: %w", err)
+			}
+			result.SetMapIndex(key, element)
+		}
+		return result, nil
+	}
+
+	// the remaining types are converted as cadence.DecodeFields converts them
+	v := reflect.ValueOf(value)
+	if !v.CanConvert(t) {
+		return reflect.Value{}, typeMismatch(value, t)
+	}
+	return v.Convert(t), nil
+}
+
+// integerValue returns the value of any Cadence integer.
+func integerValue(value cadence.Value) (*big.Int, bool) {
+	switch v := value.(type) {
+	case cadence.Int:
+		return v.Big(), true
+	case cadence.UInt:
+		return v.Big(), true
+	case cadence.Int128:
+		return v.Big(), true
+	case cadence.Int256:
+		return v.Big(), true
+	case cadence.UInt128:
+		return v.Big(), true
+	case cadence.UInt256:
+		return v.Big(), true
+	case cadence.Word128:
+		return v.Big(), true
+	case cadence.Word256:
+		return v.Big(), true
+	case cadence.Int8:
+		return big.NewInt(int64(v)), true
+	case cadence.Int16:
+		return big.NewInt(int64(v)), true
+	case cadence.Int32:
+		return big.NewInt(int64(v)), true
+	case cadence.Int64:
+		return big.NewInt(int64(v)), true
+	case cadence.UInt8:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.UInt16:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.UInt32:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.UInt64:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.Word8:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.Word16:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.Word32:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case cadence.Word64:
+		return new(big.Int).SetUint64(uint64(v)), true
+	default:
+		return nil, false
+	}
+}
+
+// decimalValue returns the exact value of a Fix64, UFix64 or integer.
+func decimalValue(value cadence.Value) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case cadence.UFix64:
+		return new(big.Rat).SetFrac(new(big.Int).SetUint64(uint64(v)), big.NewInt(fix64Scale)), true
+	case cadence.Fix64:
+		return big.NewRat(int64(v), fix64Scale), true
+	default:
+		i, ok := integerValue(value)
+		if !ok {
+			return nil, false
+		}
+		return new(big.Rat).SetInt(i), true
+	}
+}
+
+func typeMismatch(value cadence.Value, t reflect.Type) error {
+	return fmt.Errorf("cannot decode %T into %s", value, t)
+}
@@ -0,0 +1,251 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/encoding/ccf"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+type listingInfo struct {
+	Name  string  `cadence:"name"`
+	Delta float64 `cadence:"delta"`
+}
+
+type listingCreated struct {
+	Amount      string            `cadence:"amount"`
+	AmountExact *big.Rat          `cadence:"amount"`
+	Raw         cadence.UFix64    `cadence:"amount"`
+	To          *flow.Address     `cadence:"to"`
+	ToHex       string            `cadence:"to"`
+	From        *flow.Address     `cadence:"from"`
+	IDs         []uint64          `cadence:"ids"`
+	Metadata    map[string]string `cadence:"metadata"`
+	Info        listingInfo       `cadence:"info"`
+	Supply      *big.Int          `cadence:"supply"`
+	Ignored     string
+}
+
+var (
+	marketLocation = common.NewAddressLocation(nil, common.Address{0, 0, 0, 0, 0, 0, 0, 1}, "Market")
+
+	listingInfoType = cadence.NewStructType(
+		marketLocation,
+		"Market.ListingInfo",
+		[]cadence.Field{
+			{Identifier: "name", Type: cadence.StringType},
+			{Identifier: "delta", Type: cadence.Fix64Type},
+		},
+		nil,
+	)
+
+	listingCreatedType = cadence.NewEventType(
+		marketLocation,
+		"Market.ListingCreated",
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type},
+			{Identifier: "to", Type: cadence.NewOptionalType(cadence.AddressType)},
+			{Identifier: "from", Type: cadence.NewOptionalType(cadence.AddressType)},
+			{Identifier: "ids", Type: cadence.NewVariableSizedArrayType(cadence.UInt64Type)},
+			{Identifier: "metadata", Type: cadence.NewDictionaryType(cadence.StringType, cadence.StringType)},
+			{Identifier: "info", Type: listingInfoType},
+			{Identifier: "supply", Type: cadence.IntType},
+		},
+		nil,
+	)
+)
+
+func newListingCreatedEvent(t *testing.T) flow.Event {
+	to := flow.HexToAddress("01")
+
+	amount, err := cadence.NewUFix64("12.5")
+	require.NoError(t, err)
+	delta, err := cadence.NewFix64("-0.25")
+	require.NoError(t, err)
+
+	value := cadence.NewEvent([]cadence.Value{
+		amount,
+		cadence.NewOptional(cadence.NewAddress(to)),
+		cadence.NewOptional(nil),
+		cadence.NewArray([]cadence.Value{cadence.NewUInt64(1), cadence.NewUInt64(2)}).
+			WithType(cadence.NewVariableSizedArrayType(cadence.UInt64Type)),
+		cadence.NewDictionary([]cadence.KeyValuePair{{Key: cadence.String("color"), Value: cadence.String("red")}}).
+			WithType(cadence.NewDictionaryType(cadence.StringType, cadence.StringType)),
+		cadence.NewStruct([]cadence.Value{cadence.String("first"), delta}).WithType(listingInfoType),
+		cadence.NewIntFromBig(new(big.Int).Lsh(big.NewInt(1), 100)),
+	}).WithType(listingCreatedType)
+
+	return flow.Event{
+		Type:  string(listingCreatedType.ID()),
+		Value: value,
+	}
+}
+
+func assertListingCreated(t *testing.T, listing listingCreated) {
+	to := flow.HexToAddress("01")
+
+	assert.Equal(t, "12.50000000", listing.Amount)
+	assert.Equal(t, big.NewRat(25, 2), listing.AmountExact)
+	assert.Equal(t, cadence.UFix64(12_50000000), listing.Raw)
+	assert.Equal(t, &to, listing.To)
+	assert.Equal(t, "0x0000000000000001", listing.ToHex)
+	assert.Nil(t, listing.From)
+	assert.Equal(t, []uint64{1, 2}, listing.IDs)
+	assert.Equal(t, map[string]string{"color": "red"}, listing.Metadata)
+	assert.Equal(t, listingInfo{Name: "first", Delta: -0.25}, listing.Info)
+	assert.Equal(t, new(big.Int).Lsh(big.NewInt(1), 100), listing.Supply)
+	assert.Empty(t, listing.Ignored)
+}
+
+func TestDecodeEvent(t *testing.T) {
+	event := newListingCreatedEvent(t)
+
+	t.Run("Value", func(t *testing.T) {
+		listing, err := flow.DecodeEvent[listingCreated](event)
+		require.NoError(t, err)
+		assertListingCreated(t, listing)
+	})
+
+	t.Run("CCF payload", func(t *testing.T) {
+		payload, err := ccf.Encode(event.Value)
+		require.NoError(t, err)
+
+		listing, err := flow.DecodeEvent[listingCreated](flow.Event{Type: event.Type, Payload: payload})
+		require.NoError(t, err)
+		assertListingCreated(t, listing)
+	})
+
+	t.Run("JSON-CDC payload", func(t *testing.T) {
+		payload, err := jsoncdc.Encode(event.Value)
+		require.NoError(t, err)
+
+		listing, err := flow.DecodeEvent[listingCreated](flow.Event{Type: event.Type, Payload: payload})
+		require.NoError(t, err)
+		assertListingCreated(t, listing)
+	})
+
+	t.Run("Generated events", func(t *testing.T) {
+		type fooEvent struct {
+			A cadence.Int `cadence:"a"`
+			B string      `cadence:"b"`
+		}
+
+		for _, encoding := range []flow.EventEncodingVersion{flow.EventEncodingVersionCCF, flow.EventEncodingVersionJSONCDC} {
+			generated := test.EventGenerator(encoding).New()
+
+			foo, err := flow.DecodeEvent[fooEvent](flow.Event{Type: generated.Type, Payload: generated.Payload})
+			require.NoError(t, err)
+			assert.Equal(t, fooEvent{A: cadence.NewInt(1), B: "foo"}, foo)
+		}
+	})
+
+	t.Run("Addresses", func(t *testing.T) {
+		type addresses struct {
+			To    flow.Address    `cadence:"to"`
+			Bytes [8]byte         `cadence:"to"`
+			From  flow.Address    `cadence:"from"`
+			All   []*flow.Address `cadence:"all"`
+		}
+
+		to := flow.HexToAddress("01")
+		eventType := cadence.NewEventType(
+			marketLocation,
+			"Market.Transferred",
+			[]cadence.Field{
+				{Identifier: "to", Type: cadence.NewOptionalType(cadence.AddressType)},
+				{Identifier: "from", Type: cadence.NewOptionalType(cadence.AddressType)},
+				{Identifier: "all", Type: cadence.NewVariableSizedArrayType(cadence.NewOptionalType(cadence.AddressType))},
+			},
+			nil,
+		)
+		value := cadence.NewEvent([]cadence.Value{
+			cadence.NewOptional(cadence.NewAddress(to)),
+			cadence.NewOptional(nil),
+			cadence.NewArray([]cadence.Value{cadence.NewOptional(cadence.NewAddress(to)), cadence.NewOptional(nil)}).
+				WithType(cadence.NewVariableSizedArrayType(cadence.NewOptionalType(cadence.AddressType))),
+		}).WithType(eventType)
+
+		decoded, err := flow.DecodeEvent[addresses](flow.Event{Type: string(eventType.ID()), Value: value})
+		require.NoError(t, err)
+		assert.Equal(t, to, decoded.To)
+		assert.Equal(t, [8]byte(to), decoded.Bytes)
+		assert.Equal(t, flow.EmptyAddress, decoded.From)
+		assert.Equal(t, []*flow.Address{&to, nil}, decoded.All)
+	})
+
+	t.Run("Missing field", func(t *testing.T) {
+		type missing struct {
+			Price string `cadence:"price"`
+		}
+
+		_, err := flow.DecodeEvent[missing](event)
+		assert.ErrorContains(t, err, "price field not found")
+	})
+
+	t.Run("Type mismatch", func(t *testing.T) {
+		type mismatch struct {
+			IDs []string `cadence:"ids"`
+		}
+
+		_, err := flow.DecodeEvent[mismatch](event)
+		assert.ErrorContains(t, err, "Cadence field ids")
+	})
+
+	t.Run("Neither value nor payload", func(t *testing.T) {
+		_, err := flow.DecodeEvent[listingCreated](flow.Event{Type: event.Type})
+		assert.ErrorContains(t, err, "neither a value nor a payload")
+	})
+
+	t.Run("Not a struct", func(t *testing.T) {
+		_, err := flow.DecodeEvent[string](event)
+		assert.Error(t, err)
+	})
+}
+
+func TestEventRegistry(t *testing.T) {
+	event := newListingCreatedEvent(t)
+
+	registry := flow.NewEventRegistry()
+	flow.RegisterEvent[listingCreated](registry, event.Type)
+	assert.Equal(t, []string{event.Type}, registry.Types())
+
+	decoded, err := registry.Decode(event)
+	require.NoError(t, err)
+
+	listing, ok := decoded.(*listingCreated)
+	require.True(t, ok)
+	assertListingCreated(t, *listing)
+
+	_, err = registry.Decode(flow.Event{Type: "A.0000000000000001.Market.ListingRemoved"})
+	assert.ErrorIs(t, err, flow.ErrUnregisteredEventType)
+
+	assert.Panics(t, func() {
+		flow.RegisterEvent[string](registry, "A.0000000000000001.Market.Other")
+	})
+}
@@ -0,0 +1,492 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var (
+	signatureAlgorithmType = cadence.NewEnumType(
+		nil,
+		"SignatureAlgorithm",
+		cadence.UInt8Type,
+		[]cadence.Field{{Identifier: "rawValue", Type: cadence.UInt8Type}},
+		nil,
+	)
+
+	hashAlgorithmType = cadence.NewEnumType(
+		nil,
+		"HashAlgorithm",
+		cadence.UInt8Type,
+		[]cadence.Field{{Identifier: "rawValue", Type: cadence.UInt8Type}},
+		nil,
+	)
+
+	publicKeyType = cadence.NewStructType(
+		nil,
+		"PublicKey",
+		[]cadence.Field{
+			{Identifier: "publicKey", Type: cadence.NewVariableSizedArrayType(cadence.UInt8Type)},
+			{Identifier: "signatureAlgorithm", Type: signatureAlgorithmType},
+		},
+		nil,
+	)
+
+	tokenLocation = common.NewAddressLocation(nil, common.Address{0xf2, 0x33, 0xdc, 0xee, 0x88, 0xfe, 0x0a, 0xbe}, "FungibleToken")
+)
+
+func newEvent(eventType string, location common.Location, fields []cadence.Field, values ...cadence.Value) flow.Event {
+	event := cadence.NewEvent(values).WithType(cadence.NewEventType(location, eventType, fields, nil))
+	return flow.Event{Type: eventType, Value: event}
+}
+
+func newFlowEvent(eventType string, fields []cadence.Field, values ...cadence.Value) flow.Event {
+	return newEvent(eventType, stdlib.FlowLocation{}, fields, values...)
+}
+
+func bytesArray(b []byte) cadence.Array {
+	values := make([]cadence.Value, len(b))
+	for i, v := range b {
+		values[i] = cadence.NewUInt8(v)
+	}
+	return cadence.NewArray(values).WithType(cadence.NewVariableSizedArrayType(cadence.UInt8Type))
+}
+
+func newAccountKeyAddedEvent(t *testing.T, address flow.Address, key *flow.AccountKey, sigAlgo uint8, hashAlgo uint8) flow.Event {
+	return newAccountKeyAddedEventWithWeight(t, address, key, sigAlgo, hashAlgo, "1000.0")
+}
+
+func newAccountKeyAddedEventWithWeight(
+	t *testing.T,
+	address flow.Address,
+	key *flow.AccountKey,
+	sigAlgo uint8,
+	hashAlgo uint8,
+	keyWeight string,
+) flow.Event {
+	weight, err := cadence.NewUFix64(keyWeight)
+	require.NoError(t, err)
+
+	publicKey := cadence.NewStruct([]cadence.Value{
+		bytesArray(key.PublicKey.Encode()),
+		cadence.NewEnum([]cadence.Value{cadence.NewUInt8(sigAlgo)}).WithType(signatureAlgorithmType),
+	}).WithType(publicKeyType)
+
+	return newFlowEvent(
+		flow.EventAccountKeyAdded,
+		[]cadence.Field{
+			{Identifier: "address", Type: cadence.AddressType},
+			{Identifier: "publicKey", Type: publicKeyType},
+			{Identifier: "weight", Type: cadence.UFix64Type},
+			{Identifier: "hashAlgorithm", Type: hashAlgorithmType},
+			{Identifier: "keyIndex", Type: cadence.IntType},
+		},
+		cadence.NewAddress(address),
+		publicKey,
+		weight,
+		cadence.NewEnum([]cadence.Value{cadence.NewUInt8(hashAlgo)}).WithType(hashAlgorithmType),
+		cadence.NewInt(2),
+	)
+}
+
+func TestAccountKeyAddedEvent(t *testing.T) {
+	address := test.AddressGenerator().New()
+	key := test.AccountKeyGenerator().New()
+
+	t.Run("Decodes account key", func(t *testing.T) {
+		event := flow.AccountKeyAddedEvent(newAccountKeyAddedEvent(t, address, key, 1, 3))
+		assertValue(t, address)(event.Address())
+
+		accountKey, err := event.AccountKey()
+		require.NoError(t, err)
+		assert.Equal(t, uint32(2), accountKey.Index)
+		assert.True(t, key.PublicKey.Equals(accountKey.PublicKey))
+		assert.Equal(t, crypto.ECDSA_P256, accountKey.SigAlgo)
+		assert.Equal(t, crypto.SHA3_256, accountKey.HashAlgo)
+		assert.Equal(t, 1000, accountKey.Weight)
+		assert.False(t, accountKey.Revoked)
+	})
+
+	t.Run("Decodes JSON-CDC payload", func(t *testing.T) {
+		event := newAccountKeyAddedEvent(t, address, key, 1, 3)
+
+		payload, err := jsoncdc.Encode(event.Value)
+		require.NoError(t, err)
+		value, err := jsoncdc.Decode(nil, payload)
+		require.NoError(t, err)
+		event.Value = value.(cadence.Event)
+
+		accountKey, err := flow.AccountKeyAddedEvent(event).AccountKey()
+		require.NoError(t, err)
+		assert.True(t, key.PublicKey.Equals(accountKey.PublicKey))
+	})
+
+	t.Run("Unsupported algorithms", func(t *testing.T) {
+		_, err := flow.AccountKeyAddedEvent(newAccountKeyAddedEvent(t, address, key, 9, 3)).AccountKey()
+		assert.Error(t, err)
+
+		_, err = flow.AccountKeyAddedEvent(newAccountKeyAddedEvent(t, address, key, 1, 9)).AccountKey()
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid public key", func(t *testing.T) {
+		_, err := flow.AccountKeyAddedEvent(newAccountKeyAddedEvent(t, address, key, 2, 3)).AccountKey()
+		assert.Error(t, err)
+	})
+
+	t.Run("Fractional weight", func(t *testing.T) {
+		event := newAccountKeyAddedEventWithWeight(t, address, key, 1, 3, "500.5")
+		accountKey, err := flow.AccountKeyAddedEvent(event).AccountKey()
+		require.NoError(t, err)
+		assert.Equal(t, 500, accountKey.Weight)
+	})
+
+	t.Run("Malformed event", func(t *testing.T) {
+		event := newFlowEvent(
+			flow.EventAccountKeyAdded,
+			[]cadence.Field{
+				{Identifier: "address", Type: cadence.AddressType},
+				{Identifier: "publicKey", Type: cadence.StringType},
+			},
+			cadence.NewAddress(address),
+			cadence.String("not a key"),
+		)
+		_, err := flow.AccountKeyAddedEvent(event).AccountKey()
+		assert.ErrorContains(t, err, "publicKey")
+
+		_, err = flow.AccountKeyAddedEvent(flow.Event{Type: flow.EventAccountKeyAdded}).AccountKey()
+		assert.Error(t, err)
+	})
+}
+
+func TestAccountCreatedEvent(t *testing.T) {
+	address := test.AddressGenerator().New()
+
+	event := flow.AccountCreatedEvent(newFlowEvent(
+		flow.EventAccountCreated,
+		[]cadence.Field{{Identifier: "address", Type: cadence.AddressType}},
+		cadence.NewAddress(address),
+	))
+	assertValue(t, address)(event.Address())
+
+	_, err := flow.AccountCreatedEvent(flow.Event{Type: flow.EventAccountCreated}).Address()
+	assert.Error(t, err)
+}
+
+func TestAccountKeyRemovedEvent(t *testing.T) {
+	address := test.AddressGenerator().New()
+
+	newKeyRemovedEvent := func(index *big.Int) flow.AccountKeyRemovedEvent {
+		return flow.AccountKeyRemovedEvent(newFlowEvent(
+			flow.EventAccountKeyRemoved,
+			[]cadence.Field{
+				{Identifier: "address", Type: cadence.AddressType},
+				{Identifier: "publicKey", Type: cadence.IntType},
+			},
+			cadence.NewAddress(address),
+			cadence.NewIntFromBig(index),
+		))
+	}
+
+	event := newKeyRemovedEvent(big.NewInt(5))
+	assertValue(t, address)(event.Address())
+	assertValue(t, uint32(5))(event.KeyIndex())
+
+	t.Run("Out of range key index", func(t *testing.T) {
+		_, err := newKeyRemovedEvent(big.NewInt(1 << 32)).KeyIndex()
+		assert.ErrorContains(t, err, "out of range")
+
+		_, err = newKeyRemovedEvent(big.NewInt(-1)).KeyIndex()
+		assert.ErrorContains(t, err, "out of range")
+	})
+
+	t.Run("Malformed event", func(t *testing.T) {
+		malformed := flow.AccountKeyRemovedEvent(newFlowEvent(
+			flow.EventAccountKeyRemoved,
+			[]cadence.Field{{Identifier: "publicKey", Type: cadence.StringType}},
+			cadence.String("5"),
+		))
+
+		_, err := malformed.Address()
+		assert.Error(t, err)
+		_, err = malformed.KeyIndex()
+		assert.Error(t, err)
+	})
+}
+
+func TestAccountContractEvents(t *testing.T) {
+	address := test.AddressGenerator().New()
+	codeHash := make([]byte, 32)
+	for i := range codeHash {
+		codeHash[i] = byte(i)
+	}
+
+	fields := []cadence.Field{
+		{Identifier: "address", Type: cadence.AddressType},
+		{Identifier: "codeHash", Type: cadence.NewConstantSizedArrayType(32, cadence.UInt8Type)},
+		{Identifier: "contract", Type: cadence.StringType},
+	}
+	values := []cadence.Value{cadence.NewAddress(address), bytesArray(codeHash), cadence.String("Example")}
+
+	added := flow.AccountContractAddedEvent(newFlowEvent(flow.EventAccountContractAdded, fields, values...))
+	assertValue(t, address)(added.Address())
+	assertValue(t, crypto.Hash(codeHash))(added.CodeHash())
+	assertValue(t, "Example")(added.Contract())
+
+	updated := flow.AccountContractUpdatedEvent(newFlowEvent(flow.EventAccountContractUpdated, fields, values...))
+	assertValue(t, address)(updated.Address())
+	assertValue(t, crypto.Hash(codeHash))(updated.CodeHash())
+	assertValue(t, "Example")(updated.Contract())
+
+	removed := flow.AccountContractRemovedEvent(newFlowEvent(flow.EventAccountContractRemoved, fields, values...))
+	assertValue(t, address)(removed.Address())
+	assertValue(t, crypto.Hash(codeHash))(removed.CodeHash())
+	assertValue(t, "Example")(removed.Contract())
+
+	t.Run("Malformed event", func(t *testing.T) {
+		malformed := flow.AccountContractAddedEvent(newFlowEvent(
+			flow.EventAccountContractAdded,
+			[]cadence.Field{
+				{Identifier: "codeHash", Type: cadence.StringType},
+				{Identifier: "contract", Type: cadence.IntType},
+			},
+			cadence.String("hash"),
+			cadence.NewInt(1),
+		))
+
+		_, err := malformed.Address()
+		assert.Error(t, err)
+		_, err = malformed.CodeHash()
+		assert.ErrorContains(t, err, "codeHash")
+		_, err = malformed.Contract()
+		assert.Error(t, err)
+	})
+}
+
+func TestInboxEvents(t *testing.T) {
+	addresses := test.AddressGenerator()
+	provider, recipient := addresses.New(), addresses.New()
+
+	published := flow.InboxValuePublishedEvent(newFlowEvent(
+		flow.EventInboxValuePublished,
+		[]cadence.Field{
+			{Identifier: "provider", Type: cadence.AddressType},
+			{Identifier: "recipient", Type: cadence.AddressType},
+			{Identifier: "name", Type: cadence.StringType},
+			{Identifier: "type", Type: cadence.MetaType},
+		},
+		cadence.NewAddress(provider),
+		cadence.NewAddress(recipient),
+		cadence.String("capability"),
+		cadence.NewTypeValue(cadence.NewCapabilityType(cadence.StringType)),
+	))
+	assertValue(t, provider)(published.Provider())
+	assertValue(t, recipient)(published.Recipient())
+	assertValue(t, "capability")(published.Name())
+	assertValue[cadence.Type](t, cadence.NewCapabilityType(cadence.StringType))(published.ValueType())
+
+	unpublished := flow.InboxValueUnpublishedEvent(newFlowEvent(
+		flow.EventInboxValueUnpublished,
+		[]cadence.Field{
+			{Identifier: "provider", Type: cadence.AddressType},
+			{Identifier: "name", Type: cadence.StringType},
+		},
+		cadence.NewAddress(provider),
+		cadence.String("capability"),
+	))
+	assertValue(t, provider)(unpublished.Provider())
+	assertValue(t, "capability")(unpublished.Name())
+
+	claimed := flow.InboxValueClaimedEvent(newFlowEvent(
+		flow.EventInboxValueClaimed,
+		[]cadence.Field{
+			{Identifier: "provider", Type: cadence.AddressType},
+			{Identifier: "recipient", Type: cadence.AddressType},
+			{Identifier: "name", Type: cadence.StringType},
+		},
+		cadence.NewAddress(provider),
+		cadence.NewAddress(recipient),
+		cadence.String("capability"),
+	))
+	assertValue(t, provider)(claimed.Provider())
+	assertValue(t, recipient)(claimed.Recipient())
+	assertValue(t, "capability")(claimed.Name())
+
+	t.Run("Malformed event", func(t *testing.T) {
+		malformed := flow.InboxValuePublishedEvent(newFlowEvent(
+			flow.EventInboxValuePublished,
+			[]cadence.Field{
+				{Identifier: "provider", Type: cadence.StringType},
+				{Identifier: "type", Type: cadence.StringType},
+			},
+			cadence.String("0x01"),
+			cadence.String("Capability"),
+		))
+
+		_, err := malformed.Provider()
+		assert.Error(t, err)
+		_, err = malformed.Recipient()
+		assert.Error(t, err)
+		_, err = malformed.Name()
+		assert.Error(t, err)
+		_, err = malformed.ValueType()
+		assert.Error(t, err)
+	})
+}
+
+func TestFungibleTokenEvents(t *testing.T) {
+	owner := test.AddressGenerator().New()
+	amount, err := cadence.NewUFix64("10.5")
+	require.NoError(t, err)
+	balance, err := cadence.NewUFix64("42.0")
+	require.NoError(t, err)
+
+	deposited := flow.FungibleTokenDepositedEvent(newEvent(
+		"FungibleToken.Deposited",
+		tokenLocation,
+		[]cadence.Field{
+			{Identifier: "type", Type: cadence.StringType},
+			{Identifier: "amount", Type: cadence.UFix64Type},
+			{Identifier: "to", Type: cadence.NewOptionalType(cadence.AddressType)},
+			{Identifier: "toUUID", Type: cadence.UInt64Type},
+			{Identifier: "depositedUUID", Type: cadence.UInt64Type},
+			{Identifier: "balanceAfter", Type: cadence.UFix64Type},
+		},
+		cadence.String("A.1654653399040a61.FlowToken.Vault"),
+		amount,
+		cadence.NewOptional(cadence.NewAddress(owner)),
+		cadence.NewUInt64(1),
+		cadence.NewUInt64(2),
+		balance,
+	))
+	vaultType, err := deposited.VaultType()
+	require.NoError(t, err)
+	assert.Equal(t, "A.1654653399040a61.FlowToken.Vault", vaultType)
+	assertValue(t, amount)(deposited.Amount())
+	assertAddress(t, owner, true)(deposited.To())
+	assertValue(t, uint64(1))(deposited.ToUUID())
+	assertValue(t, uint64(2))(deposited.DepositedUUID())
+	assertValue(t, balance)(deposited.BalanceAfter())
+
+	withdrawn := flow.FungibleTokenWithdrawnEvent(newEvent(
+		"FungibleToken.Withdrawn",
+		tokenLocation,
+		[]cadence.Field{
+			{Identifier: "type", Type: cadence.StringType},
+			{Identifier: "amount", Type: cadence.UFix64Type},
+			{Identifier: "from", Type: cadence.NewOptionalType(cadence.AddressType)},
+			{Identifier: "fromUUID", Type: cadence.UInt64Type},
+			{Identifier: "withdrawnUUID", Type: cadence.UInt64Type},
+			{Identifier: "balanceAfter", Type: cadence.UFix64Type},
+		},
+		cadence.String("A.1654653399040a61.FlowToken.Vault"),
+		amount,
+		cadence.NewOptional(nil),
+		cadence.NewUInt64(3),
+		cadence.NewUInt64(4),
+		balance,
+	))
+	vaultType, err = withdrawn.VaultType()
+	require.NoError(t, err)
+	assert.Equal(t, "A.1654653399040a61.FlowToken.Vault", vaultType)
+	assertValue(t, amount)(withdrawn.Amount())
+	assertAddress(t, flow.EmptyAddress, false)(withdrawn.From())
+	assertValue(t, uint64(3))(withdrawn.FromUUID())
+	assertValue(t, uint64(4))(withdrawn.WithdrawnUUID())
+	assertValue(t, balance)(withdrawn.BalanceAfter())
+}
+
+func TestFlowTokenEvents(t *testing.T) {
+	owner := test.AddressGenerator().New()
+	amount, err := cadence.NewUFix64("0.001")
+	require.NoError(t, err)
+
+	flowTokenLocation := common.NewAddressLocation(nil, common.Address{0x16, 0x54, 0x65, 0x33, 0x99, 0x04, 0x0a, 0x61}, "FlowToken")
+
+	deposited := flow.FlowTokenDepositedEvent(newEvent(
+		"FlowToken.TokensDeposited",
+		flowTokenLocation,
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type},
+			{Identifier: "to", Type: cadence.NewOptionalType(cadence.AddressType)},
+		},
+		amount,
+		cadence.NewOptional(cadence.NewAddress(owner)),
+	))
+	assertValue(t, amount)(deposited.Amount())
+	assertAddress(t, owner, true)(deposited.To())
+
+	withdrawn := flow.FlowTokenWithdrawnEvent(newEvent(
+		"FlowToken.TokensWithdrawn",
+		flowTokenLocation,
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type},
+			{Identifier: "from", Type: cadence.NewOptionalType(cadence.AddressType)},
+		},
+		amount,
+		cadence.NewOptional(nil),
+	))
+	assertValue(t, amount)(withdrawn.Amount())
+	assertAddress(t, flow.EmptyAddress, false)(withdrawn.From())
+
+	t.Run("Malformed event", func(t *testing.T) {
+		malformed := flow.FlowTokenDepositedEvent(newEvent(
+			"FlowToken.TokensDeposited",
+			flowTokenLocation,
+			[]cadence.Field{{Identifier: "to", Type: cadence.StringType}},
+			cadence.String("0x01"),
+		))
+
+		_, err := malformed.Amount()
+		assert.Error(t, err)
+		_, _, err = malformed.To()
+		assert.Error(t, err)
+
+		_, err = flow.FlowTokenWithdrawnEvent(flow.Event{Type: "FlowToken.TokensWithdrawn"}).Amount()
+		assert.Error(t, err)
+	})
+}
+
+func assertValue[T any](t *testing.T, expected T) func(T, error) {
+	return func(actual T, err error) {
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func assertAddress(t *testing.T, expected flow.Address, expectedOk bool) func(flow.Address, bool, error) {
+	return func(actual flow.Address, ok bool, err error) {
+		require.NoError(t, err)
+		assert.Equal(t, expectedOk, ok)
+		assert.Equal(t, expected, actual)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+)
+
+// A FungibleTokenDepositedEvent is emitted by the FungibleToken contract when tokens are deposited into a vault.
+//
+// This event contains the following fields:
+// - type: String, the type identifier of the vault
+// - amount: UFix64
+// - to: Address?, the owner of the vault if it is stored in an account
+// - toUUID: UInt64
+// - depositedUUID: UInt64
+// - balanceAfter: UFix64
+type FungibleTokenDepositedEvent Event
+
+// VaultType returns the type identifier of the vault, for example A.1654653399040a61.FlowToken.Vault.
+func (evt FungibleTokenDepositedEvent) VaultType() (string, error) {
+	value, err := eventFieldAs[cadence.String](Event(evt), "type")
+	return string(value), err
+}
+
+// Amount returns the amount of tokens deposited.
+func (evt FungibleTokenDepositedEvent) Amount() (cadence.UFix64, error) {
+	return eventFieldAs[cadence.UFix64](Event(evt), "amount")
+}
+
+// To returns the owner of the vault the tokens were deposited into, or false if the vault has no owner.
+func (evt FungibleTokenDepositedEvent) To() (Address, bool, error) {
+	return eventOptionalAddressField(Event(evt), "to")
+}
+
+// ToUUID returns the UUID of the vault the tokens were deposited into.
+func (evt FungibleTokenDepositedEvent) ToUUID() (uint64, error) {
+	value, err := eventFieldAs[cadence.UInt64](Event(evt), "toUUID")
+	return uint64(value), err
+}
+
+// DepositedUUID returns the UUID of the vault that was deposited.
+func (evt FungibleTokenDepositedEvent) DepositedUUID() (uint64, error) {
+	value, err := eventFieldAs[cadence.UInt64](Event(evt), "depositedUUID")
+	return uint64(value), err
+}
+
+// BalanceAfter returns the balance of the receiving vault after the deposit.
+func (evt FungibleTokenDepositedEvent) BalanceAfter() (cadence.UFix64, error) {
+	return eventFieldAs[cadence.UFix64](Event(evt), "balanceAfter")
+}
+
+// A FungibleTokenWithdrawnEvent is emitted by the FungibleToken contract when tokens are withdrawn from a vault.
+//
+// This event contains the following fields:
+// - type: String, the type identifier of the vault
+// - amount: UFix64
+// - from: Address?, the owner of the vault if it is stored in an account
+// - fromUUID: UInt64
+// - withdrawnUUID: UInt64
+// - balanceAfter: UFix64
+type FungibleTokenWithdrawnEvent Event
+
+// VaultType returns the type identifier of the vault, for example A.1654653399040a61.FlowToken.Vault.
+func (evt FungibleTokenWithdrawnEvent) VaultType() (string, error) {
+	value, err := eventFieldAs[cadence.String](Event(evt), "type")
+	return string(value), err
+}
+
+// Amount returns the amount of tokens withdrawn.
+func (evt FungibleTokenWithdrawnEvent) Amount() (cadence.UFix64, error) {
+	return eventFieldAs[cadence.UFix64](Event(evt), "amount")
+}
+
+// From returns the owner of the vault the tokens were withdrawn from, or false if the vault has no owner.
+func (evt FungibleTokenWithdrawnEvent) From() (Address, bool, error) {
+	return eventOptionalAddressField(Event(evt), "from")
+}
+
+// FromUUID returns the UUID of the vault the tokens were withdrawn from.
+func (evt FungibleTokenWithdrawnEvent) FromUUID() (uint64, error) {
+	value, err := eventFieldAs[cadence.UInt64](Event(evt), "fromUUID")
+	return uint64(value), err
+}
+
+// WithdrawnUUID returns the UUID of the vault that was withdrawn.
+func (evt FungibleTokenWithdrawnEvent) WithdrawnUUID() (uint64, error) {
+	value, err := eventFieldAs[cadence.UInt64](Event(evt), "withdrawnUUID")
+	return uint64(value), err
+}
+
+// BalanceAfter returns the balance of the vault after the withdrawal.
+func (evt FungibleTokenWithdrawnEvent) BalanceAfter() (cadence.UFix64, error) {
+	return eventFieldAs[cadence.UFix64](Event(evt), "balanceAfter")
+}
+
+// A FlowTokenDepositedEvent is emitted by the FlowToken contract when FLOW is deposited into a vault.
+//
+// This event contains the following fields:
+// - amount: UFix64
+// - to: Address?
+type FlowTokenDepositedEvent Event
+
+// Amount returns the amount of FLOW deposited.
+func (evt FlowTokenDepositedEvent) Amount() (cadence.UFix64, error) {
+	return eventFieldAs[cadence.UFix64](Event(evt), "amount")
+}
+
+// To returns the owner of the vault the tokens were deposited into, or false if the vault has no owner.
+func (evt FlowTokenDepositedEvent) To() (Address, bool, error) {
+	return eventOptionalAddressField(Event(evt), "to")
+}
+
+// A FlowTokenWithdrawnEvent is emitted by the FlowToken contract when FLOW is withdrawn from a vault.
+//
+// This event contains the following fields:
+// - amount: UFix64
+// - from: Address?
+type FlowTokenWithdrawnEvent Event
+
+// Amount returns the amount of FLOW withdrawn.
+func (evt FlowTokenWithdrawnEvent) Amount() (cadence.UFix64, error) {
+	return eventFieldAs[cadence.UFix64](Event(evt), "amount")
+}
+
+// From returns the owner of the vault the tokens were withdrawn from, or false if the vault has no owner.
+func (evt FlowTokenWithdrawnEvent) From() (Address, bool, error) {
+	return eventOptionalAddressField(Event(evt), "from")
+}
+
+// eventOptionalAddressField returns the value of an optional address field, or false if the field is nil.
+func eventOptionalAddressField(evt Event, name string) (Address, bool, error) {
+	value := eventField(evt, name)
+	if optional, ok := value.(cadence.Optional); ok {
+		if optional.Value == nil {
+			return EmptyAddress, false, nil
+		}
+		value = optional.Value
+	}
+
+	address, ok := value.(cadence.Address)
+	if !ok {
+		return EmptyAddress, false, fmt.Errorf("event %s has no address field %s", evt.Type, name)
+	}
+
+	return BytesToAddress(address.Bytes()), true, nil
+}
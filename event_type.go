@@ -0,0 +1,237 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidEventType is returned for malformed event type IDs.
+var ErrInvalidEventType = errors.New("invalid event type")
+
+// protocolEventPrefix is the prefix of the events emitted by the Flow protocol itself, such as flow.AccountCreated.
+const protocolEventPrefix = "flow"
+
+// evmContractName is the name of the contract emitting the Flow EVM events.
+const evmContractName = "EVM"
+
+// An EventType identifies a type of event.
+//
+// Events are either emitted by a contract and identified as A.<address>.<contract>.<event>, for example
+// A.f233dcee88fe0abe.FungibleToken.Deposited, or emitted by the protocol and identified as flow.<event>,
+// for example flow.AccountCreated. Events of the EVM contract, such as A.e467b9dd11fa00df.EVM.BlockExecuted,
+// are contract events.
+type EventType struct {
+	address  Address
+	contract string
+	name     string
+}
+
+// NewEventType returns the type of an event emitted by a contract.
+func NewEventType(address Address, contract string, name string) EventType {
+	return EventType{
+		address:  address,
+		contract: contract,
+		name:     name,
+	}
+}
+
+// NewProtocolEventType returns the type of an event emitted by the protocol, for example "AccountCreated".
+func NewProtocolEventType(name string) EventType {
+	return EventType{name: name}
+}
+
+// ParseEventType parses an event type ID.
+//
+// An error wrapping ErrInvalidEventType is returned if the ID is not a well-formed contract or protocol event type.
+func ParseEventType(id string) (EventType, error) {
+	parts := strings.Split(id, ".")
+
+	switch {
+	case len(parts) == 2 && parts[0] == protocolEventPrefix:
+		if !isIdentifier(parts[1]) {
+			return EventType{}, fmt.Errorf("%w %q: invalid event name", ErrInvalidEventType, id)
+		}
+		return NewProtocolEventType(parts[1]), nil
+
+	case len(parts) == 4 && parts[0] == "A":
+		address, err := parseEventAddress(parts[1])
+		if err != nil {
+			return EventType{}, fmt.Errorf("%w %q: %s", ErrInvalidEventType, id, err)
+		}
+		if !isIdentifier(parts[2]) {
+			return EventType{}, fmt.Errorf("%w %q: invalid contract name", ErrInvalidEventType, id)
+		}
+		if !isIdentifier(parts[3]) {
+			return EventType{}, fmt.Errorf("%w %q: invalid event name", ErrInvalidEventType, id)
+		}
+		return NewEventType(address, parts[2], parts[3]), nil
+
+	default:
+		return EventType{}, fmt.Errorf(
+			"%w %q: expected A.<address>.<contract>.<event> or flow.<event>",
+			ErrInvalidEventType,
+			id,
+		)
+	}
+}
+
+// MustParseEventType parses an event type ID and panics if it is invalid.
+func MustParseEventType(id string) EventType {
+	eventType, err := ParseEventType(id)
+	if err != nil {
+		panic(err)
+	}
+	return eventType
+}
+
+// parseEventAddress parses the address of an event type ID, which has no 0x prefix and 16 hex digits.
+func parseEventAddress(s string) (Address, error) {
+	if len(s) != 2*AddressLength {
+		return Address{}, fmt.Errorf("address must have %d hex digits", 2*AddressLength)
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return Address{}, fmt.Errorf("invalid address: %w", err)
+	}
+
+	return BytesToAddress(b), nil
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// IsProtocol returns true for events emitted by the protocol, such as flow.AccountCreated.
+func (e EventType) IsProtocol() bool {
+	return e.contract == ""
+}
+
+// IsEVM returns true for events emitted by the Flow EVM contract.
+func (e EventType) IsEVM() bool {
+	return e.contract == evmContractName
+}
+
+// Address returns the address of the contract emitting the event, or EmptyAddress for protocol events.
+func (e EventType) Address() Address {
+	return e.address
+}
+
+// Contract returns the name of the contract emitting the event, or an empty string for protocol events.
+func (e EventType) Contract() string {
+	return e.contract
+}
+
+// Name returns the name of the event.
+func (e EventType) Name() string {
+	return e.name
+}
+
+// ContractID returns the ID of the contract emitting the event, in the A.<address>.<contract> form
+// used by EventFilter.Contracts, or an empty string for protocol events.
+func (e EventType) ContractID() string {
+	if e.IsProtocol() {
+		return ""
+	}
+	return fmt.Sprintf("A.%s.%s", e.address.Hex(), e.contract)
+}
+
+// String returns the event type ID.
+func (e EventType) String() string {
+	if e.IsProtocol() {
+		return fmt.Sprintf("%s.%s", protocolEventPrefix, e.name)
+	}
+	return fmt.Sprintf("%s.%s", e.ContractID(), e.name)
+}
+
+// Validate returns an error if the event cannot be emitted on the given chain.
+//
+// The contract address must be a valid address of the chain, and EVM events must be emitted
+// by the EVM contract of the service account.
+func (e EventType) Validate(chain ChainID) error {
+	if e.IsProtocol() {
+		return nil
+	}
+
+	if address := e.address; !address.IsValid(chain) {
+		return fmt.Errorf("event type %s: address %s is not valid on %s", e, e.address, chain)
+	}
+
+	if e.IsEVM() && e.address != ServiceAddress(chain) {
+		return fmt.Errorf("event type %s: the EVM contract is deployed at %s on %s", e, ServiceAddress(chain), chain)
+	}
+
+	return nil
+}
+
+// NewEventFilter returns a filter matching events of the given types.
+func NewEventFilter(types ...EventType) EventFilter {
+	filter := EventFilter{}
+	for _, eventType := range types {
+		filter.EventTypes = append(filter.EventTypes, eventType.String())
+	}
+	return filter
+}
+
+// Validate parses the event types, contracts and addresses of the filter and checks them against the given chain.
+func (f EventFilter) Validate(chain ChainID) error {
+	for _, id := range f.EventTypes {
+		eventType, err := ParseEventType(id)
+		if err != nil {
+			return err
+		}
+		if err := eventType.Validate(chain); err != nil {
+			return err
+		}
+	}
+
+	for _, contract := range f.Contracts {
+		eventType, err := ParseEventType(contract + ".Event")
+		if err != nil || eventType.IsProtocol() {
+			return fmt.Errorf("invalid contract %q: expected A.<address>.<contract>", contract)
+		}
+		if address := eventType.Address(); !address.IsValid(chain) {
+			return fmt.Errorf("invalid contract %q: address is not valid on %s", contract, chain)
+		}
+	}
+
+	for _, address := range f.Addresses {
+		a := HexToAddress(address)
+		if !a.IsValid(chain) {
+			return fmt.Errorf("invalid address %q on %s", address, chain)
+		}
+	}
+
+	return nil
+}
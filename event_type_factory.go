@@ -56,3 +56,8 @@ func (f eventTypeFactory) String() string {
 func NewEventTypeFactory() eventTypeFactory {
 	return eventTypeFactory{}
 }
+
+// EventType parses the event type built by the factory.
+func (f eventTypeFactory) EventType() (EventType, error) {
+	return ParseEventType(f.String())
+}
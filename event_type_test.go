@@ -0,0 +1,137 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+func TestParseEventType(t *testing.T) {
+	t.Run("Contract event", func(t *testing.T) {
+		eventType, err := flow.ParseEventType("A.f233dcee88fe0abe.FungibleToken.Deposited")
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.HexToAddress("f233dcee88fe0abe"), eventType.Address())
+		assert.Equal(t, "FungibleToken", eventType.Contract())
+		assert.Equal(t, "Deposited", eventType.Name())
+		assert.Equal(t, "A.f233dcee88fe0abe.FungibleToken", eventType.ContractID())
+		assert.Equal(t, "A.f233dcee88fe0abe.FungibleToken.Deposited", eventType.String())
+		assert.False(t, eventType.IsProtocol())
+		assert.False(t, eventType.IsEVM())
+	})
+
+	t.Run("Protocol event", func(t *testing.T) {
+		eventType, err := flow.ParseEventType(flow.EventAccountCreated)
+		require.NoError(t, err)
+
+		assert.Equal(t, flow.EmptyAddress, eventType.Address())
+		assert.Empty(t, eventType.Contract())
+		assert.Equal(t, "AccountCreated", eventType.Name())
+		assert.Empty(t, eventType.ContractID())
+		assert.Equal(t, flow.EventAccountCreated, eventType.String())
+		assert.True(t, eventType.IsProtocol())
+	})
+
+	t.Run("EVM event", func(t *testing.T) {
+		eventType, err := flow.ParseEventType("A.e467b9dd11fa00df.EVM.TransactionExecuted")
+		require.NoError(t, err)
+
+		assert.True(t, eventType.IsEVM())
+		assert.Equal(t, "TransactionExecuted", eventType.Name())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, id := range []string{
+			"",
+			"flow",
+			"flow.",
+			"flow.Account.Created",
+			"A.f233dcee88fe0abe.FungibleToken",
+			"A.f233dcee88fe0abe.FungibleToken.Deposited.Extra",
+			"A.0x233dcee88fe0abe.FungibleToken.Deposited",
+			"A.f233dcee88fe0a.FungibleToken.Deposited",
+			"A.f233dcee88fe0abz.FungibleToken.Deposited",
+			"A.f233dcee88fe0abe.1Token.Deposited",
+			"A.f233dcee88fe0abe.FungibleToken.Deposit-ed",
+			"B.f233dcee88fe0abe.FungibleToken.Deposited",
+		} {
+			_, err := flow.ParseEventType(id)
+			assert.ErrorIs(t, err, flow.ErrInvalidEventType, id)
+		}
+
+		assert.Panics(t, func() { flow.MustParseEventType("invalid") })
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		address := flow.HexToAddress("1654653399040a61")
+		eventType := flow.NewEventType(address, "FlowToken", "TokensWithdrawn")
+		assert.Equal(t, eventType, flow.MustParseEventType(eventType.String()))
+
+		fromFactory, err := flow.NewEventTypeFactory().
+			WithAddress(address).
+			WithContractName("FlowToken").
+			WithEventName("TokensWithdrawn").
+			EventType()
+		require.NoError(t, err)
+		assert.Equal(t, eventType, fromFactory)
+
+		assert.Equal(t, flow.NewProtocolEventType("AccountKeyAdded"), flow.MustParseEventType(flow.EventAccountKeyAdded))
+	})
+}
+
+func TestEventType_Validate(t *testing.T) {
+	fungibleToken := flow.MustParseEventType("A.f233dcee88fe0abe.FungibleToken.Deposited")
+	assert.NoError(t, fungibleToken.Validate(flow.Mainnet))
+	assert.Error(t, fungibleToken.Validate(flow.Testnet))
+
+	assert.NoError(t, flow.MustParseEventType(flow.EventAccountCreated).Validate(flow.Testnet))
+
+	evm := flow.MustParseEventType("A.e467b9dd11fa00df.EVM.BlockExecuted")
+	assert.NoError(t, evm.Validate(flow.Mainnet))
+	assert.Error(t, evm.Validate(flow.Testnet))
+
+	otherEVM := flow.NewEventType(flow.HexToAddress("f233dcee88fe0abe"), "EVM", "BlockExecuted")
+	assert.Error(t, otherEVM.Validate(flow.Mainnet))
+}
+
+func TestEventFilter(t *testing.T) {
+	filter := flow.NewEventFilter(
+		flow.MustParseEventType("A.f233dcee88fe0abe.FungibleToken.Deposited"),
+		flow.NewProtocolEventType("AccountCreated"),
+	)
+	assert.Equal(t, []string{"A.f233dcee88fe0abe.FungibleToken.Deposited", "flow.AccountCreated"}, filter.EventTypes)
+	assert.NoError(t, filter.Validate(flow.Mainnet))
+	assert.Error(t, filter.Validate(flow.Testnet))
+
+	filter = flow.EventFilter{
+		Contracts: []string{"A.1654653399040a61.FlowToken"},
+		Addresses: []string{"e467b9dd11fa00df"},
+	}
+	assert.NoError(t, filter.Validate(flow.Mainnet))
+
+	assert.Error(t, flow.EventFilter{EventTypes: []string{"A.1.Foo.Bar"}}.Validate(flow.Mainnet))
+	assert.Error(t, flow.EventFilter{Contracts: []string{"A.1654653399040a61"}}.Validate(flow.Mainnet))
+	assert.Error(t, flow.EventFilter{Contracts: []string{"A.1654653399040a61.FlowToken.Extra"}}.Validate(flow.Mainnet))
+	assert.Error(t, flow.EventFilter{Addresses: []string{"0x01"}}.Validate(flow.Mainnet))
+}
@@ -0,0 +1,259 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package evm decodes the events emitted by the Flow EVM contract.
+//
+// Every EVM transaction and block executed on Flow emits an EVM.TransactionExecuted or
+// EVM.BlockExecuted Cadence event. The decoders in this package turn these events into Go
+// values using the go-ethereum address and hash types.
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/rlp"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// ErrUnexpectedEventType is returned when an event is decoded as the wrong EVM event.
+var ErrUnexpectedEventType = errors.New("evm: unexpected event type")
+
+// ErrorCode is the error code of a failed EVM transaction, as reported by Flow EVM.
+//
+// Zero means the transaction succeeded. Codes below 400 are validation errors, for which the
+// transaction was not executed; codes from 400 on are execution errors such as a revert.
+type ErrorCode uint16
+
+// ErrCodeNone is the error code of a successful transaction.
+const ErrCodeNone ErrorCode = 0
+
+// IsValidationError returns true if the transaction was rejected before execution.
+func (c ErrorCode) IsValidationError() bool {
+	return c != ErrCodeNone && c < 400
+}
+
+// IsExecutionError returns true if the transaction failed during execution.
+func (c ErrorCode) IsExecutionError() bool {
+	return c >= 400
+}
+
+// A Log is an EVM log emitted by a transaction.
+type Log struct {
+	Address common.Address
+	Topics  []common.Hash
+	Data    []byte
+}
+
+// TransactionExecuted is the decoded EVM.TransactionExecuted event.
+type TransactionExecuted struct {
+	// BlockHeight is the height of the EVM block the transaction is included in.
+	BlockHeight uint64
+	// Hash is the EVM transaction hash.
+	Hash common.Hash
+	// Index is the index of the transaction in the EVM block.
+	Index uint16
+	// Payload is the encoded EVM transaction, or the encoded call for transactions sent by a COA.
+	Payload      []byte
+	ErrorCode    ErrorCode
+	ErrorMessage string
+	// Type is the EVM transaction type.
+	Type        uint8
+	GasConsumed uint64
+	// ContractAddress is the address of the deployed contract, or nil if the transaction did not deploy one.
+	ContractAddress  *common.Address
+	Logs             []Log
+	ReturnedData     []byte
+	PrecompiledCalls []byte
+}
+
+// Failed returns true if the transaction did not succeed.
+func (t TransactionExecuted) Failed() bool {
+	return t.ErrorCode != ErrCodeNone
+}
+
+// BlockExecuted is the decoded EVM.BlockExecuted event.
+type BlockExecuted struct {
+	Height uint64
+	Hash   common.Hash
+	// Timestamp is the block time in seconds since the Unix epoch.
+	Timestamp uint64
+	// TotalSupply is the total amount of FLOW in EVM, in attoFLOW.
+	TotalSupply         *big.Int
+	TotalGasUsed        uint64
+	ParentHash          common.Hash
+	ReceiptRoot         common.Hash
+	TransactionHashRoot common.Hash
+	PrevRandao          common.Hash
+}
+
+// DecodeTransactionExecuted decodes an EVM.TransactionExecuted event.
+func DecodeTransactionExecuted(event flow.Event) (*TransactionExecuted, error) {
+	fields, err := eventFields(event, "TransactionExecuted")
+	if err != nil {
+		return nil, err
+	}
+
+	d := decoder{fields: fields}
+	tx := &TransactionExecuted{
+		BlockHeight:      d.uint64("blockHeight"),
+		Hash:             common.BytesToHash(d.bytes("hash")),
+		Index:            uint16(d.uint("index")),
+		Payload:          d.bytes("payload"),
+		ErrorCode:        ErrorCode(d.uint("errorCode")),
+		ErrorMessage:     d.string("errorMessage"),
+		Type:             uint8(d.uint("type")),
+		GasConsumed:      d.uint64("gasConsumed"),
+		ReturnedData:     d.bytes("returnedData"),
+		PrecompiledCalls: d.optionalBytes("precompiledCalls"),
+	}
+
+	if address := strings.TrimPrefix(d.string("contractAddress"), "0x"); address != "" {
+		contract := common.HexToAddress(address)
+		tx.ContractAddress = &contract
+	}
+
+	if logs := d.bytes("logs"); len(logs) > 0 {
+		if err := rlp.DecodeBytes(logs, &tx.Logs); err != nil {
+			return nil, fmt.Errorf("evm: cannot decode logs of transaction %s: %w", tx.Hash, err)
+		}
+	}
+
+	if d.err != nil {
+		return nil, fmt.Errorf("evm: cannot decode TransactionExecuted event: %w", d.err)
+	}
+
+	return tx, nil
+}
+
+// DecodeBlockExecuted decodes an EVM.BlockExecuted event.
+func DecodeBlockExecuted(event flow.Event) (*BlockExecuted, error) {
+	fields, err := eventFields(event, "BlockExecuted")
+	if err != nil {
+		return nil, err
+	}
+
+	d := decoder{fields: fields}
+	block := &BlockExecuted{
+		Height:              d.uint64("height"),
+		Hash:                common.BytesToHash(d.bytes("hash")),
+		Timestamp:           d.uint64("timestamp"),
+		TotalSupply:         d.bigInt("totalSupply"),
+		TotalGasUsed:        d.uint64("totalGasUsed"),
+		ParentHash:          common.BytesToHash(d.bytes("parentHash")),
+		ReceiptRoot:         common.BytesToHash(d.bytes("receiptRoot")),
+		TransactionHashRoot: common.BytesToHash(d.bytes("transactionHashRoot")),
+		PrevRandao:          common.BytesToHash(d.bytes("prevrandao")),
+	}
+
+	if d.err != nil {
+		return nil, fmt.Errorf("evm: cannot decode BlockExecuted event: %w", d.err)
+	}
+
+	return block, nil
+}
+
+// eventFields checks that the event is the given event of the EVM contract and returns its fields by name.
+func eventFields(event flow.Event, name string) (map[string]cadence.Value, error) {
+	if !strings.HasSuffix(event.Type, ".EVM."+name) {
+		return nil, fmt.Errorf("%w: expected EVM.%s, got %s", ErrUnexpectedEventType, name, event.Type)
+	}
+	return cadence.FieldsMappedByName(event.Value), nil
+}
+
+// decoder reads event fields, recording the first missing or mistyped field.
+type decoder struct {
+	fields map[string]cadence.Value
+	err    error
+}
+
+func (d *decoder) fail(name string, value cadence.Value) {
+	if d.err == nil {
+		d.err = fmt.Errorf("unexpected value %v for field %s", value, name)
+	}
+}
+
+func (d *decoder) uint64(name string) uint64 {
+	value, ok := d.fields[name].(cadence.UInt64)
+	if !ok {
+		d.fail(name, d.fields[name])
+	}
+	return uint64(value)
+}
+
+func (d *decoder) uint(name string) uint64 {
+	switch value := d.fields[name].(type) {
+	case cadence.UInt8:
+		return uint64(value)
+	case cadence.UInt16:
+		return uint64(value)
+	default:
+		d.fail(name, value)
+		return 0
+	}
+}
+
+func (d *decoder) string(name string) string {
+	value, ok := d.fields[name].(cadence.String)
+	if !ok {
+		d.fail(name, d.fields[name])
+	}
+	return string(value)
+}
+
+func (d *decoder) bigInt(name string) *big.Int {
+	value, ok := d.fields[name].(cadence.Int)
+	if !ok {
+		d.fail(name, d.fields[name])
+		return nil
+	}
+	return value.Big()
+}
+
+// bytes reads a [UInt8] or [UInt8; N] field.
+func (d *decoder) bytes(name string) []byte {
+	array, ok := d.fields[name].(cadence.Array)
+	if !ok {
+		d.fail(name, d.fields[name])
+		return nil
+	}
+
+	b := make([]byte, len(array.Values))
+	for i, element := range array.Values {
+		value, ok := element.(cadence.UInt8)
+		if !ok {
+			d.fail(name, d.fields[name])
+			return nil
+		}
+		b[i] = byte(value)
+	}
+	return b
+}
+
+// optionalBytes reads a byte array field that older versions of the EVM contract do not emit.
+func (d *decoder) optionalBytes(name string) []byte {
+	if _, ok := d.fields[name]; !ok {
+		return nil
+	}
+	return d.bytes(name)
+}
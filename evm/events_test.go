@@ -0,0 +1,223 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evm_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/encoding/ccf"
+	ethcommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
+	"github.com/onflow/flow-go-sdk/evm"
+)
+
+var evmContract = contracts.MustForChain(flow.Mainnet).EVM
+
+var (
+	bytesType   = cadence.NewVariableSizedArrayType(cadence.UInt8Type)
+	bytes32Type = cadence.NewConstantSizedArrayType(32, cadence.UInt8Type)
+
+	// transactionExecutedFields are the fields of EVM.TransactionExecuted, in the order the EVM contract declares them.
+	transactionExecutedFields = []cadence.Field{
+		{Identifier: "hash", Type: bytes32Type},
+		{Identifier: "index", Type: cadence.UInt16Type},
+		{Identifier: "type", Type: cadence.UInt8Type},
+		{Identifier: "payload", Type: bytesType},
+		{Identifier: "errorCode", Type: cadence.UInt16Type},
+		{Identifier: "errorMessage", Type: cadence.StringType},
+		{Identifier: "gasConsumed", Type: cadence.UInt64Type},
+		{Identifier: "contractAddress", Type: cadence.StringType},
+		{Identifier: "logs", Type: bytesType},
+		{Identifier: "blockHeight", Type: cadence.UInt64Type},
+		{Identifier: "returnedData", Type: bytesType},
+		{Identifier: "precompiledCalls", Type: bytesType},
+		{Identifier: "stateUpdateChecksum", Type: cadence.NewConstantSizedArrayType(4, cadence.UInt8Type)},
+	}
+
+	// blockExecutedFields are the fields of EVM.BlockExecuted, in the order the EVM contract declares them.
+	blockExecutedFields = []cadence.Field{
+		{Identifier: "height", Type: cadence.UInt64Type},
+		{Identifier: "hash", Type: bytes32Type},
+		{Identifier: "timestamp", Type: cadence.UInt64Type},
+		{Identifier: "totalSupply", Type: cadence.IntType},
+		{Identifier: "totalGasUsed", Type: cadence.UInt64Type},
+		{Identifier: "parentHash", Type: bytes32Type},
+		{Identifier: "receiptRoot", Type: bytes32Type},
+		{Identifier: "transactionHashRoot", Type: bytes32Type},
+		{Identifier: "prevrandao", Type: bytes32Type},
+	}
+)
+
+func byteArray(b []byte) cadence.Array {
+	values := make([]cadence.Value, len(b))
+	for i, v := range b {
+		values[i] = cadence.UInt8(v)
+	}
+	return cadence.NewArray(values)
+}
+
+// newEvent creates an EVM event with the given fields, omitting the fields without a value,
+// and round-trips it through CCF to check the values match the field types.
+func newEvent(t *testing.T, name string, fields []cadence.Field, values map[string]cadence.Value) flow.Event {
+	eventValues := make([]cadence.Value, 0, len(fields))
+	eventFields := make([]cadence.Field, 0, len(fields))
+	for _, field := range fields {
+		value, ok := values[field.Identifier]
+		if !ok {
+			continue
+		}
+		if array, ok := value.(cadence.Array); ok {
+			value = array.WithType(field.Type.(cadence.ArrayType))
+		}
+		eventValues = append(eventValues, value)
+		eventFields = append(eventFields, field)
+	}
+
+	location := common.NewAddressLocation(nil, common.Address(evmContract.Address), "EVM")
+	event := cadence.NewEvent(eventValues).WithType(cadence.NewEventType(location, "EVM."+name, eventFields, nil))
+
+	payload, err := ccf.Encode(event)
+	require.NoError(t, err)
+	decoded, err := ccf.Decode(nil, payload)
+	require.NoError(t, err)
+
+	return flow.Event{
+		Type:    evmContract.EventType(name),
+		Value:   decoded.(cadence.Event),
+		Payload: payload,
+	}
+}
+
+func TestDecodeTransactionExecuted(t *testing.T) {
+	hash := ethcommon.HexToHash("0x7a8f5d0b8c9e6a3f2d1c0b9a8f7e6d5c4b3a29180f1e2d3c4b5a69788796a5b4")
+	logs := []evm.Log{{
+		Address: ethcommon.HexToAddress("0x000000000000000000000002f239A6A1DA2F5e6A"),
+		Topics:  []ethcommon.Hash{ethcommon.HexToHash("0x01"), ethcommon.HexToHash("0x02")},
+		Data:    []byte{0x2a},
+	}}
+	encodedLogs, err := rlp.EncodeToBytes(logs)
+	require.NoError(t, err)
+
+	fields := map[string]cadence.Value{
+		"blockHeight":         cadence.NewUInt64(12),
+		"hash":                byteArray(hash.Bytes()),
+		"index":               cadence.NewUInt16(3),
+		"payload":             byteArray([]byte{0xf8, 0x6b}),
+		"errorCode":           cadence.NewUInt16(0),
+		"errorMessage":        cadence.String(""),
+		"type":                cadence.NewUInt8(255),
+		"gasConsumed":         cadence.NewUInt64(21_000),
+		"contractAddress":     cadence.String("0x99466ed2e37b892a2ee3e9cd55a98b68f5735db2"),
+		"logs":                byteArray(encodedLogs),
+		"returnedData":        byteArray([]byte{0x01}),
+		"precompiledCalls":    byteArray(nil),
+		"stateUpdateChecksum": byteArray([]byte{1, 2, 3, 4}),
+	}
+
+	tx, err := evm.DecodeTransactionExecuted(newEvent(t, "TransactionExecuted", transactionExecutedFields, fields))
+	require.NoError(t, err)
+
+	contract := ethcommon.HexToAddress("0x99466ed2e37b892a2ee3e9cd55a98b68f5735db2")
+	assert.Equal(t, &evm.TransactionExecuted{
+		BlockHeight:      12,
+		Hash:             hash,
+		Index:            3,
+		Payload:          []byte{0xf8, 0x6b},
+		Type:             255,
+		GasConsumed:      21_000,
+		ContractAddress:  &contract,
+		Logs:             logs,
+		ReturnedData:     []byte{0x01},
+		PrecompiledCalls: []byte{},
+	}, tx)
+	assert.False(t, tx.Failed())
+
+	t.Run("Failed", func(t *testing.T) {
+		failed := map[string]cadence.Value{}
+		for name, value := range fields {
+			failed[name] = value
+		}
+		failed["errorCode"] = cadence.NewUInt16(400)
+		failed["errorMessage"] = cadence.String("execution reverted")
+		failed["contractAddress"] = cadence.String("")
+		failed["logs"] = byteArray(nil)
+		delete(failed, "precompiledCalls")
+
+		tx, err := evm.DecodeTransactionExecuted(newEvent(t, "TransactionExecuted", transactionExecutedFields, failed))
+		require.NoError(t, err)
+		assert.True(t, tx.Failed())
+		assert.True(t, tx.ErrorCode.IsExecutionError())
+		assert.False(t, tx.ErrorCode.IsValidationError())
+		assert.Equal(t, "execution reverted", tx.ErrorMessage)
+		assert.Nil(t, tx.ContractAddress)
+		assert.Empty(t, tx.Logs)
+		assert.Nil(t, tx.PrecompiledCalls)
+	})
+
+	t.Run("Missing field", func(t *testing.T) {
+		_, err := evm.DecodeTransactionExecuted(newEvent(t, "TransactionExecuted", transactionExecutedFields, map[string]cadence.Value{
+			"blockHeight": cadence.NewUInt64(12),
+		}))
+		assert.Error(t, err)
+	})
+
+	t.Run("Wrong event", func(t *testing.T) {
+		_, err := evm.DecodeTransactionExecuted(newEvent(t, "BlockExecuted", blockExecutedFields, fields))
+		assert.ErrorIs(t, err, evm.ErrUnexpectedEventType)
+	})
+}
+
+func TestDecodeBlockExecuted(t *testing.T) {
+	hash := ethcommon.HexToHash("0x01")
+	parent := ethcommon.HexToHash("0x02")
+
+	event := newEvent(t, "BlockExecuted", blockExecutedFields, map[string]cadence.Value{
+		"height":              cadence.NewUInt64(7),
+		"hash":                byteArray(hash.Bytes()),
+		"timestamp":           cadence.NewUInt64(1_700_000_000),
+		"totalSupply":         cadence.NewIntFromBig(big.NewInt(5_000_000_000_000_000_000)),
+		"totalGasUsed":        cadence.NewUInt64(42_000),
+		"parentHash":          byteArray(parent.Bytes()),
+		"receiptRoot":         byteArray(ethcommon.HexToHash("0x03").Bytes()),
+		"transactionHashRoot": byteArray(ethcommon.HexToHash("0x04").Bytes()),
+		"prevrandao":          byteArray(ethcommon.HexToHash("0x05").Bytes()),
+	})
+
+	block, err := evm.DecodeBlockExecuted(event)
+	require.NoError(t, err)
+	assert.Equal(t, &evm.BlockExecuted{
+		Height:              7,
+		Hash:                hash,
+		Timestamp:           1_700_000_000,
+		TotalSupply:         big.NewInt(5_000_000_000_000_000_000),
+		TotalGasUsed:        42_000,
+		ParentHash:          parent,
+		ReceiptRoot:         ethcommon.HexToHash("0x03"),
+		TransactionHashRoot: ethcommon.HexToHash("0x04"),
+		PrevRandao:          ethcommon.HexToHash("0x05"),
+	}, block)
+}
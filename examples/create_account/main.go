@@ -74,7 +74,8 @@ func CreateAccountDemo() {
 	for _, event := range accountCreationTxRes.Events {
 		if event.Type == flow.EventAccountCreated {
 			accountCreatedEvent := flow.AccountCreatedEvent(event)
-			myAddress = accountCreatedEvent.Address()
+			myAddress, err = accountCreatedEvent.Address()
+			examples.Handle(err)
 		}
 	}
 
@@ -82,7 +82,8 @@ func DeployContractDemo() {
 	for _, event := range accountCreationTxRes.Events {
 		if event.Type == flow.EventAccountCreated {
 			accountCreatedEvent := flow.AccountCreatedEvent(event)
-			myAddress = accountCreatedEvent.Address()
+			myAddress, err = accountCreatedEvent.Address()
+			examples.Handle(err)
 		}
 	}
 
@@ -126,7 +127,8 @@ func DeployContractDemo() {
 	for _, event := range deployContractTxResp.Events {
 		if event.Type == flow.EventAccountCreated {
 			accountCreatedEvent := flow.AccountCreatedEvent(event)
-			nftAddress = accountCreatedEvent.Address()
+			nftAddress, err = accountCreatedEvent.Address()
+			examples.Handle(err)
 		}
 	}
 
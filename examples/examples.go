@@ -174,7 +174,8 @@ func CreateAccountWithContracts(flowClient access.Client, publicKeys []*flow.Acc
 		}
 		accountCreatedEvent := flow.AccountCreatedEvent(event)
 
-		addr := accountCreatedEvent.Address()
+		addr, err := accountCreatedEvent.Address()
+		Handle(err)
 		account, err := flowClient.GetAccount(ctx, addr)
 		Handle(err)
 
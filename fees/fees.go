@@ -0,0 +1,233 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fees extracts the transaction fees charged by the network from transaction results.
+//
+// Fees are reported by the FlowFees contract through a FeesDeducted event, while the
+// corresponding FLOW movement from the payer to the fee vault is reported by FlowToken
+// withdrawal and deposit events.
+package fees
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
+)
+
+// coreContracts returns the core contracts of the chain.
+func coreContracts(chain flow.ChainID) (*contracts.CoreContracts, error) {
+	core, err := contracts.ForChain(chain)
+	if err != nil {
+		return nil, fmt.Errorf("fees: %w", err)
+	}
+	return core, nil
+}
+
+// FeesDeductedEventType returns the type of the FlowFees.FeesDeducted event on the given chain.
+func FeesDeductedEventType(chain flow.ChainID) (string, error) {
+	core, err := coreContracts(chain)
+	if err != nil {
+		return "", err
+	}
+	return core.EventTypes().FlowFeesFeesDeducted, nil
+}
+
+// TokensWithdrawnEventType returns the type of the FlowToken.TokensWithdrawn event on the given chain.
+func TokensWithdrawnEventType(chain flow.ChainID) (string, error) {
+	core, err := coreContracts(chain)
+	if err != nil {
+		return "", err
+	}
+	return core.EventTypes().FlowTokenTokensWithdrawn, nil
+}
+
+// TokensDepositedEventType returns the type of the FlowToken.TokensDeposited event on the given chain.
+func TokensDepositedEventType(chain flow.ChainID) (string, error) {
+	core, err := coreContracts(chain)
+	if err != nil {
+		return "", err
+	}
+	return core.EventTypes().FlowTokenTokensDeposited, nil
+}
+
+// A FeesDeductedEvent is emitted by the FlowFees contract when the fee of a transaction is charged.
+//
+// This event contains the following fields:
+// - amount: UFix64
+// - inclusionEffort: UFix64
+// - executionEffort: UFix64
+type FeesDeductedEvent flow.Event
+
+// Amount returns the total fee charged.
+func (evt FeesDeductedEvent) Amount() (cadence.UFix64, error) {
+	return ufix64Field(evt.Value, "amount")
+}
+
+// InclusionEffort returns the inclusion effort the fee was computed from.
+func (evt FeesDeductedEvent) InclusionEffort() (cadence.UFix64, error) {
+	return ufix64Field(evt.Value, "inclusionEffort")
+}
+
+// ExecutionEffort returns the execution effort the fee was computed from.
+func (evt FeesDeductedEvent) ExecutionEffort() (cadence.UFix64, error) {
+	return ufix64Field(evt.Value, "executionEffort")
+}
+
+func ufix64Field(event cadence.Event, name string) (cadence.UFix64, error) {
+	value, ok := cadence.SearchFieldByName(event, name).(cadence.UFix64)
+	if !ok {
+		return 0, fmt.Errorf("fees: event %s has no UFix64 field %s", eventTypeID(event), name)
+	}
+	return value, nil
+}
+
+func eventTypeID(event cadence.Event) string {
+	if event.EventType == nil {
+		return "<unknown>"
+	}
+	return event.EventType.ID()
+}
+
+// ErrNoFeesDeducted is returned when a transaction result contains no FeesDeducted event,
+// for example for system transactions or on networks where fees are disabled.
+var ErrNoFeesDeducted = errors.New("fees: no fees deducted")
+
+// TransactionFees is the fee charged for a single transaction.
+type TransactionFees struct {
+	// TransactionID is the ID of the transaction the fee was charged for.
+	TransactionID flow.Identifier
+	// BlockHeight is the height of the block containing the transaction, if known.
+	BlockHeight uint64
+	// Payer is the account the fee was withdrawn from.
+	//
+	// Payer is flow.EmptyAddress if the withdrawal could not be matched, for example when the fee is zero.
+	Payer flow.Address
+	// Total is the total fee charged.
+	Total cadence.UFix64
+	// InclusionEffort is the inclusion effort the fee was computed from.
+	InclusionEffort cadence.UFix64
+	// ExecutionEffort is the execution effort the fee was computed from.
+	ExecutionEffort cadence.UFix64
+}
+
+// FromTransactionResult returns the fees charged for the transaction with the given result.
+//
+// ErrNoFeesDeducted is returned if the result contains no FeesDeducted event.
+func FromTransactionResult(chain flow.ChainID, result *flow.TransactionResult) (*TransactionFees, error) {
+	fees, err := FromEvents(chain, result.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	fees.TransactionID = result.TransactionID
+	fees.BlockHeight = result.BlockHeight
+
+	return fees, nil
+}
+
+// FromEvents returns the fees charged for a transaction, given the events it emitted.
+//
+// ErrNoFeesDeducted is returned if the events contain no FeesDeducted event.
+func FromEvents(chain flow.ChainID, events []flow.Event) (*TransactionFees, error) {
+	core, err := coreContracts(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes := core.EventTypes()
+	feesDeductedType := eventTypes.FlowFeesFeesDeducted
+	withdrawnType := eventTypes.FlowTokenTokensWithdrawn
+	depositedType := eventTypes.FlowTokenTokensDeposited
+	feesAddress := core.FlowFees.Address
+
+	feesIndex := -1
+	for i, event := range events {
+		if event.Type == feesDeductedType {
+			feesIndex = i
+		}
+	}
+	if feesIndex < 0 {
+		return nil, ErrNoFeesDeducted
+	}
+
+	deducted := FeesDeductedEvent(events[feesIndex])
+	fees := &TransactionFees{
+		TransactionID: deducted.TransactionID,
+	}
+	if fees.Total, err = deducted.Amount(); err != nil {
+		return nil, err
+	}
+	if fees.InclusionEffort, err = deducted.InclusionEffort(); err != nil {
+		return nil, err
+	}
+	if fees.ExecutionEffort, err = deducted.ExecutionEffort(); err != nil {
+		return nil, err
+	}
+
+	if fees.Total == 0 {
+		return fees, nil
+	}
+
+	// The fee is withdrawn from the payer's vault and deposited into the fee vault
+	// right before the FeesDeducted event is emitted.
+	deposited := false
+	for i := feesIndex - 1; i >= 0; i-- {
+		event := events[i]
+
+		switch event.Type {
+		case depositedType:
+			if deposited {
+				continue
+			}
+			deposit := flow.FlowTokenDepositedEvent(event)
+			amount, err := deposit.Amount()
+			if err != nil {
+				return nil, err
+			}
+			to, ok, err := deposit.To()
+			if err != nil {
+				return nil, err
+			}
+			deposited = ok && to == feesAddress && amount == fees.Total
+
+		case withdrawnType:
+			if !deposited {
+				continue
+			}
+			withdrawal := flow.FlowTokenWithdrawnEvent(event)
+			amount, err := withdrawal.Amount()
+			if err != nil {
+				return nil, err
+			}
+			from, ok, err := withdrawal.From()
+			if err != nil {
+				return nil, err
+			}
+			if ok && amount == fees.Total {
+				fees.Payer = from
+				return fees, nil
+			}
+		}
+	}
+
+	return fees, nil
+}
@@ -0,0 +1,237 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fees_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/fees"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var (
+	emulatorFlowToken = flow.HexToAddress("0ae53cb6e3f42a79")
+	emulatorFlowFees  = flow.HexToAddress("e5a8b7f23e8b548f")
+)
+
+func newEvent(txID flow.Identifier, index int, address flow.Address, contract string, name string, fields []cadence.Field, values []cadence.Value) flow.Event {
+	location := common.NewAddressLocation(nil, common.Address(address), contract)
+	value := cadence.NewEvent(values).WithType(
+		cadence.NewEventType(location, contract+"."+name, fields, nil),
+	)
+
+	return flow.Event{
+		Type:          string(location.TypeID(nil, contract+"."+name)),
+		TransactionID: txID,
+		EventIndex:    index,
+		Value:         value,
+	}
+}
+
+func ufix64(t *testing.T, s string) cadence.UFix64 {
+	v, err := cadence.NewUFix64(s)
+	require.NoError(t, err)
+	return v
+}
+
+func withdrawnEvent(t *testing.T, txID flow.Identifier, index int, amount string, from flow.Address) flow.Event {
+	return newEvent(txID, index, emulatorFlowToken, "FlowToken", "TokensWithdrawn",
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type},
+			{Identifier: "from", Type: cadence.NewOptionalType(cadence.AddressType)},
+		},
+		[]cadence.Value{
+			ufix64(t, amount),
+			cadence.NewOptional(cadence.Address(from)),
+		},
+	)
+}
+
+func depositedEvent(t *testing.T, txID flow.Identifier, index int, amount string, to flow.Address) flow.Event {
+	return newEvent(txID, index, emulatorFlowToken, "FlowToken", "TokensDeposited",
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type},
+			{Identifier: "to", Type: cadence.NewOptionalType(cadence.AddressType)},
+		},
+		[]cadence.Value{
+			ufix64(t, amount),
+			cadence.NewOptional(cadence.Address(to)),
+		},
+	)
+}
+
+func feesDeductedEvent(t *testing.T, txID flow.Identifier, index int, amount, inclusion, execution string) flow.Event {
+	return newEvent(txID, index, emulatorFlowFees, "FlowFees", "FeesDeducted",
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type},
+			{Identifier: "inclusionEffort", Type: cadence.UFix64Type},
+			{Identifier: "executionEffort", Type: cadence.UFix64Type},
+		},
+		[]cadence.Value{
+			ufix64(t, amount),
+			ufix64(t, inclusion),
+			ufix64(t, execution),
+		},
+	)
+}
+
+func transactionEvents(t *testing.T, txID flow.Identifier, payer flow.Address, amount string) []flow.Event {
+	other := test.AddressGenerator().New()
+
+	return []flow.Event{
+		// an unrelated transfer of the same amount emitted by the transaction itself
+		withdrawnEvent(t, txID, 0, amount, other),
+		depositedEvent(t, txID, 1, amount, payer),
+		withdrawnEvent(t, txID, 2, amount, payer),
+		depositedEvent(t, txID, 3, amount, emulatorFlowFees),
+		feesDeductedEvent(t, txID, 4, amount, "1.00000000", "0.00123000"),
+	}
+}
+
+func TestEventTypes(t *testing.T) {
+	eventType, err := fees.FeesDeductedEventType(flow.Mainnet)
+	require.NoError(t, err)
+	assert.Equal(t, "A.f919ee77447b7497.FlowFees.FeesDeducted", eventType)
+
+	eventType, err = fees.TokensWithdrawnEventType(flow.Testnet)
+	require.NoError(t, err)
+	assert.Equal(t, "A.7e60df042a9c0868.FlowToken.TokensWithdrawn", eventType)
+
+	eventType, err = fees.TokensDepositedEventType(flow.Emulator)
+	require.NoError(t, err)
+	assert.Equal(t, "A.0ae53cb6e3f42a79.FlowToken.TokensDeposited", eventType)
+
+	_, err = fees.FeesDeductedEventType(flow.MonotonicEmulator)
+	assert.Error(t, err)
+}
+
+func TestFromTransactionResult(t *testing.T) {
+	txID := test.IdentifierGenerator().New()
+	payer := test.AddressGenerator().New()
+
+	t.Run("Fees and payer", func(t *testing.T) {
+		result := &flow.TransactionResult{
+			TransactionID: txID,
+			BlockHeight:   42,
+			Events:        transactionEvents(t, txID, payer, "0.00001000"),
+		}
+
+		txFees, err := fees.FromTransactionResult(flow.Emulator, result)
+		require.NoError(t, err)
+
+		assert.Equal(t, &fees.TransactionFees{
+			TransactionID:   txID,
+			BlockHeight:     42,
+			Payer:           payer,
+			Total:           ufix64(t, "0.00001000"),
+			InclusionEffort: ufix64(t, "1.00000000"),
+			ExecutionEffort: ufix64(t, "0.00123000"),
+		}, txFees)
+	})
+
+	t.Run("Zero fees have no payer", func(t *testing.T) {
+		result := &flow.TransactionResult{
+			TransactionID: txID,
+			Events: []flow.Event{
+				feesDeductedEvent(t, txID, 0, "0.0", "0.0", "0.0"),
+			},
+		}
+
+		txFees, err := fees.FromTransactionResult(flow.Emulator, result)
+		require.NoError(t, err)
+		assert.Equal(t, flow.EmptyAddress, txFees.Payer)
+		assert.Equal(t, cadence.UFix64(0), txFees.Total)
+	})
+
+	t.Run("No fees deducted", func(t *testing.T) {
+		_, err := fees.FromTransactionResult(flow.Emulator, &flow.TransactionResult{TransactionID: txID})
+		assert.ErrorIs(t, err, fees.ErrNoFeesDeducted)
+	})
+}
+
+type mockEventsClient struct {
+	events  []flow.Event
+	heights map[flow.Identifier]uint64
+	queries int
+}
+
+func (c *mockEventsClient) GetEventsForHeightRange(_ context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	c.queries++
+
+	blocks := make(map[uint64]*flow.BlockEvents)
+	var result []flow.BlockEvents
+	for _, event := range c.events {
+		height := c.heights[event.TransactionID]
+		if event.Type != eventType || height < startHeight || height > endHeight {
+			continue
+		}
+		block, ok := blocks[height]
+		if !ok {
+			block = &flow.BlockEvents{Height: height}
+			blocks[height] = block
+		}
+		block.Events = append(block.Events, event)
+	}
+	for _, block := range blocks {
+		result = append(result, *block)
+	}
+	return result, nil
+}
+
+func TestNewReport(t *testing.T) {
+	ids := test.IdentifierGenerator()
+	addresses := test.AddressGenerator()
+	alice, bob := addresses.New(), addresses.New()
+	tx1, tx2, tx3 := ids.New(), ids.New(), ids.New()
+
+	client := &mockEventsClient{
+		heights: map[flow.Identifier]uint64{tx1: 10, tx2: 300, tx3: 12},
+	}
+	client.events = append(client.events, transactionEvents(t, tx1, alice, "0.00001000")...)
+	client.events = append(client.events, transactionEvents(t, tx2, alice, "0.00002000")...)
+	client.events = append(client.events, transactionEvents(t, tx3, bob, "0.00004000")...)
+
+	report, err := fees.NewReport(context.Background(), client, flow.Emulator, 1, 400)
+	require.NoError(t, err)
+
+	// the range is split into two queries per event type
+	assert.Equal(t, 6, client.queries)
+
+	require.Len(t, report.Transactions, 3)
+	assert.Equal(t, tx1, report.Transactions[0].TransactionID)
+	assert.Equal(t, tx3, report.Transactions[1].TransactionID)
+	assert.Equal(t, tx2, report.Transactions[2].TransactionID)
+	assert.Equal(t, uint64(300), report.Transactions[2].BlockHeight)
+
+	assert.Equal(t, ufix64(t, "0.00007000"), report.Total)
+	assert.Equal(t, map[flow.Address]cadence.UFix64{
+		alice: ufix64(t, "0.00003000"),
+		bob:   ufix64(t, "0.00004000"),
+	}, report.TotalByPayer)
+
+	_, err = fees.NewReport(context.Background(), client, flow.Emulator, 10, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,187 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// EventsClient is the subset of the Access API used to build a fee report.
+type EventsClient interface {
+	// GetEventsForHeightRange retrieves events for all sealed blocks between the start and end block heights (inclusive) with the given type.
+	GetEventsForHeightRange(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error)
+}
+
+// maxHeightRange is the maximum number of blocks included in a single events query.
+//
+// Access nodes reject event queries spanning more than 250 blocks.
+const maxHeightRange = 250
+
+// A Report aggregates the fees charged for all transactions in a range of blocks.
+type Report struct {
+	// StartHeight is the first block height included in the report.
+	StartHeight uint64
+	// EndHeight is the last block height included in the report.
+	EndHeight uint64
+	// Transactions lists the fees of every transaction, ordered by block height and transaction index.
+	Transactions []*TransactionFees
+	// Total is the sum of all fees charged.
+	Total cadence.UFix64
+	// TotalByPayer is the sum of all fees charged, grouped by payer.
+	TotalByPayer map[flow.Address]cadence.UFix64
+}
+
+// NewReport builds a fee report for all sealed blocks between the start and end heights (inclusive).
+func NewReport(
+	ctx context.Context,
+	client EventsClient,
+	chain flow.ChainID,
+	startHeight uint64,
+	endHeight uint64,
+) (*Report, error) {
+	if endHeight < startHeight {
+		return nil, fmt.Errorf("fees: end height %d is lower than start height %d", endHeight, startHeight)
+	}
+
+	feesDeductedType, err := FeesDeductedEventType(chain)
+	if err != nil {
+		return nil, err
+	}
+	withdrawnType, err := TokensWithdrawnEventType(chain)
+	if err != nil {
+		return nil, err
+	}
+	depositedType, err := TokensDepositedEventType(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		StartHeight:  startHeight,
+		EndHeight:    endHeight,
+		TotalByPayer: make(map[flow.Address]cadence.UFix64),
+	}
+
+	for start := startHeight; start <= endHeight; start += maxHeightRange {
+		end := start + maxHeightRange - 1
+		if end > endHeight || end < start {
+			end = endHeight
+		}
+
+		transactions := make(map[flow.Identifier]*transactionEvents)
+		for _, eventType := range []string{feesDeductedType, withdrawnType, depositedType} {
+			blocks, err := client.GetEventsForHeightRange(ctx, eventType, start, end)
+			if err != nil {
+				return nil, fmt.Errorf("fees: failed to get %s events: %w", eventType, err)
+			}
+			collectTransactionEvents(transactions, blocks)
+		}
+
+		ordered := make([]*transactionEvents, 0, len(transactions))
+		for _, tx := range transactions {
+			ordered = append(ordered, tx)
+		}
+		sort.Slice(ordered, func(i, j int) bool {
+			if ordered[i].height == ordered[j].height {
+				return ordered[i].index < ordered[j].index
+			}
+			return ordered[i].height < ordered[j].height
+		})
+
+		for _, tx := range ordered {
+			sort.Slice(tx.events, func(i, j int) bool {
+				return tx.events[i].EventIndex < tx.events[j].EventIndex
+			})
+
+			fees, err := FromEvents(chain, tx.events)
+			if errors.Is(err, ErrNoFeesDeducted) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			fees.BlockHeight = tx.height
+
+			err = report.add(fees)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if end == endHeight {
+			break
+		}
+	}
+
+	return report, nil
+}
+
+func (r *Report) add(fees *TransactionFees) error {
+	total, err := addUFix64(r.Total, fees.Total)
+	if err != nil {
+		return err
+	}
+	payerTotal, err := addUFix64(r.TotalByPayer[fees.Payer], fees.Total)
+	if err != nil {
+		return err
+	}
+
+	r.Transactions = append(r.Transactions, fees)
+	r.Total = total
+	r.TotalByPayer[fees.Payer] = payerTotal
+
+	return nil
+}
+
+func addUFix64(a, b cadence.UFix64) (cadence.UFix64, error) {
+	sum := a + b
+	if sum < a {
+		return 0, errors.New("fees: total overflows UFix64")
+	}
+	return sum, nil
+}
+
+type transactionEvents struct {
+	height uint64
+	index  int
+	events []flow.Event
+}
+
+func collectTransactionEvents(transactions map[flow.Identifier]*transactionEvents, blocks []flow.BlockEvents) {
+	for _, block := range blocks {
+		for _, event := range block.Events {
+			tx, ok := transactions[event.TransactionID]
+			if !ok {
+				tx = &transactionEvents{
+					height: block.Height,
+					index:  event.TransactionIndex,
+				}
+				transactions[event.TransactionID] = tx
+			}
+			tx.events = append(tx.events, event)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package imports resolves the contract imports of Cadence scripts and transactions for a target network.
+//
+// Scripts are written once with network-independent imports, either by contract name:
+//
+//	import "FungibleToken"
+//
+// or with a placeholder address:
+//
+//	import FungibleToken from 0xFUNGIBLETOKENADDRESS
+//
+// and a Resolver rewrites them to the addresses the contracts are deployed at on a given chain:
+//
+//	import FungibleToken from 0xf233dcee88fe0abe
+package imports
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// ErrUnresolvedImport is matched by errors reporting imports that could not be resolved.
+var ErrUnresolvedImport = errors.New("imports: unresolved import")
+
+// An UnresolvedImportError lists the contracts that have no registered address on the target chain.
+type UnresolvedImportError struct {
+	Chain     flow.ChainID
+	Contracts []string
+}
+
+func (e *UnresolvedImportError) Error() string {
+	return fmt.Sprintf(
+		"imports: no address registered on %s for contracts: %s",
+		e.Chain,
+		strings.Join(e.Contracts, ", "),
+	)
+}
+
+// Is returns true for ErrUnresolvedImport.
+func (e *UnresolvedImportError) Is(target error) bool {
+	return target == ErrUnresolvedImport
+}
+
+// A Registry maps contract names to the addresses they are deployed at on each chain.
+//
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	addresses map[flow.ChainID]map[string]flow.Address
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		addresses: make(map[flow.ChainID]map[string]flow.Address),
+	}
+}
+
+// Register records the address of a contract on a chain, replacing any previous address.
+func (r *Registry) Register(chain flow.ChainID, name string, address flow.Address) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	contracts, ok := r.addresses[chain]
+	if !ok {
+		contracts = make(map[string]flow.Address)
+		r.addresses[chain] = contracts
+	}
+	contracts[name] = address
+
+	return r
+}
+
+// RegisterAll records the addresses of several contracts on a chain.
+func (r *Registry) RegisterAll(chain flow.ChainID, contracts map[string]flow.Address) *Registry {
+	for name, address := range contracts {
+		r.Register(chain, name, address)
+	}
+
+	return r
+}
+
+// Address returns the address of a contract on a chain.
+func (r *Registry) Address(chain flow.ChainID, name string) (flow.Address, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	address, ok := r.addresses[chain][name]
+	return address, ok
+}
+
+// Contracts returns the addresses of all contracts registered on a chain.
+func (r *Registry) Contracts(chain flow.ChainID) map[string]flow.Address {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	contracts := make(map[string]flow.Address, len(r.addresses[chain]))
+	for name, address := range r.addresses[chain] {
+		contracts[name] = address
+	}
+
+	return contracts
+}
+
+// importPattern matches import declarations with a string or address location:
+//
+//	import "Name"
+//	import Name from "./Name.cdc"
+//	import Name, Other from 0xPLACEHOLDER
+//
+// Builtin imports without a location, such as `import Crypto`, are not matched.
+var importPattern = regexp.MustCompile(
+	`(?m)^([ \t]*)import[ \t]+(?:([A-Za-z_]\w*(?:[ \t]*,[ \t]*[A-Za-z_]\w*)*)[ \t]+from[ \t]+)?("[^"\n]*"|0x\w+)`,
+)
+
+var hexAddressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{1,16}$`)
+
+// A Resolver rewrites the imports of Cadence code for a target chain.
+type Resolver struct {
+	chain    flow.ChainID
+	registry *Registry
+}
+
+// NewResolver returns a resolver that rewrites imports to the addresses registered for chain.
+func NewResolver(chain flow.ChainID, registry *Registry) *Resolver {
+	return &Resolver{
+		chain:    chain,
+		registry: registry,
+	}
+}
+
+// Chain returns the chain imports are resolved for.
+func (r *Resolver) Chain() flow.ChainID {
+	return r.chain
+}
+
+// Resolve rewrites the string and placeholder imports of code to address imports.
+//
+// Imports from a literal hex address and builtin imports are left unchanged. Contracts imported
+// by path, such as `import Name from "./Name.cdc"`, are resolved by their name. Text that only looks
+// like an import declaration, inside a comment or a string literal, is left unchanged.
+//
+// An *UnresolvedImportError is returned if a contract has no address registered for the chain.
+func (r *Resolver) Resolve(code []byte) ([]byte, error) {
+	unresolved := make(map[string]struct{})
+	var conflict error
+
+	var resolved []byte
+	last := 0
+
+	for _, decl := range importDeclarations(code) {
+		resolved = append(resolved, code[last:decl.start]...)
+		last = decl.end
+
+		if hexAddressPattern.MatchString(decl.location) {
+			resolved = append(resolved, code[decl.start:decl.end]...)
+			continue
+		}
+
+		names := decl.names()
+
+		var address flow.Address
+		for i, name := range names {
+			a, ok := r.registry.Address(r.chain, name)
+			if !ok {
+				unresolved[name] = struct{}{}
+				continue
+			}
+
+			if i > 0 && a != address && conflict == nil {
+				conflict = fmt.Errorf(
+					"imports: contracts %s are imported together but registered at different addresses on %s",
+					strings.Join(names, ", "),
+					r.chain,
+				)
+			}
+			address = a
+		}
+
+		resolved = append(resolved, fmt.Sprintf("%simport %s from %s", decl.indent, strings.Join(names, ", "), address.HexWithPrefix())...)
+	}
+
+	resolved = append(resolved, code[last:]...)
+
+	if len(unresolved) > 0 {
+		contracts := make([]string, 0, len(unresolved))
+		for name := range unresolved {
+			contracts = append(contracts, name)
+		}
+		sort.Strings(contracts)
+
+		return nil, &UnresolvedImportError{Chain: r.chain, Contracts: contracts}
+	}
+
+	if conflict != nil {
+		return nil, conflict
+	}
+
+	return resolved, nil
+}
+
+// Imported returns the names of the contracts imported by code, in order of first appearance.
+//
+// Unlike Resolve, it includes contracts imported from a literal hex address.
+func Imported(code []byte) []string {
+	var names []string
+	seen := make(map[string]struct{})
+
+	for _, decl := range importDeclarations(code) {
+		var matched []string
+		if decl.identifiers != "" || !hexAddressPattern.MatchString(decl.location) {
+			matched = decl.names()
+		}
+
+		for _, name := range matched {
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// An importDeclaration is an import declaration matched by importPattern.
+type importDeclaration struct {
+	// start and end are the offsets of the declaration, including its indentation, in the code.
+	start, end  int
+	indent      string
+	identifiers string
+	location    string
+}
+
+// names returns the imported contract names: the declared identifiers, or the name derived
+// from the location of an import without identifiers.
+func (d importDeclaration) names() []string {
+	if d.identifiers == "" {
+		return []string{contractName(d.location)}
+	}
+
+	var names []string
+	for _, name := range strings.Split(d.identifiers, ",") {
+		names = append(names, strings.TrimSpace(name))
+	}
+	return names
+}
+
+// importDeclarations returns the import declarations of code that are not inside a comment or a string literal.
+func importDeclarations(code []byte) []importDeclaration {
+	ignored := ignoredRanges(code)
+
+	var declarations []importDeclaration
+	for _, m := range importPattern.FindAllSubmatchIndex(code, -1) {
+		// the import keyword directly follows the indentation
+		if isIgnored(ignored, m[3]) {
+			continue
+		}
+
+		decl := importDeclaration{
+			start:    m[0],
+			end:      m[1],
+			indent:   string(code[m[2]:m[3]]),
+			location: string(code[m[6]:m[7]]),
+		}
+		if m[4] >= 0 {
+			decl.identifiers = string(code[m[4]:m[5]])
+		}
+
+		declarations = append(declarations, decl)
+	}
+
+	return declarations
+}
+
+// ignoredRanges returns the sorted [start, end) offsets of the comments and string literals of code.
+//
+// Block comments may be nested, as in Cadence.
+func ignoredRanges(code []byte) [][2]int {
+	var ranges [][2]int
+
+	for i := 0; i < len(code); {
+		switch {
+		case bytes.HasPrefix(code[i:], []byte("//")):
+			end := bytes.IndexByte(code[i:], '\n')
+			if end < 0 {
+				end = len(code) - i
+			}
+			ranges = append(ranges, [2]int{i, i + end})
+			i += end
+
+		case bytes.HasPrefix(code[i:], []byte("/*")):
+			start := i
+			depth := 0
+			for i < len(code) {
+				if bytes.HasPrefix(code[i:], []byte("/*")) {
+					depth++
+					i += 2
+				} else if bytes.HasPrefix(code[i:], []byte("*/")) {
+					depth--
+					i += 2
+					if depth == 0 {
+						break
+					}
+				} else {
+					i++
+				}
+			}
+			ranges = append(ranges, [2]int{start, i})
+
+		case code[i] == '"':
+			start := i
+			i++
+			for i < len(code) && code[i] != '"' && code[i] != '\n' {
+				if code[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i = min(i+1, len(code))
+			ranges = append(ranges, [2]int{start, i})
+
+		default:
+			i++
+		}
+	}
+
+	return ranges
+}
+
+// isIgnored returns true if offset lies within one of the sorted ranges.
+func isIgnored(ranges [][2]int, offset int) bool {
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i][1] > offset
+	})
+	return i < len(ranges) && ranges[i][0] <= offset
+}
+
+// contractName derives the contract name from a string import location,
+// for example "FungibleToken" or "./contracts/FungibleToken.cdc".
+func contractName(location string) string {
+	location = strings.Trim(location, `"`)
+	return strings.TrimSuffix(path.Base(location), ".cdc")
+}
+
+// SetScript resolves the imports of script and sets it as the script of the transaction.
+//
+// It is equivalent to tx.SetResolvedScript(script, r).
+func (r *Resolver) SetScript(tx *flow.Transaction, script []byte) error {
+	return tx.SetResolvedScript(script, r)
+}
+
+// A ScriptClient executes Cadence scripts.
+//
+// Both access.Client and the gRPC and HTTP clients implement this interface.
+type ScriptClient interface {
+	ExecuteScriptAtLatestBlock(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error)
+}
+
+// ExecuteScriptAtLatestBlock resolves the imports of script and executes it against the latest sealed state.
+func (r *Resolver) ExecuteScriptAtLatestBlock(
+	ctx context.Context,
+	client ScriptClient,
+	script []byte,
+	arguments []cadence.Value,
+) (cadence.Value, error) {
+	resolved, err := r.Resolve(script)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.ExecuteScriptAtLatestBlock(ctx, resolved, arguments)
+}
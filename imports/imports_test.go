@@ -0,0 +1,205 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imports_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/mocks"
+	"github.com/onflow/flow-go-sdk/imports"
+)
+
+var (
+	fungibleToken = flow.HexToAddress("f233dcee88fe0abe")
+	flowToken     = flow.HexToAddress("1654653399040a61")
+)
+
+func newResolver() *imports.Resolver {
+	registry := imports.NewRegistry().
+		Register(flow.Mainnet, "FungibleToken", fungibleToken).
+		Register(flow.Mainnet, "FlowToken", flowToken).
+		Register(flow.Mainnet, "Burner", fungibleToken)
+
+	return imports.NewResolver(flow.Mainnet, registry)
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	resolver := newResolver()
+
+	t.Run("Resolves imports", func(t *testing.T) {
+		code := `import Crypto
+import "FungibleToken"
+  import FlowToken from 0xFLOWTOKENADDRESS
+import FlowToken from "../contracts/FlowToken.cdc"
+import FungibleToken, Burner from 0xFUNGIBLETOKENADDRESS
+import Foo from 0x01
+
+transaction {}
+`
+		expected := `import Crypto
+import FungibleToken from 0xf233dcee88fe0abe
+  import FlowToken from 0x1654653399040a61
+import FlowToken from 0x1654653399040a61
+import FungibleToken, Burner from 0xf233dcee88fe0abe
+import Foo from 0x01
+
+transaction {}
+`
+
+		resolved, err := resolver.Resolve([]byte(code))
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(resolved))
+	})
+
+	t.Run("Unresolved imports", func(t *testing.T) {
+		code := `import "NonFungibleToken"
+import MetadataViews from 0xMETADATAVIEWSADDRESS
+import "FungibleToken"
+`
+
+		_, err := resolver.Resolve([]byte(code))
+		require.ErrorIs(t, err, imports.ErrUnresolvedImport)
+
+		var unresolved *imports.UnresolvedImportError
+		require.ErrorAs(t, err, &unresolved)
+		assert.Equal(t, flow.Mainnet, unresolved.Chain)
+		assert.Equal(t, []string{"MetadataViews", "NonFungibleToken"}, unresolved.Contracts)
+	})
+
+	t.Run("Ignores comments and strings", func(t *testing.T) {
+		code := `import "FungibleToken"
+// import "Unknown"
+/* a nested /* block */ comment
+import "Unknown"
+*/
+transaction {
+    execute {
+        log("import \"Unknown\"")
+        log("/*")
+    }
+}
+import "FlowToken"
+`
+		expected := `import FungibleToken from 0xf233dcee88fe0abe
+// import "Unknown"
+/* a nested /* block */ comment
+import "Unknown"
+*/
+transaction {
+    execute {
+        log("import \"Unknown\"")
+        log("/*")
+    }
+}
+import FlowToken from 0x1654653399040a61
+`
+
+		resolved, err := resolver.Resolve([]byte(code))
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(resolved))
+		assert.Equal(t, []string{"FungibleToken", "FlowToken"}, imports.Imported([]byte(code)))
+	})
+
+	t.Run("Other chain", func(t *testing.T) {
+		_, err := imports.NewResolver(flow.Testnet, imports.NewRegistry()).Resolve([]byte(`import "FungibleToken"`))
+		assert.ErrorIs(t, err, imports.ErrUnresolvedImport)
+	})
+
+	t.Run("Conflicting addresses", func(t *testing.T) {
+		_, err := resolver.Resolve([]byte(`import FungibleToken, FlowToken from 0xPLACEHOLDER`))
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, imports.ErrUnresolvedImport)
+	})
+}
+
+func TestImported(t *testing.T) {
+	code := []byte(`import Crypto
+import "FungibleToken"
+import FlowToken from "./contracts/FlowToken.cdc"
+import NonFungibleToken, MetadataViews from 0x1d7e57aa55817448
+import FungibleToken from 0xFUNGIBLETOKENADDRESS
+import 0x01
+
+access(all) contract Example {}
+`)
+
+	assert.Equal(t,
+		[]string{"FungibleToken", "FlowToken", "NonFungibleToken", "MetadataViews"},
+		imports.Imported(code),
+	)
+	assert.Empty(t, imports.Imported([]byte("access(all) fun main() {}")))
+}
+
+func TestTransaction_SetResolvedScript(t *testing.T) {
+	tx := flow.NewTransaction()
+
+	err := tx.SetResolvedScript([]byte(`import "FlowToken"`), newResolver())
+	require.NoError(t, err)
+	assert.Equal(t, "import FlowToken from 0x1654653399040a61", string(tx.Script))
+
+	err = tx.SetResolvedScript([]byte(`import "Unknown"`), newResolver())
+	assert.ErrorIs(t, err, imports.ErrUnresolvedImport)
+	assert.Equal(t, "import FlowToken from 0x1654653399040a61", string(tx.Script))
+}
+
+func TestResolver_SetScript(t *testing.T) {
+	tx := flow.NewTransaction()
+
+	err := newResolver().SetScript(tx, []byte(`import "FlowToken"`))
+	require.NoError(t, err)
+	assert.Equal(t, "import FlowToken from 0x1654653399040a61", string(tx.Script))
+
+	err = newResolver().SetScript(tx, []byte(`import "Unknown"`))
+	assert.ErrorIs(t, err, imports.ErrUnresolvedImport)
+	assert.Equal(t, "import FlowToken from 0x1654653399040a61", string(tx.Script))
+}
+
+func TestResolver_ExecuteScriptAtLatestBlock(t *testing.T) {
+	client := new(mocks.Client)
+	client.
+		On("ExecuteScriptAtLatestBlock", mock.Anything, []byte(`import FlowToken from 0x1654653399040a61`), []cadence.Value(nil)).
+		Return(cadence.NewBool(true), nil)
+
+	value, err := newResolver().ExecuteScriptAtLatestBlock(context.Background(), client, []byte(`import "FlowToken"`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewBool(true), value)
+
+	client.AssertExpectations(t)
+}
+
+func TestRegistry(t *testing.T) {
+	registry := imports.NewRegistry().
+		RegisterAll(flow.Testnet, map[string]flow.Address{"FlowToken": flowToken})
+
+	address, ok := registry.Address(flow.Testnet, "FlowToken")
+	assert.True(t, ok)
+	assert.Equal(t, flowToken, address)
+
+	_, ok = registry.Address(flow.Mainnet, "FlowToken")
+	assert.False(t, ok)
+
+	assert.Equal(t, map[string]flow.Address{"FlowToken": flowToken}, registry.Contracts(flow.Testnet))
+}
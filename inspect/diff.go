@@ -0,0 +1,149 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inspect
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// A Difference is a single field that differs between two transactions.
+type Difference struct {
+	// Field names the differing field, for example "payer" or "arguments[1]".
+	Field string
+	// Before is the value in the first transaction, or empty if the field is absent.
+	//
+	// Arguments are shown as Cadence values. If two arguments hold the same value but are encoded
+	// differently, both are shown as their raw JSON-CDC encoding, quoted as a Go string literal so
+	// that differences in whitespace are visible.
+	Before string
+	// After is the value in the second transaction, or empty if the field is absent.
+	After string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.Field, d.Before, d.After)
+}
+
+// Diff returns the fields that differ between two transactions, in transaction field order.
+//
+// Diff is intended to detect tampering, for example by comparing a transaction returned by a
+// co-signer with the one that was sent out for signing. An empty result means the two
+// transactions are identical, including their signatures.
+func Diff(a, b *flow.Transaction) []Difference {
+	var diffs []Difference
+
+	add := func(field, before, after string) {
+		if before != after {
+			diffs = append(diffs, Difference{Field: field, Before: before, After: after})
+		}
+	}
+
+	if !bytes.Equal(a.Script, b.Script) {
+		diffs = append(diffs, Difference{
+			Field:  "script",
+			Before: string(a.Script),
+			After:  string(b.Script),
+		})
+	}
+
+	for i := 0; i < len(a.Arguments) || i < len(b.Arguments); i++ {
+		if i < len(a.Arguments) && i < len(b.Arguments) && bytes.Equal(a.Arguments[i], b.Arguments[i]) {
+			continue
+		}
+
+		before, after := formatArgument(a, i), formatArgument(b, i)
+		if before == after {
+			// the values are equal but encoded differently, which still changes the transaction ID
+			before, after = quotedArgument(a, i), quotedArgument(b, i)
+		}
+
+		diffs = append(diffs, Difference{
+			Field:  fmt.Sprintf("arguments[%d]", i),
+			Before: before,
+			After:  after,
+		})
+	}
+
+	add("reference_block_id", a.ReferenceBlockID.String(), b.ReferenceBlockID.String())
+	add("compute_limit", fmt.Sprint(a.GasLimit), fmt.Sprint(b.GasLimit))
+	add("proposal_key", formatProposalKey(a.ProposalKey), formatProposalKey(b.ProposalKey))
+	add("payer", a.Payer.HexWithPrefix(), b.Payer.HexWithPrefix())
+	add("authorizers", formatAddresses(a.Authorizers), formatAddresses(b.Authorizers))
+	add("payload_signatures", formatSignatures(a.PayloadSignatures), formatSignatures(b.PayloadSignatures))
+	add("envelope_signatures", formatSignatures(a.EnvelopeSignatures), formatSignatures(b.EnvelopeSignatures))
+
+	return diffs
+}
+
+func formatArgument(tx *flow.Transaction, i int) string {
+	if i >= len(tx.Arguments) {
+		return ""
+	}
+
+	value, err := tx.Argument(i)
+	if err != nil {
+		return rawArgument(tx, i)
+	}
+
+	return value.String()
+}
+
+func rawArgument(tx *flow.Transaction, i int) string {
+	if i >= len(tx.Arguments) {
+		return ""
+	}
+
+	return string(bytes.TrimSpace(tx.Arguments[i]))
+}
+
+// quotedArgument returns the exact encoding of an argument as a quoted string.
+func quotedArgument(tx *flow.Transaction, i int) string {
+	if i >= len(tx.Arguments) {
+		return ""
+	}
+
+	return strconv.Quote(string(tx.Arguments[i]))
+}
+
+func formatProposalKey(key flow.ProposalKey) string {
+	return fmt.Sprintf("%s key %d, sequence number %d", key.Address.HexWithPrefix(), key.KeyIndex, key.SequenceNumber)
+}
+
+func formatAddresses(addresses []flow.Address) string {
+	hex := make([]string, len(addresses))
+	for i, address := range addresses {
+		hex[i] = address.HexWithPrefix()
+	}
+
+	return "[" + strings.Join(hex, ", ") + "]"
+}
+
+func formatSignatures(signatures []flow.TransactionSignature) string {
+	formatted := make([]string, len(signatures))
+	for i, sig := range signatures {
+		formatted[i] = fmt.Sprintf("%s key %d: %x", sig.Address.HexWithPrefix(), sig.KeyIndex, sig.Signature)
+	}
+
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
@@ -0,0 +1,255 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package inspect describes transactions in human-readable form, so that they can be reviewed before signing.
+package inspect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/parser"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// An Import is a contract import declared by a transaction script.
+type Import struct {
+	// Identifiers are the names of the imported declarations.
+	Identifiers []string
+	// Location is the import location as written in the script, for example 0x1654653399040a61 or "FungibleToken".
+	Location string
+	// Address is the account the contracts are imported from, or flow.EmptyAddress if the
+	// location is not an address.
+	Address flow.Address
+}
+
+// An Argument is a decoded transaction argument.
+type Argument struct {
+	Index int
+	// Name is the parameter name declared by the transaction script, if any.
+	Name string
+	// DeclaredType is the parameter type declared by the transaction script, if any.
+	DeclaredType string
+	// Type is the Cadence type ID of the decoded value.
+	Type string
+	// Value is the decoded value.
+	Value cadence.Value
+}
+
+// A Signer is an account that must sign the transaction, together with the signatures it provided so far.
+type Signer struct {
+	Address flow.Address
+	// Roles lists the signing roles of the account: proposer, payer and/or authorizer.
+	Roles []string
+	// PayloadKeys are the indices of the account keys that signed the payload.
+	PayloadKeys []uint32
+	// EnvelopeKeys are the indices of the account keys that signed the envelope.
+	EnvelopeKeys []uint32
+}
+
+// A Description is a human-readable breakdown of a transaction.
+type Description struct {
+	ID               flow.Identifier
+	ReferenceBlockID flow.Identifier
+	ComputeLimit     uint64
+	ProposalKey      flow.ProposalKey
+	Payer            flow.Address
+	Authorizers      []flow.Address
+	Imports          []Import
+	Arguments        []Argument
+	// Signers lists every signing account in signer order (proposer, payer, authorizers).
+	Signers []Signer
+}
+
+// Describe decodes the script imports, the arguments and the signing state of a transaction.
+//
+// An error is returned if the script cannot be parsed or an argument cannot be decoded.
+func Describe(tx *flow.Transaction, options ...jsoncdc.Option) (*Description, error) {
+	program, err := parser.ParseProgram(nil, tx.Script, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("inspect: failed to parse script: %w", err)
+	}
+
+	d := &Description{
+		ID:               tx.ID(),
+		ReferenceBlockID: tx.ReferenceBlockID,
+		ComputeLimit:     tx.GasLimit,
+		ProposalKey:      tx.ProposalKey,
+		Payer:            tx.Payer,
+		Authorizers:      tx.Authorizers,
+		Imports:          imports(program),
+		Signers:          signers(tx),
+	}
+
+	var parameters []*ast.Parameter
+	if transactions := program.TransactionDeclarations(); len(transactions) > 0 && transactions[0].ParameterList != nil {
+		parameters = transactions[0].ParameterList.Parameters
+	}
+
+	for i := range tx.Arguments {
+		value, err := tx.Argument(i, options...)
+		if err != nil {
+			return nil, fmt.Errorf("inspect: %w", err)
+		}
+
+		arg := Argument{
+			Index: i,
+			Value: value,
+		}
+		if value.Type() != nil {
+			arg.Type = value.Type().ID()
+		}
+		if i < len(parameters) {
+			arg.Name = parameters[i].Identifier.Identifier
+			arg.DeclaredType = parameters[i].TypeAnnotation.String()
+		}
+
+		d.Arguments = append(d.Arguments, arg)
+	}
+
+	return d, nil
+}
+
+func imports(program *ast.Program) []Import {
+	var result []Import
+
+	for _, declaration := range program.ImportDeclarations() {
+		imp := Import{}
+
+		for _, identifier := range declaration.Identifiers {
+			imp.Identifiers = append(imp.Identifiers, identifier.Identifier)
+		}
+
+		switch location := declaration.Location.(type) {
+		case common.AddressLocation:
+			imp.Address = flow.BytesToAddress(location.Address.Bytes())
+			imp.Location = imp.Address.HexWithPrefix()
+		case common.StringLocation:
+			imp.Location = fmt.Sprintf("%q", string(location))
+		default:
+			imp.Location = location.String()
+		}
+
+		result = append(result, imp)
+	}
+
+	return result
+}
+
+func signers(tx *flow.Transaction) []Signer {
+	var result []Signer
+	index := make(map[flow.Address]int)
+
+	add := func(address flow.Address, role string) {
+		if address == flow.EmptyAddress {
+			return
+		}
+		i, ok := index[address]
+		if !ok {
+			i = len(result)
+			index[address] = i
+			result = append(result, Signer{Address: address})
+		}
+		for _, r := range result[i].Roles {
+			if r == role {
+				return
+			}
+		}
+		result[i].Roles = append(result[i].Roles, role)
+	}
+
+	add(tx.ProposalKey.Address, "proposer")
+	add(tx.Payer, "payer")
+	for _, authorizer := range tx.Authorizers {
+		add(authorizer, "authorizer")
+	}
+
+	for _, sig := range tx.PayloadSignatures {
+		if i, ok := index[sig.Address]; ok {
+			result[i].PayloadKeys = append(result[i].PayloadKeys, sig.KeyIndex)
+		}
+	}
+
+	for _, sig := range tx.EnvelopeSignatures {
+		if i, ok := index[sig.Address]; ok {
+			result[i].EnvelopeKeys = append(result[i].EnvelopeKeys, sig.KeyIndex)
+		}
+	}
+
+	return result
+}
+
+// String returns a multi-line, human-readable rendering of the description.
+func (d *Description) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Transaction %s\n", d.ID)
+	fmt.Fprintf(&b, "  Reference block: %s\n", d.ReferenceBlockID)
+	fmt.Fprintf(&b, "  Compute limit:   %d\n", d.ComputeLimit)
+	fmt.Fprintf(&b, "  Proposal key:    %s key %d, sequence number %d\n",
+		d.ProposalKey.Address.HexWithPrefix(), d.ProposalKey.KeyIndex, d.ProposalKey.SequenceNumber)
+	fmt.Fprintf(&b, "  Payer:           %s\n", d.Payer.HexWithPrefix())
+
+	if len(d.Imports) > 0 {
+		b.WriteString("Imports:\n")
+		for _, imp := range d.Imports {
+			fmt.Fprintf(&b, "  %s from %s\n", strings.Join(imp.Identifiers, ", "), imp.Location)
+		}
+	}
+
+	if len(d.Arguments) > 0 {
+		b.WriteString("Arguments:\n")
+		for _, arg := range d.Arguments {
+			name := arg.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", arg.Index)
+			}
+			fmt.Fprintf(&b, "  %s: %s = %s\n", name, arg.Type, arg.Value)
+		}
+	}
+
+	b.WriteString("Signers:\n")
+	for _, signer := range d.Signers {
+		fmt.Fprintf(&b, "  %s (%s): payload %s, envelope %s\n",
+			signer.Address.HexWithPrefix(),
+			strings.Join(signer.Roles, ", "),
+			formatKeys(signer.PayloadKeys),
+			formatKeys(signer.EnvelopeKeys),
+		)
+	}
+
+	return b.String()
+}
+
+func formatKeys(keys []uint32) string {
+	if len(keys) == 0 {
+		return "not signed"
+	}
+
+	indices := make([]string, len(keys))
+	for i, key := range keys {
+		indices[i] = fmt.Sprintf("%d", key)
+	}
+
+	return "signed by key " + strings.Join(indices, ", ")
+}
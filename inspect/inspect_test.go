@@ -0,0 +1,179 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inspect_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/inspect"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+const transferScript = `
+import FungibleToken from 0xf233dcee88fe0abe
+import "FlowToken"
+
+transaction(amount: UFix64, to: Address) {
+	prepare(signer: &Account) {}
+}
+`
+
+func newTransaction(t *testing.T) *flow.Transaction {
+	addresses := test.AddressGenerator()
+	proposer, payer := addresses.New(), addresses.New()
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(transferScript)).
+		SetComputeLimit(100).
+		SetReferenceBlockID(test.IdentifierGenerator().New()).
+		SetProposalKey(proposer, 2, 7).
+		SetPayer(payer).
+		AddAuthorizer(proposer)
+
+	require.NoError(t, tx.AddArgument(cadence.UFix64(1_000_000_00)))
+	require.NoError(t, tx.AddArgument(cadence.NewAddress(payer)))
+
+	return tx
+}
+
+func TestDescribe(t *testing.T) {
+	tx := newTransaction(t)
+	tx.AddPayloadSignature(tx.ProposalKey.Address, 2, []byte{1})
+	tx.AddEnvelopeSignature(tx.Payer, 0, []byte{2})
+
+	d, err := inspect.Describe(tx)
+	require.NoError(t, err)
+
+	assert.Equal(t, tx.ID(), d.ID)
+	assert.Equal(t, uint64(100), d.ComputeLimit)
+
+	require.Len(t, d.Imports, 2)
+	assert.Equal(t, []string{"FungibleToken"}, d.Imports[0].Identifiers)
+	assert.Equal(t, flow.HexToAddress("f233dcee88fe0abe"), d.Imports[0].Address)
+	assert.Equal(t, "0xf233dcee88fe0abe", d.Imports[0].Location)
+	assert.Equal(t, `"FlowToken"`, d.Imports[1].Location)
+	assert.Equal(t, flow.EmptyAddress, d.Imports[1].Address)
+
+	require.Len(t, d.Arguments, 2)
+	assert.Equal(t, "amount", d.Arguments[0].Name)
+	assert.Equal(t, "UFix64", d.Arguments[0].DeclaredType)
+	assert.Equal(t, "UFix64", d.Arguments[0].Type)
+	assert.Equal(t, cadence.UFix64(1_000_000_00), d.Arguments[0].Value)
+	assert.Equal(t, "to", d.Arguments[1].Name)
+	assert.Equal(t, "Address", d.Arguments[1].Type)
+
+	require.Len(t, d.Signers, 2)
+	assert.Equal(t, tx.ProposalKey.Address, d.Signers[0].Address)
+	assert.Equal(t, []string{"proposer", "authorizer"}, d.Signers[0].Roles)
+	assert.Equal(t, []uint32{2}, d.Signers[0].PayloadKeys)
+	assert.Empty(t, d.Signers[0].EnvelopeKeys)
+	assert.Equal(t, tx.Payer, d.Signers[1].Address)
+	assert.Equal(t, []string{"payer"}, d.Signers[1].Roles)
+	assert.Equal(t, []uint32{0}, d.Signers[1].EnvelopeKeys)
+
+	s := d.String()
+	assert.Contains(t, s, tx.ID().String())
+	assert.Contains(t, s, "amount: UFix64 = 1.00000000")
+	assert.Contains(t, s, "FungibleToken from 0xf233dcee88fe0abe")
+	assert.Contains(t, s, "(payer): payload not signed, envelope signed by key 0")
+}
+
+func TestDescribe_Errors(t *testing.T) {
+	t.Run("Invalid script", func(t *testing.T) {
+		tx := flow.NewTransaction().SetScript([]byte("transaction {"))
+
+		_, err := inspect.Describe(tx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid argument", func(t *testing.T) {
+		tx := newTransaction(t)
+		tx.Arguments[0] = []byte("not json")
+
+		_, err := inspect.Describe(tx)
+		assert.Error(t, err)
+	})
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("Identical", func(t *testing.T) {
+		a := newTransaction(t)
+		b := newTransaction(t)
+
+		assert.Empty(t, inspect.Diff(a, b))
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		a := newTransaction(t)
+		b := newTransaction(t)
+
+		b.Arguments[0] = []byte(`{"type":"UFix64","value":"1000.00000000"}`)
+		b.SetComputeLimit(9999)
+		b.AddAuthorizer(flow.HexToAddress("01"))
+
+		diffs := inspect.Diff(a, b)
+		require.Len(t, diffs, 3)
+
+		assert.Equal(t, inspect.Difference{Field: "arguments[0]", Before: "1.00000000", After: "1000.00000000"}, diffs[0])
+		assert.Equal(t, "compute_limit", diffs[1].Field)
+		assert.Equal(t, "authorizers", diffs[2].Field)
+	})
+
+	t.Run("Re-encoded argument", func(t *testing.T) {
+		a := newTransaction(t)
+		b := newTransaction(t)
+
+		b.Arguments[0] = []byte(`{"type":"UFix64","value":"1.00000000"}`)
+
+		diffs := inspect.Diff(a, b)
+		require.Len(t, diffs, 1)
+		assert.Equal(t, `"{\"type\":\"UFix64\",\"value\":\"1.00000000\"}"`, diffs[0].After)
+	})
+
+	t.Run("Trailing newline", func(t *testing.T) {
+		a := newTransaction(t)
+		b := newTransaction(t)
+
+		b.Arguments[0] = append(append([]byte{}, a.Arguments[0]...), '\n')
+
+		diffs := inspect.Diff(a, b)
+		require.Len(t, diffs, 1)
+		assert.Equal(t, "arguments[0]", diffs[0].Field)
+		assert.NotEqual(t, diffs[0].Before, diffs[0].After)
+		assert.Equal(t, strconv.Quote(string(a.Arguments[0])), diffs[0].Before)
+		assert.Equal(t, strconv.Quote(string(a.Arguments[0])+"\n"), diffs[0].After)
+	})
+
+	t.Run("Signatures", func(t *testing.T) {
+		a := newTransaction(t)
+		b := newTransaction(t)
+
+		b.AddEnvelopeSignature(b.Payer, 0, []byte{1})
+
+		diffs := inspect.Diff(a, b)
+		require.Len(t, diffs, 1)
+		assert.Equal(t, "envelope_signatures", diffs[0].Field)
+	})
+}
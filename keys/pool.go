@@ -0,0 +1,364 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package keys provides utilities for managing account keys: leasing them as transaction
+// proposal keys and rotating them.
+package keys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// AccountKeysClient is the subset of the Access API used by a ProposalKeyPool.
+//
+// It is implemented by the gRPC access client.
+type AccountKeysClient interface {
+	// GetAccountKeysAtLatestBlock gets all keys of an account at the latest sealed block.
+	GetAccountKeysAtLatestBlock(ctx context.Context, address flow.Address) ([]*flow.AccountKey, error)
+
+	// GetAccountKeyAtLatestBlock gets a single account key at the latest sealed block.
+	GetAccountKeyAtLatestBlock(ctx context.Context, address flow.Address, keyIndex uint32) (*flow.AccountKey, error)
+}
+
+// ErrNoProposalKeys is returned when an account has no keys that can be used for proposing transactions.
+var ErrNoProposalKeys = errors.New("keys: account has no usable proposal keys")
+
+// invalidProposalSeqNumberErrorCode is the FVM error code reported when a transaction
+// declares a proposal key sequence number that does not match the on-chain value.
+const invalidProposalSeqNumberErrorCode = "Error Code: 1007"
+
+// IsSequenceNumberMismatch returns true if the given error reports that a transaction
+// used an outdated or otherwise invalid proposal key sequence number.
+func IsSequenceNumberMismatch(err error) bool {
+	return err != nil && strings.Contains(err.Error(), invalidProposalSeqNumberErrorCode)
+}
+
+// A PoolOption configures a ProposalKeyPool.
+type PoolOption func(*poolConfig)
+
+type poolConfig struct {
+	filter func(key *flow.AccountKey) bool
+}
+
+// WithKeyFilter restricts the pool to the account keys for which filter returns true.
+//
+// Revoked keys are always excluded.
+func WithKeyFilter(filter func(key *flow.AccountKey) bool) PoolOption {
+	return func(config *poolConfig) {
+		config.filter = filter
+	}
+}
+
+// WithKeyIndices restricts the pool to the account keys with the given indices.
+func WithKeyIndices(indices ...uint32) PoolOption {
+	allowed := make(map[uint32]struct{}, len(indices))
+	for _, index := range indices {
+		allowed[index] = struct{}{}
+	}
+
+	return WithKeyFilter(func(key *flow.AccountKey) bool {
+		_, ok := allowed[key.Index]
+		return ok
+	})
+}
+
+// A ProposalKeyPool hands out the keys of a single account for use as transaction proposal keys.
+//
+// Each key is leased to at most one caller at a time, together with the sequence number
+// the next transaction proposed with that key must declare. This allows many transactions
+// to be sent in parallel from the same account without sequence number collisions.
+//
+// A ProposalKeyPool is safe for concurrent use.
+type ProposalKeyPool struct {
+	client  AccountKeysClient
+	address flow.Address
+	free    chan uint32
+
+	mu        sync.Mutex
+	sequences map[uint32]uint64
+	inUse     map[uint32]struct{}
+	acquired  uint64
+	waits     uint64
+	resyncs   uint64
+}
+
+// NewProposalKeyPool loads the keys of the given account and returns a pool managing them.
+//
+// All non-revoked keys are used unless the pool is restricted with WithKeyFilter or WithKeyIndices.
+// ErrNoProposalKeys is returned if no key qualifies.
+func NewProposalKeyPool(
+	ctx context.Context,
+	client AccountKeysClient,
+	address flow.Address,
+	opts ...PoolOption,
+) (*ProposalKeyPool, error) {
+	config := poolConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	accountKeys, err := client.GetAccountKeysAtLatestBlock(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("keys: failed to get keys of account %s: %w", address, err)
+	}
+
+	usable := make([]*flow.AccountKey, 0, len(accountKeys))
+	for _, key := range accountKeys {
+		if key.Revoked {
+			continue
+		}
+		if config.filter != nil && !config.filter(key) {
+			continue
+		}
+		usable = append(usable, key)
+	}
+
+	if len(usable) == 0 {
+		return nil, ErrNoProposalKeys
+	}
+
+	p := &ProposalKeyPool{
+		client:    client,
+		address:   address,
+		free:      make(chan uint32, len(usable)),
+		sequences: make(map[uint32]uint64, len(usable)),
+		inUse:     make(map[uint32]struct{}, len(usable)),
+	}
+
+	for _, key := range usable {
+		p.sequences[key.Index] = key.SequenceNumber
+		p.free <- key.Index
+	}
+
+	return p, nil
+}
+
+// Address returns the address of the account whose keys are managed by this pool.
+func (p *ProposalKeyPool) Address() flow.Address {
+	return p.address
+}
+
+// Acquire leases a free proposal key, blocking until one is available or the context is done.
+//
+// The returned lease must be handed back with Complete, Resync or Release.
+func (p *ProposalKeyPool) Acquire(ctx context.Context) (*ProposalKeyLease, error) {
+	select {
+	case index := <-p.free:
+		return p.lease(index, false), nil
+	default:
+	}
+
+	select {
+	case index := <-p.free:
+		return p.lease(index, true), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// TryAcquire leases a free proposal key if one is immediately available.
+func (p *ProposalKeyPool) TryAcquire() (*ProposalKeyLease, bool) {
+	select {
+	case index := <-p.free:
+		return p.lease(index, false), true
+	default:
+		return nil, false
+	}
+}
+
+func (p *ProposalKeyPool) lease(index uint32, waited bool) *ProposalKeyLease {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.inUse[index] = struct{}{}
+	p.acquired++
+	if waited {
+		p.waits++
+	}
+
+	return &ProposalKeyLease{
+		pool:           p,
+		Address:        p.address,
+		KeyIndex:       index,
+		SequenceNumber: p.sequences[index],
+	}
+}
+
+// giveBack returns a key to the pool with the given next sequence number.
+func (p *ProposalKeyPool) giveBack(index uint32, sequenceNumber uint64) {
+	p.mu.Lock()
+	p.sequences[index] = sequenceNumber
+	delete(p.inUse, index)
+	p.mu.Unlock()
+
+	p.free <- index
+}
+
+// Sync reloads the sequence numbers of all keys that are not currently leased.
+//
+// Leased keys are left untouched; their sequence numbers are settled when the lease is returned.
+func (p *ProposalKeyPool) Sync(ctx context.Context) error {
+	accountKeys, err := p.client.GetAccountKeysAtLatestBlock(ctx, p.address)
+	if err != nil {
+		return fmt.Errorf("keys: failed to get keys of account %s: %w", p.address, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, key := range accountKeys {
+		if _, managed := p.sequences[key.Index]; !managed {
+			continue
+		}
+		if _, leased := p.inUse[key.Index]; leased {
+			continue
+		}
+		p.sequences[key.Index] = key.SequenceNumber
+	}
+	p.resyncs++
+
+	return nil
+}
+
+// PoolMetrics is a snapshot of the utilization of a ProposalKeyPool.
+type PoolMetrics struct {
+	// Keys is the number of keys managed by the pool.
+	Keys int
+	// InUse is the number of keys currently leased.
+	InUse int
+	// Available is the number of keys that can be leased without waiting.
+	Available int
+	// Acquired is the total number of leases handed out.
+	Acquired uint64
+	// Waits is the number of leases that had to wait for a key to become free.
+	Waits uint64
+	// Resyncs is the number of times sequence numbers were reloaded from chain.
+	Resyncs uint64
+}
+
+// Utilization returns the fraction of keys currently leased, between 0 and 1.
+func (m PoolMetrics) Utilization() float64 {
+	if m.Keys == 0 {
+		return 0
+	}
+	return float64(m.InUse) / float64(m.Keys)
+}
+
+// Metrics returns a snapshot of the pool utilization.
+func (p *ProposalKeyPool) Metrics() PoolMetrics {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return PoolMetrics{
+		Keys:      len(p.sequences),
+		InUse:     len(p.inUse),
+		Available: len(p.sequences) - len(p.inUse),
+		Acquired:  p.acquired,
+		Waits:     p.waits,
+		Resyncs:   p.resyncs,
+	}
+}
+
+// A ProposalKeyLease grants exclusive use of a proposal key until it is returned to its pool.
+type ProposalKeyLease struct {
+	pool *ProposalKeyPool
+	once sync.Once
+
+	// Address is the account the key belongs to.
+	Address flow.Address
+	// KeyIndex is the index of the leased key on the account.
+	KeyIndex uint32
+	// SequenceNumber is the sequence number the next transaction proposed with this key must declare.
+	SequenceNumber uint64
+}
+
+// ProposalKey returns the proposal key described by this lease.
+func (l *ProposalKeyLease) ProposalKey() flow.ProposalKey {
+	return flow.ProposalKey{
+		Address:        l.Address,
+		KeyIndex:       l.KeyIndex,
+		SequenceNumber: l.SequenceNumber,
+	}
+}
+
+// SetProposalKey sets the leased key and sequence number as the proposal key of the transaction.
+func (l *ProposalKeyLease) SetProposalKey(tx *flow.Transaction) *flow.Transaction {
+	return tx.SetProposalKey(l.Address, l.KeyIndex, l.SequenceNumber)
+}
+
+// Release returns the key to the pool without consuming its sequence number.
+//
+// Use Release if the transaction proposed with this key was never submitted.
+func (l *ProposalKeyLease) Release() {
+	l.once.Do(func() {
+		l.pool.giveBack(l.KeyIndex, l.SequenceNumber)
+	})
+}
+
+// Complete returns the key to the pool once the transaction proposed with it has a final result.
+//
+// The sequence number is incremented unless the transaction expired. If the result reports a
+// sequence number mismatch, the key is resynchronized from chain instead.
+func (l *ProposalKeyLease) Complete(ctx context.Context, result *flow.TransactionResult) error {
+	if result != nil && IsSequenceNumberMismatch(result.Error) {
+		return l.Resync(ctx)
+	}
+
+	l.once.Do(func() {
+		next := l.SequenceNumber
+		if result != nil && result.Status != flow.TransactionStatusExpired {
+			next++
+		}
+		l.pool.giveBack(l.KeyIndex, next)
+	})
+
+	return nil
+}
+
+// Resync reloads the sequence number of the leased key from chain and returns the key to the pool.
+//
+// If the key cannot be loaded, it is returned with its current sequence number and the error is reported.
+func (l *ProposalKeyLease) Resync(ctx context.Context) error {
+	var err error
+
+	l.once.Do(func() {
+		next := l.SequenceNumber
+
+		var key *flow.AccountKey
+		key, err = l.pool.client.GetAccountKeyAtLatestBlock(ctx, l.Address, l.KeyIndex)
+		if err != nil {
+			err = fmt.Errorf("keys: failed to resync key %d of account %s: %w", l.KeyIndex, l.Address, err)
+		} else {
+			next = key.SequenceNumber
+		}
+
+		l.pool.mu.Lock()
+		l.pool.resyncs++
+		l.pool.mu.Unlock()
+
+		l.pool.giveBack(l.KeyIndex, next)
+	})
+
+	return err
+}
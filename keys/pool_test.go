@@ -0,0 +1,276 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/keys"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+type mockKeysClient struct {
+	mu   sync.Mutex
+	keys []*flow.AccountKey
+}
+
+func (c *mockKeysClient) GetAccountKeysAtLatestBlock(_ context.Context, _ flow.Address) ([]*flow.AccountKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]*flow.AccountKey, len(c.keys))
+	for i, key := range c.keys {
+		k := *key
+		keys[i] = &k
+	}
+	return keys, nil
+}
+
+func (c *mockKeysClient) GetAccountKeyAtLatestBlock(_ context.Context, _ flow.Address, keyIndex uint32) (*flow.AccountKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range c.keys {
+		if key.Index == keyIndex {
+			k := *key
+			return &k, nil
+		}
+	}
+	return nil, errors.New("key not found")
+}
+
+func (c *mockKeysClient) setSequenceNumber(keyIndex uint32, sequenceNumber uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.keys[keyIndex].SequenceNumber = sequenceNumber
+}
+
+func newMockKeysClient(count int) *mockKeysClient {
+	keyGen := test.AccountKeyGenerator()
+	client := &mockKeysClient{}
+	for i := 0; i < count; i++ {
+		key := keyGen.New()
+		key.Index = uint32(i)
+		key.SequenceNumber = uint64(i * 10)
+		client.keys = append(client.keys, key)
+	}
+	return client
+}
+
+func TestProposalKeyPool_Acquire(t *testing.T) {
+	ctx := context.Background()
+	address := test.AddressGenerator().New()
+
+	t.Run("Leases every key once", func(t *testing.T) {
+		client := newMockKeysClient(3)
+		pool, err := keys.NewProposalKeyPool(ctx, client, address)
+		require.NoError(t, err)
+
+		seen := make(map[uint32]uint64)
+		for i := 0; i < 3; i++ {
+			lease, err := pool.Acquire(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, address, lease.Address)
+			seen[lease.KeyIndex] = lease.SequenceNumber
+		}
+
+		assert.Equal(t, map[uint32]uint64{0: 0, 1: 10, 2: 20}, seen)
+
+		_, ok := pool.TryAcquire()
+		assert.False(t, ok)
+	})
+
+	t.Run("Blocks until a key is returned", func(t *testing.T) {
+		client := newMockKeysClient(1)
+		pool, err := keys.NewProposalKeyPool(ctx, client, address)
+		require.NoError(t, err)
+
+		lease, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err = pool.Acquire(timeoutCtx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		go lease.Release()
+
+		next, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, lease.KeyIndex, next.KeyIndex)
+		assert.Equal(t, lease.SequenceNumber, next.SequenceNumber)
+		assert.GreaterOrEqual(t, pool.Metrics().Waits, uint64(1))
+	})
+
+	t.Run("Skips revoked and filtered keys", func(t *testing.T) {
+		client := newMockKeysClient(4)
+		client.keys[1].Revoked = true
+
+		pool, err := keys.NewProposalKeyPool(ctx, client, address, keys.WithKeyIndices(1, 2))
+		require.NoError(t, err)
+		assert.Equal(t, 1, pool.Metrics().Keys)
+
+		lease, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(2), lease.KeyIndex)
+	})
+
+	t.Run("Fails without usable keys", func(t *testing.T) {
+		client := newMockKeysClient(1)
+		client.keys[0].Revoked = true
+
+		_, err := keys.NewProposalKeyPool(ctx, client, address)
+		assert.ErrorIs(t, err, keys.ErrNoProposalKeys)
+	})
+}
+
+func TestProposalKeyLease_Complete(t *testing.T) {
+	ctx := context.Background()
+	address := test.AddressGenerator().New()
+
+	t.Run("Increments sequence number on seal", func(t *testing.T) {
+		pool, err := keys.NewProposalKeyPool(ctx, newMockKeysClient(1), address)
+		require.NoError(t, err)
+
+		lease, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+
+		tx := lease.SetProposalKey(flow.NewTransaction())
+		assert.Equal(t, lease.ProposalKey(), tx.ProposalKey)
+
+		err = lease.Complete(ctx, &flow.TransactionResult{Status: flow.TransactionStatusSealed})
+		require.NoError(t, err)
+
+		// returning twice has no effect
+		err = lease.Complete(ctx, &flow.TransactionResult{Status: flow.TransactionStatusSealed})
+		require.NoError(t, err)
+
+		next, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), next.SequenceNumber)
+	})
+
+	t.Run("Keeps sequence number on expiry", func(t *testing.T) {
+		pool, err := keys.NewProposalKeyPool(ctx, newMockKeysClient(1), address)
+		require.NoError(t, err)
+
+		lease, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+
+		err = lease.Complete(ctx, &flow.TransactionResult{Status: flow.TransactionStatusExpired})
+		require.NoError(t, err)
+
+		next, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), next.SequenceNumber)
+	})
+
+	t.Run("Resyncs on sequence number mismatch", func(t *testing.T) {
+		client := newMockKeysClient(1)
+		pool, err := keys.NewProposalKeyPool(ctx, client, address)
+		require.NoError(t, err)
+
+		lease, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+
+		client.setSequenceNumber(0, 42)
+
+		err = lease.Complete(ctx, &flow.TransactionResult{
+			Status: flow.TransactionStatusSealed,
+			Error:  errors.New("[Error Code: 1007] invalid proposal key: public key 0 on account f8d6e0586b0a20c7 has sequence number 42, but given 0"),
+		})
+		require.NoError(t, err)
+
+		next, err := pool.Acquire(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(42), next.SequenceNumber)
+		assert.Equal(t, uint64(1), pool.Metrics().Resyncs)
+	})
+}
+
+func TestProposalKeyPool_Sync(t *testing.T) {
+	ctx := context.Background()
+	client := newMockKeysClient(2)
+
+	pool, err := keys.NewProposalKeyPool(ctx, client, test.AddressGenerator().New(), keys.WithKeyIndices(0))
+	require.NoError(t, err)
+
+	client.setSequenceNumber(0, 7)
+	require.NoError(t, pool.Sync(ctx))
+
+	lease, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), lease.SequenceNumber)
+}
+
+func TestProposalKeyPool_Concurrency(t *testing.T) {
+	ctx := context.Background()
+	pool, err := keys.NewProposalKeyPool(ctx, newMockKeysClient(4), test.AddressGenerator().New())
+	require.NoError(t, err)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		used = make(map[flow.ProposalKey]struct{})
+	)
+
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			lease, err := pool.Acquire(ctx)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			mu.Lock()
+			_, duplicate := used[lease.ProposalKey()]
+			used[lease.ProposalKey()] = struct{}{}
+			mu.Unlock()
+			assert.False(t, duplicate)
+
+			assert.NoError(t, lease.Complete(ctx, &flow.TransactionResult{Status: flow.TransactionStatusSealed}))
+		}()
+	}
+
+	wg.Wait()
+
+	metrics := pool.Metrics()
+	assert.Len(t, used, 40)
+	assert.Equal(t, uint64(40), metrics.Acquired)
+	assert.Equal(t, 0, metrics.InUse)
+	assert.Equal(t, 4, metrics.Available)
+	assert.Equal(t, float64(0), metrics.Utilization())
+}
+
+func TestIsSequenceNumberMismatch(t *testing.T) {
+	assert.False(t, keys.IsSequenceNumberMismatch(nil))
+	assert.False(t, keys.IsSequenceNumberMismatch(errors.New("[Error Code: 1101] cadence runtime error")))
+	assert.True(t, keys.IsSequenceNumberMismatch(errors.New("[Error Code: 1007] invalid proposal key")))
+}
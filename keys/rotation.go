@@ -0,0 +1,165 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+var (
+	// ErrInsufficientKeyWeight is returned when a key rotation would leave an account without
+	// enough non-revoked key weight to authorize transactions.
+	ErrInsufficientKeyWeight = errors.New("keys: rotation leaves the account below the key weight threshold")
+
+	// ErrRotationNotApplied is returned when the keys of an account do not reflect a key rotation.
+	ErrRotationNotApplied = errors.New("keys: rotation is not reflected in the account keys")
+)
+
+// A Rotation adds new keys to an account and revokes old ones in a single transaction.
+type Rotation struct {
+	Address flow.Address
+	// NewKeys are the keys to add, with their target weights. Their indices are assigned on chain.
+	NewKeys []*flow.AccountKey
+	// RevokeKeyIndices are the indices of the keys to revoke.
+	RevokeKeyIndices []uint32
+}
+
+// Check returns an error if the rotation cannot be applied to an account with the given keys.
+//
+// The new keys must be valid, the revoked keys must exist and not be revoked already, and the
+// non-revoked weight remaining after the rotation must be at least flow.AccountKeyWeightThreshold.
+func (r Rotation) Check(current []*flow.AccountKey) error {
+	if len(r.NewKeys) == 0 && len(r.RevokeKeyIndices) == 0 {
+		return fmt.Errorf("keys: rotation of account %s has no keys to add or revoke", r.Address)
+	}
+
+	revoked := make(map[uint32]struct{}, len(r.RevokeKeyIndices))
+	for _, index := range r.RevokeKeyIndices {
+		if _, ok := revoked[index]; ok {
+			return fmt.Errorf("keys: key %d of account %s is revoked more than once", index, r.Address)
+		}
+		revoked[index] = struct{}{}
+	}
+
+	weight := 0
+	for _, key := range current {
+		if _, ok := revoked[key.Index]; ok {
+			if key.Revoked {
+				return fmt.Errorf("keys: key %d of account %s is already revoked", key.Index, r.Address)
+			}
+			delete(revoked, key.Index)
+			continue
+		}
+		if !key.Revoked {
+			weight += key.Weight
+		}
+	}
+
+	for _, index := range r.RevokeKeyIndices {
+		if _, ok := revoked[index]; ok {
+			return fmt.Errorf("keys: key %d of account %s does not exist", index, r.Address)
+		}
+	}
+
+	for i, key := range r.NewKeys {
+		if err := key.Validate(); err != nil {
+			return fmt.Errorf("keys: new key %d is invalid: %w", i, err)
+		}
+		weight += key.Weight
+	}
+
+	if weight < flow.AccountKeyWeightThreshold {
+		return fmt.Errorf("%w: remaining weight %d, required %d", ErrInsufficientKeyWeight, weight, flow.AccountKeyWeightThreshold)
+	}
+
+	return nil
+}
+
+// Transaction builds the rotation transaction without checking it against the account keys.
+//
+// The account must authorize the transaction.
+func (r Rotation) Transaction() (*flow.Transaction, error) {
+	return templates.RotateAccountKeys(r.Address, r.NewKeys, r.RevokeKeyIndices)
+}
+
+// PrepareRotation loads the keys of the rotated account, checks the rotation against them
+// and builds the rotation transaction.
+//
+// ErrInsufficientKeyWeight is returned if the rotation would lock the account.
+func PrepareRotation(ctx context.Context, client AccountKeysClient, rotation Rotation) (*flow.Transaction, error) {
+	current, err := client.GetAccountKeysAtLatestBlock(ctx, rotation.Address)
+	if err != nil {
+		return nil, fmt.Errorf("keys: failed to get keys of account %s: %w", rotation.Address, err)
+	}
+
+	if err := rotation.Check(current); err != nil {
+		return nil, err
+	}
+
+	return rotation.Transaction()
+}
+
+// ConfirmRotation reads the keys of the rotated account and checks that the rotation was applied:
+// every revoked key is marked as revoked, and every new key is present with its target weight.
+//
+// It should be called once the rotation transaction is sealed. The current account keys are returned.
+func ConfirmRotation(ctx context.Context, client AccountKeysClient, rotation Rotation) ([]*flow.AccountKey, error) {
+	current, err := client.GetAccountKeysAtLatestBlock(ctx, rotation.Address)
+	if err != nil {
+		return nil, fmt.Errorf("keys: failed to get keys of account %s: %w", rotation.Address, err)
+	}
+
+	byIndex := make(map[uint32]*flow.AccountKey, len(current))
+	for _, key := range current {
+		byIndex[key.Index] = key
+	}
+
+	for _, index := range rotation.RevokeKeyIndices {
+		key, ok := byIndex[index]
+		if !ok || !key.Revoked {
+			return current, fmt.Errorf("%w: key %d is not revoked", ErrRotationNotApplied, index)
+		}
+	}
+
+	for i, newKey := range rotation.NewKeys {
+		if !hasKey(current, newKey) {
+			return current, fmt.Errorf("%w: new key %d was not added", ErrRotationNotApplied, i)
+		}
+	}
+
+	return current, nil
+}
+
+// hasKey returns true if a non-revoked key with the same public key, algorithms and weight exists.
+func hasKey(keys []*flow.AccountKey, target *flow.AccountKey) bool {
+	for _, key := range keys {
+		if !key.Revoked &&
+			key.Weight == target.Weight &&
+			key.HashAlgo == target.HashAlgo &&
+			key.PublicKey.Equals(target.PublicKey) {
+			return true
+		}
+	}
+	return false
+}
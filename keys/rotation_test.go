@@ -0,0 +1,166 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/keys"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+// apply mimics the effect of a sealed rotation transaction on the mock account.
+func (c *mockKeysClient) apply(rotation keys.Rotation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, index := range rotation.RevokeKeyIndices {
+		c.keys[index].Revoked = true
+	}
+	for _, key := range rotation.NewKeys {
+		k := *key
+		k.Index = uint32(len(c.keys))
+		c.keys = append(c.keys, &k)
+	}
+}
+
+func TestRotation_Check(t *testing.T) {
+	keyGen := test.AccountKeyGenerator()
+	address := test.AddressGenerator().New()
+
+	current := []*flow.AccountKey{keyGen.New(), keyGen.New()}
+	current[0].Index = 0
+	current[1].Index = 1
+	current[1].Weight = 500
+
+	t.Run("Valid", func(t *testing.T) {
+		rotation := keys.Rotation{
+			Address:          address,
+			NewKeys:          []*flow.AccountKey{keyGen.New()},
+			RevokeKeyIndices: []uint32{0, 1},
+		}
+		assert.NoError(t, rotation.Check(current))
+	})
+
+	t.Run("Insufficient weight", func(t *testing.T) {
+		newKey := keyGen.New()
+		newKey.Weight = 400
+
+		rotation := keys.Rotation{
+			Address:          address,
+			NewKeys:          []*flow.AccountKey{newKey},
+			RevokeKeyIndices: []uint32{0},
+		}
+		assert.ErrorIs(t, rotation.Check(current), keys.ErrInsufficientKeyWeight)
+
+		newKey.Weight = 500
+		assert.NoError(t, rotation.Check(current))
+	})
+
+	t.Run("Revoked keys do not count", func(t *testing.T) {
+		revoked := *current[0]
+		revoked.Revoked = true
+
+		rotation := keys.Rotation{
+			Address:          address,
+			RevokeKeyIndices: []uint32{1},
+		}
+		assert.ErrorIs(t, rotation.Check([]*flow.AccountKey{&revoked, current[1]}), keys.ErrInsufficientKeyWeight)
+	})
+
+	t.Run("Invalid revocations", func(t *testing.T) {
+		revoked := *current[1]
+		revoked.Revoked = true
+
+		tests := map[string]struct {
+			keys    []*flow.AccountKey
+			indices []uint32
+		}{
+			"Unknown index":   {current, []uint32{5}},
+			"Duplicate index": {current, []uint32{1, 1}},
+			"Already revoked": {[]*flow.AccountKey{current[0], &revoked}, []uint32{1}},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				rotation := keys.Rotation{
+					Address:          address,
+					NewKeys:          []*flow.AccountKey{keyGen.New()},
+					RevokeKeyIndices: tt.indices,
+				}
+				assert.Error(t, rotation.Check(tt.keys))
+			})
+		}
+	})
+
+	t.Run("Invalid new key", func(t *testing.T) {
+		newKey := keyGen.New()
+		newKey.Weight = flow.AccountKeyWeightThreshold + 1
+
+		rotation := keys.Rotation{Address: address, NewKeys: []*flow.AccountKey{newKey}}
+		assert.Error(t, rotation.Check(current))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Error(t, keys.Rotation{Address: address}.Check(current))
+	})
+}
+
+func TestRotation(t *testing.T) {
+	ctx := context.Background()
+	address := test.AddressGenerator().New()
+	newKey := test.AccountKeyGenerator().New()
+
+	client := newMockKeysClient(2)
+	rotation := keys.Rotation{
+		Address:          address,
+		NewKeys:          []*flow.AccountKey{newKey},
+		RevokeKeyIndices: []uint32{0, 1},
+	}
+
+	tx, err := keys.PrepareRotation(ctx, client, rotation)
+	require.NoError(t, err)
+	assert.Equal(t, []flow.Address{address}, tx.Authorizers)
+	require.Len(t, tx.Arguments, 2)
+
+	_, err = keys.ConfirmRotation(ctx, client, rotation)
+	assert.ErrorIs(t, err, keys.ErrRotationNotApplied)
+
+	client.apply(rotation)
+
+	current, err := keys.ConfirmRotation(ctx, client, rotation)
+	require.NoError(t, err)
+	require.Len(t, current, 3)
+	assert.True(t, current[0].Revoked)
+	assert.True(t, current[1].Revoked)
+	assert.False(t, current[2].Revoked)
+
+	t.Run("Rejects locking rotations", func(t *testing.T) {
+		_, err := keys.PrepareRotation(ctx, client, keys.Rotation{
+			Address:          address,
+			RevokeKeyIndices: []uint32{2},
+		})
+		assert.ErrorIs(t, err, keys.ErrInsufficientKeyWeight)
+	})
+}
@@ -0,0 +1,372 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package refblock provides a reference block provider for building transactions.
+//
+// Every transaction must reference a recent block which determines when the transaction expires.
+// Instead of fetching the latest block header before building each transaction, a Provider keeps
+// a recent finalized header up to date in the background and returns it without a network round trip.
+package refblock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// DefaultTransactionExpiry is the number of blocks after its reference block in which a transaction
+// can still be included, as defined by the Flow protocol.
+const DefaultTransactionExpiry uint64 = 600
+
+// DefaultExpiryMargin is the number of blocks before expiry at which a reference block is no longer handed out.
+const DefaultExpiryMargin uint64 = 100
+
+// DefaultBlockInterval is a conservative estimate of the time between two finalized blocks.
+const DefaultBlockInterval = 500 * time.Millisecond
+
+// DefaultPollInterval is the interval at which the latest block header is fetched.
+const DefaultPollInterval = 5 * time.Second
+
+// DefaultMinResubscribeBackoff and DefaultMaxResubscribeBackoff bound the delay before subscribing
+// again after the block header subscription failed.
+const (
+	DefaultMinResubscribeBackoff = time.Second
+	DefaultMaxResubscribeBackoff = time.Minute
+)
+
+var (
+	// ErrNoReferenceBlock is returned when the provider has not yet obtained a block header.
+	ErrNoReferenceBlock = errors.New("refblock: no reference block available")
+
+	// ErrReferenceBlockExpiring is returned when the most recent block header is too old to be used as a reference.
+	ErrReferenceBlockExpiring = errors.New("refblock: reference block is too close to expiry")
+)
+
+// HeaderClient is the subset of the Access API used to poll the latest block header.
+type HeaderClient interface {
+	// GetLatestBlockHeader gets the latest sealed or unsealed block header.
+	GetLatestBlockHeader(ctx context.Context, isSealed bool) (*flow.BlockHeader, error)
+}
+
+// HeaderSubscriber is the subset of the Access API used to follow new block headers as they are finalized.
+//
+// It is implemented by the gRPC access client.
+type HeaderSubscriber interface {
+	// SubscribeBlocksHeadersFromLatest subscribes to block headers starting at the latest block.
+	SubscribeBlocksHeadersFromLatest(ctx context.Context, blockStatus flow.BlockStatus) (<-chan flow.BlockHeader, <-chan error, error)
+}
+
+// An Option configures a Provider.
+type Option func(*config)
+
+type config struct {
+	pollInterval      time.Duration
+	blockInterval     time.Duration
+	transactionExpiry uint64
+	expiryMargin      uint64
+	subscriber        HeaderSubscriber
+	minBackoff        time.Duration
+	maxBackoff        time.Duration
+	now               func() time.Time
+}
+
+// WithPollInterval sets the interval at which the latest block header is fetched.
+//
+// When a subscription is used, polling only happens if no header was received for this long.
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.pollInterval = interval
+	}
+}
+
+// WithBlockInterval sets the estimated time between two finalized blocks,
+// used to convert the age of a header into a number of blocks.
+func WithBlockInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.blockInterval = interval
+	}
+}
+
+// WithTransactionExpiry sets the number of blocks after which a transaction referencing a block expires.
+func WithTransactionExpiry(blocks uint64) Option {
+	return func(c *config) {
+		c.transactionExpiry = blocks
+	}
+}
+
+// WithExpiryMargin sets how many blocks before expiry a reference block stops being handed out.
+func WithExpiryMargin(blocks uint64) Option {
+	return func(c *config) {
+		c.expiryMargin = blocks
+	}
+}
+
+// WithSubscription follows finalized block headers using the given subscriber instead of only polling.
+//
+// If the subscription fails or ends, the provider polls until it has subscribed again.
+func WithSubscription(subscriber HeaderSubscriber) Option {
+	return func(c *config) {
+		c.subscriber = subscriber
+	}
+}
+
+// WithResubscribeBackoff sets the delay before subscribing again after the subscription failed.
+//
+// The delay starts at minBackoff and doubles after each consecutive failure, up to maxBackoff.
+// It is reset once a header is received from the subscription.
+func WithResubscribeBackoff(minBackoff, maxBackoff time.Duration) Option {
+	return func(c *config) {
+		c.minBackoff = minBackoff
+		c.maxBackoff = maxBackoff
+	}
+}
+
+// A Provider supplies recent finalized block IDs to be used as transaction reference blocks.
+//
+// A Provider is safe for concurrent use.
+type Provider struct {
+	client HeaderClient
+	config config
+
+	mu      sync.RWMutex
+	header  *flow.BlockHeader
+	lastErr error
+	// observedAt is the time the cached header was first fetched.
+	observedAt time.Time
+}
+
+// NewProvider returns a new reference block provider fetching headers with the given client.
+//
+// The provider holds no header until Refresh or Start is called.
+func NewProvider(client HeaderClient, opts ...Option) *Provider {
+	c := config{
+		pollInterval:      DefaultPollInterval,
+		blockInterval:     DefaultBlockInterval,
+		transactionExpiry: DefaultTransactionExpiry,
+		expiryMargin:      DefaultExpiryMargin,
+		minBackoff:        DefaultMinResubscribeBackoff,
+		maxBackoff:        DefaultMaxResubscribeBackoff,
+		now:               time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &Provider{
+		client: client,
+		config: c,
+	}
+}
+
+// Start fetches the latest finalized block header and keeps it up to date in the background
+// until the context is cancelled.
+//
+// An error is returned if the initial header cannot be fetched.
+func (p *Provider) Start(ctx context.Context) error {
+	err := p.Refresh(ctx)
+	if err != nil {
+		return err
+	}
+
+	go p.run(ctx)
+
+	return nil
+}
+
+// Refresh fetches the latest finalized block header.
+func (p *Provider) Refresh(ctx context.Context) error {
+	header, err := p.client.GetLatestBlockHeader(ctx, false)
+	if err != nil {
+		err = fmt.Errorf("refblock: failed to get latest block header: %w", err)
+		p.setErr(err)
+		return err
+	}
+
+	p.update(header)
+
+	return nil
+}
+
+func (p *Provider) run(ctx context.Context) {
+	var (
+		headers <-chan flow.BlockHeader
+		errs    <-chan error
+		// resubscribe fires when it is time to subscribe again after a failure
+		resubscribe <-chan time.Time
+		backoff     time.Duration
+		unsubscribe = func() {}
+	)
+	defer func() { unsubscribe() }()
+
+	// fail drops the subscription and schedules a new one, doubling the delay after each consecutive failure
+	fail := func() {
+		unsubscribe()
+		headers, errs = nil, nil
+
+		if backoff == 0 {
+			backoff = p.config.minBackoff
+		} else {
+			backoff = min(2*backoff, p.config.maxBackoff)
+		}
+		resubscribe = time.After(backoff)
+	}
+
+	subscribe := func() {
+		resubscribe = nil
+
+		subCtx, cancel := context.WithCancel(ctx)
+		h, e, err := p.config.subscriber.SubscribeBlocksHeadersFromLatest(subCtx, flow.BlockStatusFinalized)
+		if err != nil {
+			cancel()
+			p.setErr(fmt.Errorf("refblock: failed to subscribe to block headers: %w", err))
+			fail()
+			return
+		}
+
+		headers, errs, unsubscribe = h, e, cancel
+	}
+
+	if p.config.subscriber != nil {
+		subscribe()
+	}
+
+	ticker := time.NewTicker(p.config.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			_ = p.Refresh(ctx)
+
+		case <-resubscribe:
+			subscribe()
+
+		case header, ok := <-headers:
+			if !ok {
+				// subscription ended, poll until subscribed again
+				fail()
+				continue
+			}
+			backoff = 0
+			p.update(&header)
+			ticker.Reset(p.config.pollInterval)
+
+		case err, ok := <-errs:
+			if ok && err != nil {
+				p.setErr(fmt.Errorf("refblock: block header subscription failed: %w", err))
+			}
+			fail()
+		}
+	}
+}
+
+func (p *Provider) update(header *flow.BlockHeader) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lastErr = nil
+
+	// a lagging access node may return an older header than the one already cached
+	if p.header != nil && header.Height <= p.header.Height {
+		return
+	}
+
+	h := *header
+	p.header = &h
+	p.observedAt = p.config.now()
+}
+
+func (p *Provider) setErr(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lastErr = err
+}
+
+// maxAge returns how old a header may be, measured from its timestamp, before it is considered
+// too close to expiry.
+func (p *Provider) maxAge() time.Duration {
+	if p.config.expiryMargin >= p.config.transactionExpiry {
+		return 0
+	}
+	return time.Duration(p.config.transactionExpiry-p.config.expiryMargin) * p.config.blockInterval
+}
+
+// ReferenceBlock returns the most recent finalized block header known to the provider.
+//
+// ErrReferenceBlockExpiring is returned if the header, as measured from its timestamp, is old
+// enough that a transaction referencing it could expire before being included. This is the case
+// when the access node stops making progress, even though polling it still succeeds.
+func (p *Provider) ReferenceBlock() (*flow.BlockHeader, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.header == nil {
+		if p.lastErr != nil {
+			return nil, fmt.Errorf("%w: %w", ErrNoReferenceBlock, p.lastErr)
+		}
+		return nil, ErrNoReferenceBlock
+	}
+
+	now := p.config.now()
+	age := now.Sub(p.header.Timestamp)
+	if age > p.maxAge() {
+		err := fmt.Errorf(
+			"%w: block %d is %s old and was fetched %s ago",
+			ErrReferenceBlockExpiring,
+			p.header.Height,
+			age,
+			now.Sub(p.observedAt),
+		)
+		if p.lastErr != nil {
+			return nil, fmt.Errorf("%w: %w", err, p.lastErr)
+		}
+		return nil, err
+	}
+
+	header := *p.header
+	return &header, nil
+}
+
+// ReferenceBlockID returns the ID of the most recent finalized block known to the provider.
+func (p *Provider) ReferenceBlockID() (flow.Identifier, error) {
+	header, err := p.ReferenceBlock()
+	if err != nil {
+		return flow.EmptyID, err
+	}
+	return header.ID, nil
+}
+
+// SetReferenceBlockID sets a recent reference block ID on the transaction.
+func (p *Provider) SetReferenceBlockID(tx *flow.Transaction) error {
+	id, err := p.ReferenceBlockID()
+	if err != nil {
+		return err
+	}
+
+	tx.SetReferenceBlockID(id)
+	return nil
+}
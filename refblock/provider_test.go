@@ -0,0 +1,331 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package refblock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+type mockHeaderClient struct {
+	mu      sync.Mutex
+	headers *test.BlockHeaders
+	height  uint64
+	stuck   bool
+	err     error
+	now     func() time.Time
+}
+
+func (c *mockHeaderClient) GetLatestBlockHeader(_ context.Context, _ bool) (*flow.BlockHeader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	if !c.stuck || c.height == 0 {
+		c.height++
+	}
+	header := c.headers.New()
+	header.Height = c.height
+	header.Timestamp = time.Now()
+	if c.now != nil {
+		header.Timestamp = c.now()
+	}
+	return &header, nil
+}
+
+func (c *mockHeaderClient) setStuck(stuck bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stuck = stuck
+}
+
+func (c *mockHeaderClient) setErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.err = err
+}
+
+type mockHeaderSubscriber struct {
+	headers chan flow.BlockHeader
+	errs    chan error
+}
+
+func (s *mockHeaderSubscriber) SubscribeBlocksHeadersFromLatest(
+	_ context.Context,
+	_ flow.BlockStatus,
+) (<-chan flow.BlockHeader, <-chan error, error) {
+	return s.headers, s.errs, nil
+}
+
+// subscription is the result of a call to SubscribeBlocksHeadersFromLatest.
+type subscription struct {
+	headers chan flow.BlockHeader
+	errs    chan error
+	err     error
+}
+
+// scriptedSubscriber returns the given subscriptions in order, one per call.
+type scriptedSubscriber struct {
+	subscriptions chan subscription
+}
+
+func (s *scriptedSubscriber) SubscribeBlocksHeadersFromLatest(
+	ctx context.Context,
+	_ flow.BlockStatus,
+) (<-chan flow.BlockHeader, <-chan error, error) {
+	select {
+	case sub := <-s.subscriptions:
+		return sub.headers, sub.errs, sub.err
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestProvider_ReferenceBlock(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("No reference block before refresh", func(t *testing.T) {
+		provider := NewProvider(&mockHeaderClient{headers: test.BlockHeaderGenerator()})
+
+		_, err := provider.ReferenceBlockID()
+		assert.ErrorIs(t, err, ErrNoReferenceBlock)
+	})
+
+	t.Run("Returns latest header after refresh", func(t *testing.T) {
+		provider := NewProvider(&mockHeaderClient{headers: test.BlockHeaderGenerator()})
+
+		require.NoError(t, provider.Refresh(ctx))
+		require.NoError(t, provider.Refresh(ctx))
+
+		header, err := provider.ReferenceBlock()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), header.Height)
+
+		tx := flow.NewTransaction()
+		require.NoError(t, provider.SetReferenceBlockID(tx))
+		assert.Equal(t, header.ID, tx.ReferenceBlockID)
+	})
+
+	t.Run("Refuses header close to expiry", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		client := &mockHeaderClient{headers: test.BlockHeaderGenerator(), now: clock.Now}
+		provider := NewProvider(
+			client,
+			WithBlockInterval(time.Second),
+			WithTransactionExpiry(600),
+			WithExpiryMargin(100),
+		)
+		provider.config.now = clock.Now
+
+		require.NoError(t, provider.Refresh(ctx))
+
+		clock.Advance(499 * time.Second)
+		_, err := provider.ReferenceBlockID()
+		require.NoError(t, err)
+
+		client.setErr(errors.New("unavailable"))
+		assert.Error(t, provider.Refresh(ctx))
+
+		clock.Advance(2 * time.Second)
+		_, err = provider.ReferenceBlockID()
+		assert.ErrorIs(t, err, ErrReferenceBlockExpiring)
+
+		client.setErr(nil)
+		require.NoError(t, provider.Refresh(ctx))
+		_, err = provider.ReferenceBlockID()
+		assert.NoError(t, err)
+	})
+
+	t.Run("Refuses stale header of a stuck access node", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		client := &mockHeaderClient{headers: test.BlockHeaderGenerator(), now: clock.Now}
+		provider := NewProvider(
+			client,
+			WithBlockInterval(time.Second),
+			WithTransactionExpiry(600),
+			WithExpiryMargin(100),
+		)
+		provider.config.now = clock.Now
+
+		require.NoError(t, provider.Refresh(ctx))
+		first, err := provider.ReferenceBlock()
+		require.NoError(t, err)
+
+		// the access node keeps answering with the same height
+		client.setStuck(true)
+		for i := 0; i < 6; i++ {
+			clock.Advance(100 * time.Second)
+			require.NoError(t, provider.Refresh(ctx))
+		}
+
+		_, err = provider.ReferenceBlockID()
+		assert.ErrorIs(t, err, ErrReferenceBlockExpiring)
+
+		client.setStuck(false)
+		require.NoError(t, provider.Refresh(ctx))
+		header, err := provider.ReferenceBlock()
+		require.NoError(t, err)
+		assert.Greater(t, header.Height, first.Height)
+	})
+
+	t.Run("Refuses header with old timestamp", func(t *testing.T) {
+		provider := NewProvider(&mockHeaderClient{headers: test.BlockHeaderGenerator()})
+
+		header := test.BlockHeaderGenerator().New()
+		header.Timestamp = time.Now().Add(-time.Hour)
+		provider.update(&header)
+
+		_, err := provider.ReferenceBlockID()
+		assert.ErrorIs(t, err, ErrReferenceBlockExpiring)
+	})
+}
+
+func TestProvider_Start(t *testing.T) {
+	t.Run("Polls in the background", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		provider := NewProvider(
+			&mockHeaderClient{headers: test.BlockHeaderGenerator()},
+			WithPollInterval(time.Millisecond),
+		)
+		require.NoError(t, provider.Start(ctx))
+
+		assert.Eventually(t, func() bool {
+			header, err := provider.ReferenceBlock()
+			return err == nil && header.Height > 3
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("Follows subscription", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		subscriber := &mockHeaderSubscriber{
+			headers: make(chan flow.BlockHeader),
+			errs:    make(chan error),
+		}
+		provider := NewProvider(
+			&mockHeaderClient{headers: test.BlockHeaderGenerator()},
+			WithSubscription(subscriber),
+			WithPollInterval(time.Hour),
+		)
+		require.NoError(t, provider.Start(ctx))
+
+		header := test.BlockHeaderGenerator().New()
+		header.Height = 100
+		header.Timestamp = time.Now()
+		subscriber.headers <- header
+
+		assert.Eventually(t, func() bool {
+			id, err := provider.ReferenceBlockID()
+			return err == nil && id == header.ID
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("Resubscribes after subscription failure", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		subscriber := &scriptedSubscriber{subscriptions: make(chan subscription)}
+		provider := NewProvider(
+			&mockHeaderClient{headers: test.BlockHeaderGenerator()},
+			WithSubscription(subscriber),
+			WithPollInterval(time.Hour),
+			WithResubscribeBackoff(time.Millisecond, 10*time.Millisecond),
+		)
+		require.NoError(t, provider.Start(ctx))
+
+		// the first subscription fails with an error
+		failing := subscription{headers: make(chan flow.BlockHeader), errs: make(chan error)}
+		subscriber.subscriptions <- failing
+		failing.errs <- errors.New("stream reset")
+
+		// subscribing again fails immediately, then succeeds
+		subscriber.subscriptions <- subscription{err: errors.New("unavailable")}
+		recovered := subscription{headers: make(chan flow.BlockHeader), errs: make(chan error)}
+		subscriber.subscriptions <- recovered
+
+		header := test.BlockHeaderGenerator().New()
+		header.Height = 100
+		header.Timestamp = time.Now()
+		recovered.headers <- header
+
+		assert.Eventually(t, func() bool {
+			id, err := provider.ReferenceBlockID()
+			return err == nil && id == header.ID
+		}, time.Second, time.Millisecond)
+
+		// the subscription also recovers after ending
+		close(recovered.headers)
+		resubscribed := subscription{headers: make(chan flow.BlockHeader), errs: make(chan error)}
+		subscriber.subscriptions <- resubscribed
+
+		header = test.BlockHeaderGenerator().New()
+		header.Height = 101
+		header.Timestamp = time.Now()
+		resubscribed.headers <- header
+
+		assert.Eventually(t, func() bool {
+			id, err := provider.ReferenceBlockID()
+			return err == nil && id == header.ID
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("Fails if initial header cannot be fetched", func(t *testing.T) {
+		client := &mockHeaderClient{headers: test.BlockHeaderGenerator(), err: errors.New("unavailable")}
+		provider := NewProvider(client)
+
+		assert.Error(t, provider.Start(context.Background()))
+
+		_, err := provider.ReferenceBlockID()
+		assert.ErrorIs(t, err, ErrNoReferenceBlock)
+	})
+}
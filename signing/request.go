@@ -0,0 +1,387 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package signing provides a portable format for collecting the signatures of a transaction
+// from multiple independent parties.
+//
+// A signing request wraps the RLP encoded transaction together with the metadata each signer
+// needs to review it: the network it is meant for, the accounts required to sign and their roles,
+// and human-readable descriptions of the arguments. Requests are encoded as versioned JSON so that
+// they can be passed between services, and signatures collected by different parties are combined with Merge.
+package signing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// RequestType identifies an encoded signing request.
+const RequestType = "flow-transaction-signing-request"
+
+// Version is the current version of the signing request format.
+const Version = 1
+
+var (
+	// ErrConflictingTransaction is returned when merging requests for different transactions.
+	ErrConflictingTransaction = errors.New("signing: requests are for different transactions")
+	// ErrInvalidatedEnvelopeSignature is returned when merging would invalidate existing envelope signatures,
+	// because they were produced over a different set of payload signatures.
+	ErrInvalidatedEnvelopeSignature = errors.New("signing: envelope signatures were produced over different payload signatures")
+)
+
+// A Role is a signing role of an account in a transaction.
+type Role string
+
+// List of signing roles.
+const (
+	RoleProposer   Role = "proposer"
+	RolePayer      Role = "payer"
+	RoleAuthorizer Role = "authorizer"
+)
+
+// A Signer is an account required to sign the transaction of a request.
+type Signer struct {
+	Address flow.Address `json:"address"`
+	Roles   []Role       `json:"roles"`
+}
+
+// SignsEnvelope returns true if the signer signs the transaction envelope rather than the payload.
+func (s Signer) SignsEnvelope() bool {
+	for _, role := range s.Roles {
+		if role == RolePayer {
+			return true
+		}
+	}
+	return false
+}
+
+// An Argument describes a transaction argument for the parties reviewing a request.
+type Argument struct {
+	Index       int    `json:"index"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// A Request is a transaction that is being signed by multiple parties.
+type Request struct {
+	// ChainID is the network the transaction is meant for.
+	ChainID flow.ChainID
+	// Transaction is the transaction being signed, including the signatures collected so far.
+	Transaction *flow.Transaction
+	// Signers lists the accounts required to sign the transaction, in signer order.
+	Signers []Signer
+	// Arguments describes the transaction arguments.
+	Arguments []Argument
+}
+
+// NewRequest returns a signing request for the given transaction on the given network.
+//
+// The transaction must have its proposal key, payer and authorizers set.
+func NewRequest(chainID flow.ChainID, tx *flow.Transaction) (*Request, error) {
+	if tx.ProposalKey.Address == flow.EmptyAddress {
+		return nil, errors.New("signing: transaction has no proposal key")
+	}
+	if tx.Payer == flow.EmptyAddress {
+		return nil, errors.New("signing: transaction has no payer")
+	}
+
+	return &Request{
+		ChainID:     chainID,
+		Transaction: tx,
+		Signers:     signers(tx),
+	}, nil
+}
+
+// signers returns the signing accounts of the transaction with their roles, in signer order.
+func signers(tx *flow.Transaction) []Signer {
+	var result []Signer
+	index := make(map[flow.Address]int)
+
+	add := func(address flow.Address, role Role) {
+		i, ok := index[address]
+		if !ok {
+			i = len(result)
+			index[address] = i
+			result = append(result, Signer{Address: address})
+		}
+		for _, r := range result[i].Roles {
+			if r == role {
+				return
+			}
+		}
+		result[i].Roles = append(result[i].Roles, role)
+	}
+
+	add(tx.ProposalKey.Address, RoleProposer)
+	add(tx.Payer, RolePayer)
+	for _, authorizer := range tx.Authorizers {
+		add(authorizer, RoleAuthorizer)
+	}
+
+	return result
+}
+
+// DescribeArgument attaches a name and human-readable description to the argument at the given index.
+func (r *Request) DescribeArgument(index int, name string, description string) error {
+	if index < 0 || index >= len(r.Transaction.Arguments) {
+		return fmt.Errorf("signing: no argument at index %d", index)
+	}
+
+	for i, arg := range r.Arguments {
+		if arg.Index == index {
+			r.Arguments[i].Name = name
+			r.Arguments[i].Description = description
+			return nil
+		}
+	}
+
+	r.Arguments = append(r.Arguments, Argument{
+		Index:       index,
+		Name:        name,
+		Description: description,
+	})
+
+	return nil
+}
+
+// SignPayload signs the transaction payload with the given account key.
+func (r *Request) SignPayload(address flow.Address, keyIndex uint32, signer crypto.Signer) error {
+	return r.Transaction.SignPayload(address, keyIndex, signer)
+}
+
+// SignEnvelope signs the transaction envelope with the given account key.
+//
+// The envelope must only be signed once all payload signatures have been collected.
+func (r *Request) SignEnvelope(address flow.Address, keyIndex uint32, signer crypto.Signer) error {
+	return r.Transaction.SignEnvelope(address, keyIndex, signer)
+}
+
+// Pending returns the signers that have not yet provided a signature.
+//
+// Payers are pending until they signed the envelope, all other signers until they signed the payload.
+func (r *Request) Pending() []Signer {
+	payload := make(map[flow.Address]struct{})
+	for _, sig := range r.Transaction.PayloadSignatures {
+		payload[sig.Address] = struct{}{}
+	}
+
+	envelope := make(map[flow.Address]struct{})
+	for _, sig := range r.Transaction.EnvelopeSignatures {
+		envelope[sig.Address] = struct{}{}
+	}
+
+	var pending []Signer
+	for _, signer := range r.Signers {
+		signed := payload
+		if signer.SignsEnvelope() {
+			signed = envelope
+		}
+		if _, ok := signed[signer.Address]; !ok {
+			pending = append(pending, signer)
+		}
+	}
+
+	return pending
+}
+
+// Complete returns true if every signer has provided a signature.
+func (r *Request) Complete() bool {
+	return len(r.Pending()) == 0
+}
+
+type requestJSON struct {
+	Type        string     `json:"type"`
+	Version     int        `json:"version"`
+	ChainID     string     `json:"chainId"`
+	Transaction string     `json:"transaction"`
+	Signers     []Signer   `json:"signers"`
+	Arguments   []Argument `json:"arguments,omitempty"`
+}
+
+// Encode returns the JSON representation of the signing request.
+func (r *Request) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// MarshalJSON returns the versioned JSON representation of the signing request.
+func (r *Request) MarshalJSON() ([]byte, error) {
+	return json.Marshal(requestJSON{
+		Type:        RequestType,
+		Version:     Version,
+		ChainID:     string(r.ChainID),
+		Transaction: hex.EncodeToString(r.Transaction.Encode()),
+		Signers:     r.Signers,
+		Arguments:   r.Arguments,
+	})
+}
+
+// UnmarshalJSON decodes a signing request and checks that its metadata matches the transaction.
+func (r *Request) UnmarshalJSON(data []byte) error {
+	var temp requestJSON
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return fmt.Errorf("signing: failed to decode request: %w", err)
+	}
+
+	if temp.Type != RequestType {
+		return fmt.Errorf("signing: unexpected request type %q", temp.Type)
+	}
+
+	if temp.Version != Version {
+		return fmt.Errorf("signing: unsupported request version %d", temp.Version)
+	}
+
+	encodedTx, err := hex.DecodeString(temp.Transaction)
+	if err != nil {
+		return fmt.Errorf("signing: failed to decode transaction: %w", err)
+	}
+
+	tx, err := flow.DecodeTransaction(encodedTx)
+	if err != nil {
+		return fmt.Errorf("signing: failed to decode transaction: %w", err)
+	}
+
+	if !sameSigners(temp.Signers, signers(tx)) {
+		return errors.New("signing: signers do not match transaction")
+	}
+
+	for _, arg := range temp.Arguments {
+		if arg.Index < 0 || arg.Index >= len(tx.Arguments) {
+			return fmt.Errorf("signing: description for missing argument at index %d", arg.Index)
+		}
+	}
+
+	*r = Request{
+		ChainID:     flow.ChainID(temp.ChainID),
+		Transaction: tx,
+		Signers:     temp.Signers,
+		Arguments:   temp.Arguments,
+	}
+
+	return nil
+}
+
+// Decode decodes a signing request from its JSON representation.
+func Decode(data []byte) (*Request, error) {
+	r := &Request{}
+	err := json.Unmarshal(data, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func sameSigners(a, b []Signer) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Address != b[i].Address || len(a[i].Roles) != len(b[i].Roles) {
+			return false
+		}
+		for j := range a[i].Roles {
+			if a[i].Roles[j] != b[i].Roles[j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Merge combines the signatures collected independently for the same transaction into a single request.
+//
+// All requests must be for the same network and transaction body, otherwise ErrConflictingTransaction
+// is returned. Signatures are deduplicated by account key. Because envelope signatures cover the
+// payload signatures, ErrInvalidatedEnvelopeSignature is returned if a request carries envelope
+// signatures produced over a different set of payload signatures than the merged result.
+//
+// The argument descriptions of the first request are kept.
+func Merge(requests ...*Request) (*Request, error) {
+	if len(requests) == 0 {
+		return nil, errors.New("signing: no requests to merge")
+	}
+
+	base := requests[0]
+	payload := base.Transaction.PayloadMessage()
+
+	tx := *base.Transaction
+	tx.PayloadSignatures = nil
+	tx.EnvelopeSignatures = nil
+
+	type keyID struct {
+		address  flow.Address
+		keyIndex uint32
+	}
+	payloadKeys := make(map[keyID]struct{})
+	envelopeKeys := make(map[keyID]struct{})
+
+	for _, r := range requests {
+		if r.ChainID != base.ChainID {
+			return nil, fmt.Errorf("%w: chain %s differs from %s", ErrConflictingTransaction, r.ChainID, base.ChainID)
+		}
+
+		if !bytes.Equal(r.Transaction.PayloadMessage(), payload) {
+			return nil, fmt.Errorf("%w: %s differs from %s", ErrConflictingTransaction, r.Transaction.ID(), base.Transaction.ID())
+		}
+
+		for _, sig := range r.Transaction.PayloadSignatures {
+			id := keyID{address: sig.Address, keyIndex: sig.KeyIndex}
+			if _, ok := payloadKeys[id]; ok {
+				continue
+			}
+			payloadKeys[id] = struct{}{}
+			tx.AddPayloadSignature(sig.Address, sig.KeyIndex, sig.Signature)
+		}
+	}
+
+	envelope := tx.EnvelopeMessage()
+
+	for _, r := range requests {
+		if len(r.Transaction.EnvelopeSignatures) == 0 {
+			continue
+		}
+
+		if !bytes.Equal(r.Transaction.EnvelopeMessage(), envelope) {
+			return nil, ErrInvalidatedEnvelopeSignature
+		}
+
+		for _, sig := range r.Transaction.EnvelopeSignatures {
+			id := keyID{address: sig.Address, keyIndex: sig.KeyIndex}
+			if _, ok := envelopeKeys[id]; ok {
+				continue
+			}
+			envelopeKeys[id] = struct{}{}
+			tx.AddEnvelopeSignature(sig.Address, sig.KeyIndex, sig.Signature)
+		}
+	}
+
+	return &Request{
+		ChainID:     base.ChainID,
+		Transaction: &tx,
+		Signers:     signers(&tx),
+		Arguments:   base.Arguments,
+	}, nil
+}
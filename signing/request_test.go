@@ -0,0 +1,165 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signing_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/signing"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestRequest(t *testing.T) {
+	addresses := test.AddressGenerator()
+	keys := test.AccountKeyGenerator()
+	ids := test.IdentifierGenerator()
+
+	alice, bob, payer := addresses.New(), addresses.New(), addresses.New()
+	_, aliceSigner := keys.NewWithSigner()
+	_, bobSigner := keys.NewWithSigner()
+	_, payerSigner := keys.NewWithSigner()
+
+	newRequest := func(t *testing.T) *signing.Request {
+		tx := flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			AddRawArgument(jsoncdc.MustEncode(cadence.String("hello"))).
+			SetReferenceBlockID(ids.New()).
+			SetProposalKey(alice, 0, 1).
+			SetPayer(payer).
+			AddAuthorizer(alice).
+			AddAuthorizer(bob)
+
+		r, err := signing.NewRequest(flow.Testnet, tx)
+		require.NoError(t, err)
+		return r
+	}
+
+	t.Run("Signers and roles", func(t *testing.T) {
+		r := newRequest(t)
+
+		assert.Equal(t, []signing.Signer{
+			{Address: alice, Roles: []signing.Role{signing.RoleProposer, signing.RoleAuthorizer}},
+			{Address: payer, Roles: []signing.Role{signing.RolePayer}},
+			{Address: bob, Roles: []signing.Role{signing.RoleAuthorizer}},
+		}, r.Signers)
+		assert.Equal(t, r.Signers, r.Pending())
+		assert.False(t, r.Complete())
+	})
+
+	t.Run("Encoding round trip", func(t *testing.T) {
+		r := newRequest(t)
+		require.NoError(t, r.DescribeArgument(0, "greeting", "The greeting to store"))
+		require.Error(t, r.DescribeArgument(1, "missing", ""))
+		require.NoError(t, r.SignPayload(alice, 0, aliceSigner))
+
+		data, err := r.Encode()
+		require.NoError(t, err)
+
+		var raw map[string]any
+		require.NoError(t, json.Unmarshal(data, &raw))
+		assert.Equal(t, signing.RequestType, raw["type"])
+		assert.Equal(t, float64(signing.Version), raw["version"])
+		assert.Equal(t, "flow-testnet", raw["chainId"])
+
+		decoded, err := signing.Decode(data)
+		require.NoError(t, err)
+		assert.Equal(t, r.ChainID, decoded.ChainID)
+		assert.Equal(t, r.Signers, decoded.Signers)
+		assert.Equal(t, r.Arguments, decoded.Arguments)
+		assert.Equal(t, r.Transaction.ID(), decoded.Transaction.ID())
+		assert.Len(t, decoded.Pending(), 2)
+	})
+
+	t.Run("Rejects tampered metadata", func(t *testing.T) {
+		data, err := newRequest(t).Encode()
+		require.NoError(t, err)
+
+		var raw map[string]any
+		require.NoError(t, json.Unmarshal(data, &raw))
+
+		raw["signers"] = []any{}
+		tampered, err := json.Marshal(raw)
+		require.NoError(t, err)
+		_, err = signing.Decode(tampered)
+		assert.Error(t, err)
+
+		raw["version"] = 2
+		tampered, err = json.Marshal(raw)
+		require.NoError(t, err)
+		_, err = signing.Decode(tampered)
+		assert.Error(t, err)
+	})
+
+	t.Run("Merge independent signatures", func(t *testing.T) {
+		r := newRequest(t)
+		data, err := r.Encode()
+		require.NoError(t, err)
+
+		// each party decodes and signs its own copy
+		forAlice, err := signing.Decode(data)
+		require.NoError(t, err)
+		require.NoError(t, forAlice.SignPayload(alice, 0, aliceSigner))
+
+		forBob, err := signing.Decode(data)
+		require.NoError(t, err)
+		require.NoError(t, forBob.SignPayload(bob, 0, bobSigner))
+		// duplicates are ignored
+		require.NoError(t, forBob.SignPayload(alice, 0, aliceSigner))
+
+		merged, err := signing.Merge(forAlice, forBob)
+		require.NoError(t, err)
+		assert.Len(t, merged.Transaction.PayloadSignatures, 2)
+		assert.Equal(t, []signing.Signer{r.Signers[1]}, merged.Pending())
+
+		require.NoError(t, merged.SignEnvelope(payer, 0, payerSigner))
+		assert.True(t, merged.Complete())
+
+		// merging the final request with a payload-only copy keeps the envelope signature valid
+		final, err := signing.Merge(merged, forAlice)
+		require.NoError(t, err)
+		assert.Equal(t, merged.Transaction.ID(), final.Transaction.ID())
+
+		// envelope signed before all payload signatures were merged is rejected
+		early, err := signing.Decode(data)
+		require.NoError(t, err)
+		require.NoError(t, early.SignEnvelope(payer, 0, payerSigner))
+		_, err = signing.Merge(forAlice, early)
+		assert.ErrorIs(t, err, signing.ErrInvalidatedEnvelopeSignature)
+	})
+
+	t.Run("Merge rejects conflicting bodies", func(t *testing.T) {
+		a := newRequest(t)
+		b := newRequest(t)
+
+		_, err := signing.Merge(a, b)
+		assert.ErrorIs(t, err, signing.ErrConflictingTransaction)
+
+		c, err := signing.NewRequest(flow.Mainnet, a.Transaction)
+		require.NoError(t, err)
+		_, err = signing.Merge(a, c)
+		assert.ErrorIs(t, err, signing.ErrConflictingTransaction)
+	})
+}
@@ -0,0 +1,239 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package storage reads the storage usage and capacity of accounts and computes the FLOW deposits
+// needed to increase their capacity.
+//
+// An account's storage capacity is backed by its FLOW balance: every FLOW held in the account's
+// default vault reserves a network-defined number of megabytes of storage.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+// Default settings of GetAll.
+const (
+	DefaultBatchSize   = 100
+	DefaultConcurrency = 4
+)
+
+// Client is the subset of the Access API used to read storage information.
+type Client interface {
+	ExecuteScriptAtLatestBlock(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error)
+}
+
+// Info is the storage usage and capacity of an account.
+type Info struct {
+	Address flow.Address
+	// Used and Capacity are in bytes.
+	Used     uint64 `cadence:"used"`
+	Capacity uint64 `cadence:"capacity"`
+	// Balance is the FLOW balance of the account's default vault, which backs its storage capacity.
+	Balance cadence.UFix64 `cadence:"balance"`
+	// MegabytesPerFLOW is the storage capacity reserved by each FLOW, in megabytes of 10^6 bytes.
+	MegabytesPerFLOW cadence.UFix64 `cadence:"megabytesPerFLOW"`
+	// MinimumReservation is the minimum balance an account must hold to have any storage capacity.
+	MinimumReservation cadence.UFix64 `cadence:"minimumReservation"`
+}
+
+// Available returns the number of bytes the account can still store.
+func (i Info) Available() uint64 {
+	if i.Used >= i.Capacity {
+		return 0
+	}
+	return i.Capacity - i.Used
+}
+
+// RequiredDeposit returns the amount of FLOW that must be deposited into the account
+// for its storage capacity to reach the given number of bytes.
+//
+// The result is rounded up to the smallest UFix64 unit and is zero if the account
+// already has the capacity.
+func (i Info) RequiredDeposit(capacity uint64) cadence.UFix64 {
+	if i.MegabytesPerFLOW == 0 {
+		return 0
+	}
+
+	// Balances are fixed-point values with 8 decimals, so a balance of b units reserves
+	// b * MegabytesPerFLOW / 10^8 megabytes, or b * MegabytesPerFLOW / 10^10 bytes.
+	required := new(big.Int).SetUint64(capacity)
+	required.Mul(required, big.NewInt(10_000_000_000))
+	divisor := new(big.Int).SetUint64(uint64(i.MegabytesPerFLOW))
+	required.Add(required, new(big.Int).Sub(divisor, big.NewInt(1)))
+	required.Quo(required, divisor)
+
+	if !required.IsUint64() {
+		return cadence.UFix64(^uint64(0))
+	}
+
+	balance := required.Uint64()
+	if balance < uint64(i.MinimumReservation) {
+		balance = uint64(i.MinimumReservation)
+	}
+	if balance <= uint64(i.Balance) {
+		return 0
+	}
+	return cadence.UFix64(balance - uint64(i.Balance))
+}
+
+// Get returns the storage information of an account.
+func Get(ctx context.Context, client Client, address flow.Address, network flow.ChainID) (*Info, error) {
+	infos, err := getBatch(ctx, client, []flow.Address{address}, network)
+	if err != nil {
+		return nil, err
+	}
+	return &infos[0], nil
+}
+
+// An Option configures GetAll.
+type Option func(*config)
+
+type config struct {
+	batchSize   int
+	concurrency int
+}
+
+// WithBatchSize sets how many accounts are read by a single script.
+func WithBatchSize(n int) Option {
+	return func(config *config) {
+		config.batchSize = n
+	}
+}
+
+// WithConcurrency sets how many scripts are executed at the same time.
+func WithConcurrency(n int) Option {
+	return func(config *config) {
+		config.concurrency = n
+	}
+}
+
+// GetAll returns the storage information of many accounts, in the order of the given addresses.
+//
+// The accounts are read in batches by scripts executed in parallel. The first failing batch
+// cancels the remaining ones and its error is returned.
+func GetAll(
+	ctx context.Context,
+	client Client,
+	addresses []flow.Address,
+	network flow.ChainID,
+	opts ...Option,
+) ([]Info, error) {
+	config := config{
+		batchSize:   DefaultBatchSize,
+		concurrency: DefaultConcurrency,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.batchSize < 1 {
+		config.batchSize = 1
+	}
+	if config.concurrency < 1 {
+		config.concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	infos := make([]Info, len(addresses))
+	slots := make(chan struct{}, config.concurrency)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	for start := 0; start < len(addresses); start += config.batchSize {
+		end := min(start+config.batchSize, len(addresses))
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			batch, err := getBatch(ctx, client, addresses[start:end], network)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			copy(infos[start:end], batch)
+		}(start, end)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+func getBatch(ctx context.Context, client Client, addresses []flow.Address, network flow.ChainID) ([]Info, error) {
+	script, err := templates.GetStorageInfo(addresses, network)
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+
+	value, err := client.ExecuteScriptAtLatestBlock(ctx, script.Code, script.Arguments)
+	if err != nil {
+		return nil, fmt.Errorf("storage: failed to read storage of %d accounts: %w", len(addresses), err)
+	}
+
+	array, ok := value.(cadence.Array)
+	if !ok || len(array.Values) != len(addresses) {
+		return nil, fmt.Errorf("storage: unexpected script result %s", value)
+	}
+
+	infos := make([]Info, len(addresses))
+	for i, element := range array.Values {
+		composite, ok := element.(cadence.Struct)
+		if !ok {
+			return nil, fmt.Errorf("storage: unexpected script result %s", element)
+		}
+		if err := cadence.DecodeFields(composite, &infos[i]); err != nil {
+			return nil, fmt.Errorf("storage: cannot decode storage information: %w", err)
+		}
+		infos[i].Address = addresses[i]
+	}
+
+	return infos, nil
+}
@@ -0,0 +1,177 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package storage_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/storage"
+)
+
+// mockClient answers storage scripts with the used bytes set to the last byte of each address.
+type mockClient struct {
+	mu      sync.Mutex
+	batches [][]flow.Address
+	fail    bool
+}
+
+func (c *mockClient) ExecuteScriptAtLatestBlock(_ context.Context, _ []byte, arguments []cadence.Value) (cadence.Value, error) {
+	addresses := arguments[0].(cadence.Array).Values
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.fail {
+		return nil, errors.New("script failed")
+	}
+
+	batch := make([]flow.Address, len(addresses))
+	infos := make([]cadence.Value, len(addresses))
+	for i, value := range addresses {
+		address := value.(cadence.Address)
+		batch[i] = flow.Address(address)
+		infos[i] = storageInfo(address, uint64(address[7]), 100_000, 1_00000000)
+	}
+	c.batches = append(c.batches, batch)
+
+	return cadence.NewArray(infos), nil
+}
+
+var infoType = cadence.NewStructType(
+	common.ScriptLocation{},
+	"StorageInfo",
+	[]cadence.Field{
+		{Identifier: "address", Type: cadence.AddressType},
+		{Identifier: "used", Type: cadence.UInt64Type},
+		{Identifier: "capacity", Type: cadence.UInt64Type},
+		{Identifier: "balance", Type: cadence.UFix64Type},
+		{Identifier: "megabytesPerFLOW", Type: cadence.UFix64Type},
+		{Identifier: "minimumReservation", Type: cadence.UFix64Type},
+	},
+	nil,
+)
+
+func storageInfo(address cadence.Address, used uint64, capacity uint64, balance cadence.UFix64) cadence.Struct {
+	return cadence.NewStruct([]cadence.Value{
+		address,
+		cadence.NewUInt64(used),
+		cadence.NewUInt64(capacity),
+		balance,
+		cadence.UFix64(100_00000000),
+		cadence.UFix64(1000000),
+	}).WithType(infoType)
+}
+
+func addresses(n int) []flow.Address {
+	result := make([]flow.Address, n)
+	for i := range result {
+		binary.BigEndian.PutUint64(result[i][:], uint64(i+1))
+	}
+	return result
+}
+
+func TestGet(t *testing.T) {
+	client := &mockClient{}
+	address := flow.HexToAddress("0a")
+
+	info, err := storage.Get(context.Background(), client, address, flow.Emulator)
+	require.NoError(t, err)
+	assert.Equal(t, &storage.Info{
+		Address:            address,
+		Used:               10,
+		Capacity:           100_000,
+		Balance:            1_00000000,
+		MegabytesPerFLOW:   100_00000000,
+		MinimumReservation: 1000000,
+	}, info)
+	assert.Equal(t, uint64(99_990), info.Available())
+}
+
+func TestGetAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Batches in order", func(t *testing.T) {
+		client := &mockClient{}
+		all := addresses(25)
+
+		infos, err := storage.GetAll(ctx, client, all, flow.Emulator, storage.WithBatchSize(10), storage.WithConcurrency(3))
+		require.NoError(t, err)
+		require.Len(t, infos, 25)
+		for i, info := range infos {
+			assert.Equal(t, all[i], info.Address)
+			assert.Equal(t, uint64(i+1), info.Used)
+		}
+
+		require.Len(t, client.batches, 3)
+		sizes := map[int]int{}
+		for _, batch := range client.batches {
+			sizes[len(batch)]++
+		}
+		assert.Equal(t, map[int]int{10: 2, 5: 1}, sizes)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		infos, err := storage.GetAll(ctx, &mockClient{}, nil, flow.Emulator)
+		require.NoError(t, err)
+		assert.Empty(t, infos)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := storage.GetAll(ctx, &mockClient{fail: true}, addresses(5), flow.Emulator, storage.WithBatchSize(2))
+		assert.ErrorContains(t, err, "script failed")
+	})
+}
+
+func TestInfo_RequiredDeposit(t *testing.T) {
+	// 100 MB per FLOW: each byte of capacity costs 10^-8 FLOW, the smallest UFix64 unit.
+	info := storage.Info{
+		Capacity:           100_000_000,
+		Balance:            1_00000000,
+		MegabytesPerFLOW:   100_00000000,
+		MinimumReservation: 1000000,
+	}
+
+	assert.Equal(t, cadence.UFix64(0), info.RequiredDeposit(50_000_000))
+	assert.Equal(t, cadence.UFix64(0), info.RequiredDeposit(100_000_000))
+	assert.Equal(t, cadence.UFix64(1_00000000), info.RequiredDeposit(200_000_000))
+	assert.Equal(t, cadence.UFix64(1), info.RequiredDeposit(100_000_001))
+
+	t.Run("Rounds up", func(t *testing.T) {
+		info := info
+		info.MegabytesPerFLOW = 300_00000000
+
+		// 1 byte needs 1/3 of the smallest unit.
+		assert.Equal(t, cadence.UFix64(1), info.RequiredDeposit(300_000_001))
+	})
+
+	t.Run("Minimum reservation", func(t *testing.T) {
+		empty := storage.Info{MegabytesPerFLOW: 100_00000000, MinimumReservation: 1000000}
+		assert.Equal(t, cadence.UFix64(1000000), empty.RequiredDeposit(1))
+	})
+}
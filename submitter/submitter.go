@@ -0,0 +1,411 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package submitter sends transactions at high volume from a single proposer account.
+//
+// A Submitter turns unsigned transaction intents into signed transactions by assigning a reference
+// block and a proposal key from a pool, submits them with bounded concurrency and follows them until
+// they are sealed. Transactions that expire or are rejected because of a sequence number mismatch are
+// rebuilt and re-signed automatically.
+package submitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/keys"
+)
+
+const (
+	// DefaultConcurrency is the default number of transactions in flight at the same time.
+	DefaultConcurrency = 10
+	// DefaultMaxAttempts is the default number of times a transaction is built and submitted for one intent.
+	DefaultMaxAttempts = 3
+	// DefaultPollInterval is the default interval between transaction result queries.
+	DefaultPollInterval = time.Second
+)
+
+var (
+	// ErrAttemptsExhausted is reported when an intent expired or hit a sequence number mismatch on every attempt.
+	ErrAttemptsExhausted = errors.New("submitter: maximum number of attempts reached")
+
+	// ErrMissingSigner is reported when no signer is configured for the payer or an authorizer of an intent.
+	ErrMissingSigner = errors.New("submitter: no signer for account")
+)
+
+// A Client sends transactions and reports their results.
+//
+// Both access.Client and the gRPC and HTTP clients implement this interface.
+type Client interface {
+	SendTransaction(ctx context.Context, tx flow.Transaction) error
+	GetTransactionResult(ctx context.Context, txID flow.Identifier) (*flow.TransactionResult, error)
+}
+
+// A ReferenceBlockSource sets the reference block of transactions.
+//
+// A refblock.Provider implements this interface.
+type ReferenceBlockSource interface {
+	SetReferenceBlockID(tx *flow.Transaction) error
+}
+
+// An AccountSigner signs transactions on behalf of an account with one of its keys.
+type AccountSigner struct {
+	Address  flow.Address
+	KeyIndex uint32
+	Signer   crypto.Signer
+}
+
+// An Intent describes a transaction to submit, without the fields the submitter assigns.
+type Intent struct {
+	Script []byte
+	// Arguments are the JSON-CDC encoded transaction arguments.
+	Arguments    [][]byte
+	ComputeLimit uint64
+	// Payer pays for the transaction. The proposer account pays if Payer is empty.
+	Payer       flow.Address
+	Authorizers []flow.Address
+}
+
+// A Result is the final outcome of an intent.
+type Result struct {
+	Intent Intent
+	// TransactionID is the ID of the last transaction submitted for the intent.
+	TransactionID flow.Identifier
+	// Attempts is the number of transactions built for the intent.
+	Attempts int
+	// Result is the last transaction result received, if any.
+	Result *flow.TransactionResult
+	// Err reports why the intent could not be completed, if it could not.
+	//
+	// A transaction that was sealed with an execution error is a completed intent: Err is nil
+	// and the error is reported in Result.
+	Err error
+}
+
+// An Option configures a Submitter.
+type Option func(*config)
+
+type config struct {
+	concurrency  int
+	maxAttempts  int
+	pollInterval time.Duration
+	signers      []AccountSigner
+}
+
+// WithConcurrency sets the maximum number of transactions in flight at the same time.
+func WithConcurrency(n int) Option {
+	return func(config *config) {
+		config.concurrency = n
+	}
+}
+
+// WithMaxAttempts sets how many times a transaction is built and submitted for one intent
+// before ErrAttemptsExhausted is reported.
+func WithMaxAttempts(n int) Option {
+	return func(config *config) {
+		config.maxAttempts = n
+	}
+}
+
+// WithPollInterval sets the interval between transaction result queries.
+func WithPollInterval(interval time.Duration) Option {
+	return func(config *config) {
+		config.pollInterval = interval
+	}
+}
+
+// WithSigners adds signers for the payers and authorizers of intents other than the proposer account.
+func WithSigners(signers ...AccountSigner) Option {
+	return func(config *config) {
+		config.signers = append(config.signers, signers...)
+	}
+}
+
+// A Submitter submits transactions proposed by the keys of a ProposalKeyPool.
+//
+// All keys in the pool are expected to sign with the same proposer signer.
+//
+// A Submitter is safe for concurrent use.
+type Submitter struct {
+	client   Client
+	pool     *keys.ProposalKeyPool
+	refs     ReferenceBlockSource
+	proposer crypto.Signer
+	signers  map[flow.Address]AccountSigner
+	config   config
+
+	slots chan struct{}
+	wg    sync.WaitGroup
+}
+
+// New returns a submitter proposing transactions with the keys of the given pool, signed by proposer.
+func New(
+	client Client,
+	pool *keys.ProposalKeyPool,
+	refs ReferenceBlockSource,
+	proposer crypto.Signer,
+	opts ...Option,
+) *Submitter {
+	config := config{
+		concurrency:  DefaultConcurrency,
+		maxAttempts:  DefaultMaxAttempts,
+		pollInterval: DefaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if config.concurrency < 1 {
+		config.concurrency = 1
+	}
+	if config.maxAttempts < 1 {
+		config.maxAttempts = 1
+	}
+
+	signers := make(map[flow.Address]AccountSigner, len(config.signers))
+	for _, signer := range config.signers {
+		signers[signer.Address] = signer
+	}
+
+	return &Submitter{
+		client:   client,
+		pool:     pool,
+		refs:     refs,
+		proposer: proposer,
+		signers:  signers,
+		config:   config,
+		slots:    make(chan struct{}, config.concurrency),
+	}
+}
+
+// Submit queues an intent for submission and returns a channel that receives its final result.
+//
+// The channel is buffered, so the result is not lost if it is read late. Cancelling ctx abandons the
+// intent; transactions already submitted for it may still be executed.
+func (s *Submitter) Submit(ctx context.Context, intent Intent) <-chan Result {
+	results := make(chan Result, 1)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		results <- s.process(ctx, intent)
+	}()
+
+	return results
+}
+
+// SubmitAndWait submits an intent and blocks until its final result is known.
+func (s *Submitter) SubmitAndWait(ctx context.Context, intent Intent) Result {
+	return <-s.Submit(ctx, intent)
+}
+
+// Wait blocks until every submitted intent has a final result.
+func (s *Submitter) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Submitter) process(ctx context.Context, intent Intent) Result {
+	result := Result{Intent: intent}
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-ctx.Done():
+		result.Err = ctx.Err()
+		return result
+	}
+
+	for result.Attempts < s.config.maxAttempts {
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+			return result
+		}
+
+		result.Attempts++
+
+		txID, txResult, retry, err := s.attempt(ctx, intent)
+		result.TransactionID = txID
+		result.Result = txResult
+		result.Err = err
+
+		if !retry {
+			return result
+		}
+	}
+
+	result.Err = fmt.Errorf("%w (%d)", ErrAttemptsExhausted, result.Attempts)
+	return result
+}
+
+// attempt builds, signs and submits one transaction for the intent and waits for its result.
+//
+// retry reports whether the transaction expired or hit a sequence number mismatch, in which case
+// another attempt may succeed.
+func (s *Submitter) attempt(
+	ctx context.Context,
+	intent Intent,
+) (txID flow.Identifier, result *flow.TransactionResult, retry bool, err error) {
+	lease, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return flow.EmptyID, nil, false, fmt.Errorf("submitter: failed to acquire proposal key: %w", err)
+	}
+
+	tx, err := s.build(ctx, intent, lease)
+	if err != nil {
+		lease.Release()
+		return flow.EmptyID, nil, false, err
+	}
+	txID = tx.ID()
+
+	err = s.client.SendTransaction(ctx, *tx)
+	if err != nil {
+		if keys.IsSequenceNumberMismatch(err) {
+			return txID, nil, true, lease.Resync(ctx)
+		}
+
+		lease.Release()
+		return txID, nil, false, fmt.Errorf("submitter: failed to send transaction %s: %w", txID, err)
+	}
+
+	result, err = s.waitForResult(ctx, txID)
+	if err != nil {
+		// the transaction may or may not have consumed the sequence number
+		_ = lease.Resync(context.WithoutCancel(ctx))
+		return txID, nil, false, err
+	}
+
+	err = lease.Complete(ctx, result)
+	if err != nil {
+		return txID, result, false, err
+	}
+
+	retry = result.Status == flow.TransactionStatusExpired || keys.IsSequenceNumberMismatch(result.Error)
+
+	return txID, result, retry, nil
+}
+
+func (s *Submitter) build(ctx context.Context, intent Intent, lease *keys.ProposalKeyLease) (*flow.Transaction, error) {
+	proposer := lease.Address
+
+	payer := intent.Payer
+	if payer == flow.EmptyAddress {
+		payer = proposer
+	}
+
+	tx := flow.NewTransaction().
+		SetScript(intent.Script).
+		SetComputeLimit(intent.ComputeLimit).
+		SetPayer(payer)
+	lease.SetProposalKey(tx)
+
+	for _, arg := range intent.Arguments {
+		tx.AddRawArgument(arg)
+	}
+
+	for _, authorizer := range intent.Authorizers {
+		tx.AddAuthorizer(authorizer)
+	}
+
+	if err := s.refs.SetReferenceBlockID(tx); err != nil {
+		return nil, fmt.Errorf("submitter: failed to set reference block: %w", err)
+	}
+
+	if err := s.sign(ctx, tx, lease); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// sign adds the payload signatures of the proposer and authorizers and the envelope signature of the payer.
+//
+// Signers implementing crypto.ContextSigner, such as KMS signers, are bound to ctx.
+func (s *Submitter) sign(ctx context.Context, tx *flow.Transaction, lease *keys.ProposalKeyLease) error {
+	signed := map[flow.Address]bool{tx.Payer: true}
+
+	if tx.Payer != lease.Address {
+		if err := tx.SignPayloadWithContext(ctx, lease.Address, lease.KeyIndex, s.proposer); err != nil {
+			return fmt.Errorf("submitter: failed to sign payload as proposer: %w", err)
+		}
+		signed[lease.Address] = true
+	}
+
+	for _, authorizer := range tx.Authorizers {
+		if signed[authorizer] {
+			continue
+		}
+
+		signer, ok := s.signers[authorizer]
+		if !ok {
+			return fmt.Errorf("%w %s", ErrMissingSigner, authorizer)
+		}
+
+		if err := tx.SignPayloadWithContext(ctx, signer.Address, signer.KeyIndex, signer.Signer); err != nil {
+			return fmt.Errorf("submitter: failed to sign payload as %s: %w", authorizer, err)
+		}
+		signed[authorizer] = true
+	}
+
+	if tx.Payer == lease.Address {
+		if err := tx.SignEnvelopeWithContext(ctx, lease.Address, lease.KeyIndex, s.proposer); err != nil {
+			return fmt.Errorf("submitter: failed to sign envelope as payer: %w", err)
+		}
+		return nil
+	}
+
+	signer, ok := s.signers[tx.Payer]
+	if !ok {
+		return fmt.Errorf("%w %s", ErrMissingSigner, tx.Payer)
+	}
+
+	if err := tx.SignEnvelopeWithContext(ctx, signer.Address, signer.KeyIndex, signer.Signer); err != nil {
+		return fmt.Errorf("submitter: failed to sign envelope as payer: %w", err)
+	}
+
+	return nil
+}
+
+// waitForResult polls the transaction result until the transaction is sealed or expired.
+//
+// Errors querying the result are retried until ctx is done.
+func (s *Submitter) waitForResult(ctx context.Context, txID flow.Identifier) (*flow.TransactionResult, error) {
+	ticker := time.NewTicker(s.config.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.client.GetTransactionResult(ctx, txID)
+		if err == nil && isFinal(result.Status) {
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("submitter: stopped waiting for transaction %s: %w", txID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func isFinal(status flow.TransactionStatus) bool {
+	return status == flow.TransactionStatusSealed || status == flow.TransactionStatusExpired
+}
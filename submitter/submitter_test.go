@@ -0,0 +1,307 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package submitter_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/keys"
+	"github.com/onflow/flow-go-sdk/submitter"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+var errSequenceNumber = errors.New("[Error Code: 1007] invalid proposal key: public key 0 has sequence number 1, but given 0")
+
+type mockKeysClient struct {
+	keys []*flow.AccountKey
+}
+
+func (c *mockKeysClient) GetAccountKeysAtLatestBlock(_ context.Context, _ flow.Address) ([]*flow.AccountKey, error) {
+	return c.keys, nil
+}
+
+func (c *mockKeysClient) GetAccountKeyAtLatestBlock(_ context.Context, _ flow.Address, keyIndex uint32) (*flow.AccountKey, error) {
+	for _, key := range c.keys {
+		if key.Index == keyIndex {
+			k := *key
+			k.SequenceNumber = 1
+			return &k, nil
+		}
+	}
+	return nil, errors.New("key not found")
+}
+
+type mockReferenceBlocks struct {
+	id flow.Identifier
+}
+
+func (r *mockReferenceBlocks) SetReferenceBlockID(tx *flow.Transaction) error {
+	tx.SetReferenceBlockID(r.id)
+	return nil
+}
+
+// mockClient returns the given results in order, one per submitted transaction, and seals
+// every transaction after the last one.
+type mockClient struct {
+	mu       sync.Mutex
+	sent     []flow.Transaction
+	outcomes []flow.TransactionResult
+	results  map[flow.Identifier]*flow.TransactionResult
+}
+
+func (c *mockClient) SendTransaction(_ context.Context, tx flow.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := flow.TransactionResult{Status: flow.TransactionStatusSealed}
+	if len(c.outcomes) > 0 {
+		result, c.outcomes = c.outcomes[0], c.outcomes[1:]
+	}
+
+	c.sent = append(c.sent, tx)
+	if c.results == nil {
+		c.results = make(map[flow.Identifier]*flow.TransactionResult)
+	}
+	c.results[tx.ID()] = &result
+
+	return nil
+}
+
+func (c *mockClient) GetTransactionResult(_ context.Context, txID flow.Identifier) (*flow.TransactionResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result, ok := c.results[txID]
+	if !ok {
+		return nil, errors.New("transaction not found")
+	}
+	return result, nil
+}
+
+// blockingSigner cancels the signing context and blocks until the signer observes the cancellation.
+type blockingSigner struct {
+	cancel context.CancelFunc
+}
+
+func (s blockingSigner) Sign([]byte) ([]byte, error) {
+	return nil, errors.New("signed without a context")
+}
+
+func (s blockingSigner) SignWithContext(ctx context.Context, _ []byte) ([]byte, error) {
+	s.cancel()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(5 * time.Second):
+		return nil, errors.New("signing context was not cancelled")
+	}
+}
+
+func (s blockingSigner) PublicKey() crypto.PublicKey {
+	return nil
+}
+
+func newSubmitter(t *testing.T, client *mockClient, keyCount int, opts ...submitter.Option) (*submitter.Submitter, flow.Address) {
+	address := test.AddressGenerator().New()
+	keyGenerator := test.AccountKeyGenerator()
+
+	accountKeys := make([]*flow.AccountKey, keyCount)
+	for i := range accountKeys {
+		accountKeys[i] = keyGenerator.New()
+		accountKeys[i].Index = uint32(i)
+		accountKeys[i].SequenceNumber = 0
+	}
+
+	pool, err := keys.NewProposalKeyPool(context.Background(), &mockKeysClient{keys: accountKeys}, address)
+	require.NoError(t, err)
+
+	_, signer := test.AccountKeyGenerator().NewWithSigner()
+	refs := &mockReferenceBlocks{id: test.IdentifierGenerator().New()}
+
+	opts = append([]submitter.Option{submitter.WithPollInterval(time.Millisecond)}, opts...)
+
+	return submitter.New(client, pool, refs, signer, opts...), address
+}
+
+func TestSubmitter_Submit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Sealed", func(t *testing.T) {
+		client := &mockClient{}
+		s, proposer := newSubmitter(t, client, 1)
+
+		result := s.SubmitAndWait(ctx, submitter.Intent{
+			Script:       test.GreetingScript,
+			ComputeLimit: 100,
+			Authorizers:  []flow.Address{proposer},
+		})
+		require.NoError(t, result.Err)
+
+		assert.Equal(t, 1, result.Attempts)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Result.Status)
+
+		require.Len(t, client.sent, 1)
+		tx := client.sent[0]
+		assert.Equal(t, result.TransactionID, tx.ID())
+		assert.Equal(t, proposer, tx.Payer)
+		assert.Equal(t, proposer, tx.ProposalKey.Address)
+		assert.Empty(t, tx.PayloadSignatures)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, proposer, tx.EnvelopeSignatures[0].Address)
+	})
+
+	t.Run("Separate payer", func(t *testing.T) {
+		client := &mockClient{}
+		payer := flow.HexToAddress("01")
+		_, payerSigner := test.AccountKeyGenerator().NewWithSigner()
+
+		s, proposer := newSubmitter(t, client, 1, submitter.WithSigners(submitter.AccountSigner{
+			Address:  payer,
+			KeyIndex: 3,
+			Signer:   payerSigner,
+		}))
+
+		result := s.SubmitAndWait(ctx, submitter.Intent{
+			Script: test.GreetingScript,
+			Payer:  payer,
+		})
+		require.NoError(t, result.Err)
+
+		tx := client.sent[0]
+		require.Len(t, tx.PayloadSignatures, 1)
+		assert.Equal(t, proposer, tx.PayloadSignatures[0].Address)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+		assert.Equal(t, payer, tx.EnvelopeSignatures[0].Address)
+		assert.Equal(t, uint32(3), tx.EnvelopeSignatures[0].KeyIndex)
+	})
+
+	t.Run("Missing signer", func(t *testing.T) {
+		client := &mockClient{}
+		s, _ := newSubmitter(t, client, 1)
+
+		result := s.SubmitAndWait(ctx, submitter.Intent{
+			Script:      test.GreetingScript,
+			Authorizers: []flow.Address{flow.HexToAddress("01")},
+		})
+		assert.ErrorIs(t, result.Err, submitter.ErrMissingSigner)
+		assert.Empty(t, client.sent)
+	})
+
+	t.Run("Resubmits expired and mismatched transactions", func(t *testing.T) {
+		client := &mockClient{
+			outcomes: []flow.TransactionResult{
+				{Status: flow.TransactionStatusExpired},
+				{Status: flow.TransactionStatusSealed, Error: errSequenceNumber},
+			},
+		}
+		s, _ := newSubmitter(t, client, 1)
+
+		result := s.SubmitAndWait(ctx, submitter.Intent{Script: test.GreetingScript})
+		require.NoError(t, result.Err)
+
+		assert.Equal(t, 3, result.Attempts)
+		require.Len(t, client.sent, 3)
+
+		// the expired transaction did not consume its sequence number, the mismatch resynced it
+		assert.Equal(t, uint64(0), client.sent[0].ProposalKey.SequenceNumber)
+		assert.Equal(t, uint64(0), client.sent[1].ProposalKey.SequenceNumber)
+		assert.Equal(t, uint64(1), client.sent[2].ProposalKey.SequenceNumber)
+		assert.Equal(t, client.sent[2].ID(), result.TransactionID)
+	})
+
+	t.Run("Attempts exhausted", func(t *testing.T) {
+		client := &mockClient{
+			outcomes: []flow.TransactionResult{
+				{Status: flow.TransactionStatusExpired},
+				{Status: flow.TransactionStatusExpired},
+			},
+		}
+		s, _ := newSubmitter(t, client, 1, submitter.WithMaxAttempts(2))
+
+		result := s.SubmitAndWait(ctx, submitter.Intent{Script: test.GreetingScript})
+		assert.ErrorIs(t, result.Err, submitter.ErrAttemptsExhausted)
+		assert.Equal(t, 2, result.Attempts)
+		assert.Equal(t, flow.TransactionStatusExpired, result.Result.Status)
+	})
+
+	t.Run("Concurrent intents", func(t *testing.T) {
+		client := &mockClient{}
+		s, _ := newSubmitter(t, client, 3, submitter.WithConcurrency(2))
+
+		channels := make([]<-chan submitter.Result, 10)
+		for i := range channels {
+			channels[i] = s.Submit(ctx, submitter.Intent{Script: test.GreetingScript})
+		}
+		s.Wait()
+
+		for _, results := range channels {
+			result := <-results
+			assert.NoError(t, result.Err)
+		}
+
+		seen := make(map[flow.ProposalKey]bool)
+		for _, tx := range client.sent {
+			assert.False(t, seen[tx.ProposalKey], "proposal key reused: %v", tx.ProposalKey)
+			seen[tx.ProposalKey] = true
+		}
+		assert.Len(t, client.sent, 10)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		client := &mockClient{}
+		s, _ := newSubmitter(t, client, 1)
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		result := s.SubmitAndWait(cancelled, submitter.Intent{Script: test.GreetingScript})
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	})
+
+	t.Run("Cancelled while signing", func(t *testing.T) {
+		client := &mockClient{}
+		payer := flow.HexToAddress("01")
+
+		signing, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		s, _ := newSubmitter(t, client, 1, submitter.WithSigners(submitter.AccountSigner{
+			Address: payer,
+			Signer:  blockingSigner{cancel: cancel},
+		}))
+
+		result := s.SubmitAndWait(signing, submitter.Intent{
+			Script: test.GreetingScript,
+			Payer:  payer,
+		})
+		assert.ErrorIs(t, result.Err, context.Canceled)
+		assert.Equal(t, 1, result.Attempts)
+		assert.Empty(t, client.sent)
+	})
+}
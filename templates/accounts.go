@@ -21,11 +21,9 @@ package templates
 import (
 	"encoding/hex"
 	"fmt"
-	"strings"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/common"
-	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/cadence/runtime"
 	"github.com/onflow/cadence/sema"
 	templates "github.com/onflow/sdks"
@@ -130,7 +128,7 @@ func newPublicKeyValue(pubKey crypto.PublicKey) (cadence.Struct, error) {
 //
 //	return flow.NewTransaction().
 //		SetScript([]byte(templates.AddAccountKey)).
-//		AddRawArgument(jsoncdc.MustEncode(key))
+//		AddRawArgument(flow.MustEncodeArgument(key))
 //
 // ```
 func AccountKeyToCadenceCryptoKey(key *flow.AccountKey) (cadence.Value, error) {
@@ -217,38 +215,23 @@ func CreateAccountAndFund(
 	script := templates.CreateAccount
 
 	args := [][]byte{
-		jsoncdc.MustEncode(cadencePublicKeys),
-		jsoncdc.MustEncode(cadenceContracts),
+		flow.MustEncodeArgument(cadencePublicKeys),
+		flow.MustEncodeArgument(cadenceContracts),
 	}
 
 	// if we have provided amount and network then we do funding as well
 	if amount != "" && network == flow.Mainnet || network == flow.Testnet {
-		script = templates.CreateAccountFunding
-		// replace the imports on supported networks
-		replaces := map[flow.ChainID]map[string]string{
-			flow.Testnet: {
-				"FlowToken":     "0x7e60df042a9c0868", // https://developers.flow.com/flow/core-contracts/flow-token
-				"FungibleToken": "0x9a0766d93b6608b7", // https://developers.flow.com/flow/core-contracts/fungible-token
-			},
-			flow.Mainnet: {
-				"FlowToken":     "0x1654653399040a61",
-				"FungibleToken": "0xf233dcee88fe0abe",
-			},
-		}[network]
-
-		for contract, address := range replaces {
-			script = strings.ReplaceAll(
-				script,
-				fmt.Sprintf(`"%s"`, contract),
-				fmt.Sprintf(`%s from %s`, contract, address),
-			)
+		resolved, err := resolveImports(network, templates.CreateAccountFunding)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create CreateAccount transaction: %w", err)
 		}
+		script = string(resolved)
 
 		val, err := cadence.NewUFix64(amount)
 		if err != nil {
 			return nil, err
 		}
-		args = append(args, jsoncdc.MustEncode(val))
+		args = append(args, flow.MustEncodeArgument(val))
 	}
 
 	tx := flow.NewTransaction().
@@ -269,8 +252,8 @@ func UpdateAccountContract(address flow.Address, contract Contract) *flow.Transa
 
 	return flow.NewTransaction().
 		SetScript([]byte(templates.UpdateContract)).
-		AddRawArgument(jsoncdc.MustEncode(cadenceName)).
-		AddRawArgument(jsoncdc.MustEncode(cadenceCode)).
+		AddRawArgument(flow.MustEncodeArgument(cadenceName)).
+		AddRawArgument(flow.MustEncodeArgument(cadenceCode)).
 		AddAuthorizer(address)
 }
 
@@ -281,8 +264,8 @@ func AddAccountContract(address flow.Address, contract Contract) *flow.Transacti
 
 	return flow.NewTransaction().
 		SetScript([]byte(templates.AddContract)).
-		AddRawArgument(jsoncdc.MustEncode(cadenceName)).
-		AddRawArgument(jsoncdc.MustEncode(cadenceCode)).
+		AddRawArgument(flow.MustEncodeArgument(cadenceName)).
+		AddRawArgument(flow.MustEncodeArgument(cadenceCode)).
 		AddAuthorizer(address)
 }
 
@@ -295,7 +278,7 @@ func AddAccountKey(address flow.Address, accountKey *flow.AccountKey) (*flow.Tra
 
 	return flow.NewTransaction().
 		SetScript([]byte(templates.AddAccountKey)).
-		AddRawArgument(jsoncdc.MustEncode(key)).
+		AddRawArgument(flow.MustEncodeArgument(key)).
 		AddAuthorizer(address), nil
 }
 
@@ -305,16 +288,60 @@ func RemoveAccountKey(address flow.Address, keyIndex int) *flow.Transaction {
 
 	return flow.NewTransaction().
 		SetScript([]byte(templates.RemoveAccountKey)).
-		AddRawArgument(jsoncdc.MustEncode(cadenceKeyIndex)).
+		AddRawArgument(flow.MustEncodeArgument(cadenceKeyIndex)).
 		AddAuthorizer(address)
 }
 
+const rotateAccountKeysTemplate = `import Crypto
+
+transaction(keys: [Crypto.KeyListEntry], revokeKeyIndices: [Int]) {
+    prepare(signer: auth(AddKey, RevokeKey) &Account) {
+        for key in keys {
+            signer.keys.add(publicKey: key.publicKey, hashAlgorithm: key.hashAlgorithm, weight: key.weight)
+        }
+
+        for keyIndex in revokeKeyIndices {
+            signer.keys.revoke(keyIndex: keyIndex)
+                ?? panic("Account key ".concat(keyIndex.toString()).concat(" does not exist"))
+        }
+    }
+}
+`
+
+// RotateAccountKeys generates a transaction that adds new keys to an account and revokes
+// existing keys in a single step.
+//
+// The new keys are added before the old keys are revoked, so the transaction can still be
+// authorized by the keys it revokes. Revoked keys keep their index and remain visible
+// with their Revoked flag set.
+func RotateAccountKeys(address flow.Address, newKeys []*flow.AccountKey, revokeKeyIndices []uint32) (*flow.Transaction, error) {
+	keyList := make([]cadence.Value, len(newKeys))
+	for i, key := range newKeys {
+		var err error
+		keyList[i], err = AccountKeyToCadenceCryptoKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create RotateAccountKeys transaction: %w", err)
+		}
+	}
+
+	indices := make([]cadence.Value, len(revokeKeyIndices))
+	for i, index := range revokeKeyIndices {
+		indices[i] = cadence.NewInt(int(index))
+	}
+
+	return flow.NewTransaction().
+		SetScript([]byte(rotateAccountKeysTemplate)).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewArray(keyList))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewArray(indices))).
+		AddAuthorizer(address), nil
+}
+
 // RemoveAccountContract generates a transaction that removes a contract with the given name
 func RemoveAccountContract(address flow.Address, contractName string) *flow.Transaction {
 	cadenceName := cadence.String(contractName)
 
 	return flow.NewTransaction().
 		SetScript([]byte(templates.RemoveContract)).
-		AddRawArgument(jsoncdc.MustEncode(cadenceName)).
+		AddRawArgument(flow.MustEncodeArgument(cadenceName)).
 		AddAuthorizer(address)
 }
@@ -21,10 +21,12 @@ package templates_test
 import (
 	"testing"
 
+	"github.com/onflow/cadence"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/test"
 )
 
 func TestCreateAccount(t *testing.T) {
@@ -55,3 +57,23 @@ func TestCreateAccount(t *testing.T) {
 				"2 times the contract code (converted to hex) + 500 bytes of extra data.")
 	})
 }
+
+func TestRotateAccountKeys(t *testing.T) {
+	address := flow.HexToAddress("01")
+	key := test.AccountKeyGenerator().New()
+
+	tx, err := templates.RotateAccountKeys(address, []*flow.AccountKey{key}, []uint32{0, 2})
+	require.NoError(t, err)
+
+	requireParses(t, tx.Script)
+
+	keys, err := tx.Argument(0)
+	require.NoError(t, err)
+	require.Len(t, keys.(cadence.Array).Values, 1)
+
+	indices, err := tx.Argument(1)
+	require.NoError(t, err)
+	require.Equal(t, cadence.NewArray([]cadence.Value{cadence.NewInt(0), cadence.NewInt(2)}), indices.(cadence.Array).WithType(nil))
+
+	require.Equal(t, []flow.Address{address}, tx.Authorizers)
+}
@@ -0,0 +1,286 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+const createCOATemplate = `import "FungibleToken"
+import "FlowToken"
+import "EVM"
+
+transaction(amount: UFix64) {
+    prepare(signer: auth(BorrowValue, SaveValue, IssueStorageCapabilityController, PublishCapability, UnpublishCapability) &Account) {
+        if signer.storage.type(at: /storage/evm) == nil {
+            signer.storage.save(<-EVM.createCadenceOwnedAccount(), to: /storage/evm)
+
+            let coaCap = signer.capabilities.storage.issue<&EVM.CadenceOwnedAccount>(/storage/evm)
+            signer.capabilities.unpublish(/public/evm)
+            signer.capabilities.publish(coaCap, at: /public/evm)
+        }
+
+        if amount > 0.0 {
+            let vault = signer.storage.borrow<auth(FungibleToken.Withdraw) &FlowToken.Vault>(from: /storage/flowTokenVault)
+                ?? panic("Could not borrow a reference to the signer's vault")
+            let coa = signer.storage.borrow<&EVM.CadenceOwnedAccount>(from: /storage/evm)
+                ?? panic("Could not borrow a reference to the signer's COA")
+
+            coa.deposit(from: <-vault.withdraw(amount: amount) as! @FlowToken.Vault)
+        }
+    }
+}
+`
+
+const fundEVMAddressTemplate = `import "FungibleToken"
+import "FlowToken"
+import "EVM"
+
+transaction(to: String, amount: UFix64) {
+    let sentVault: @FlowToken.Vault
+
+    prepare(signer: auth(BorrowValue) &Account) {
+        let vault = signer.storage.borrow<auth(FungibleToken.Withdraw) &FlowToken.Vault>(from: /storage/flowTokenVault)
+            ?? panic("Could not borrow a reference to the signer's vault")
+
+        self.sentVault <- vault.withdraw(amount: amount) as! @FlowToken.Vault
+    }
+
+    execute {
+        EVM.addressFromString(to).deposit(from: <-self.sentVault)
+    }
+}
+`
+
+const evmCallTemplate = `import "EVM"
+
+transaction(to: String, data: String, gasLimit: UInt64, value: UInt) {
+    let coa: auth(EVM.Call) &EVM.CadenceOwnedAccount
+
+    prepare(signer: auth(BorrowValue) &Account) {
+        self.coa = signer.storage.borrow<auth(EVM.Call) &EVM.CadenceOwnedAccount>(from: /storage/evm)
+            ?? panic("Could not borrow a reference to the signer's COA")
+    }
+
+    execute {
+        let result = self.coa.call(
+            to: EVM.addressFromString(to),
+            data: data.decodeHex(),
+            gasLimit: gasLimit,
+            value: EVM.Balance(attoflow: value)
+        )
+
+        assert(
+            result.status == EVM.Status.successful,
+            message: "EVM call failed with error code ".concat(result.errorCode.toString()).concat(": ").concat(result.errorMessage)
+        )
+    }
+}
+`
+
+const evmDeployTemplate = `import "EVM"
+
+transaction(code: String, gasLimit: UInt64, value: UInt) {
+    let coa: auth(EVM.Deploy) &EVM.CadenceOwnedAccount
+
+    prepare(signer: auth(BorrowValue) &Account) {
+        self.coa = signer.storage.borrow<auth(EVM.Deploy) &EVM.CadenceOwnedAccount>(from: /storage/evm)
+            ?? panic("Could not borrow a reference to the signer's COA")
+    }
+
+    execute {
+        let result = self.coa.deploy(
+            code: code.decodeHex(),
+            gasLimit: gasLimit,
+            value: EVM.Balance(attoflow: value)
+        )
+
+        assert(
+            result.status == EVM.Status.successful,
+            message: "EVM deployment failed with error code ".concat(result.errorCode.toString()).concat(": ").concat(result.errorMessage)
+        )
+    }
+}
+`
+
+const getCOAAddressTemplate = `import "EVM"
+
+access(all) fun main(address: Address): String? {
+    if let coa = getAccount(address).capabilities.borrow<&EVM.CadenceOwnedAccount>(/public/evm) {
+        return coa.address().toString()
+    }
+    return nil
+}
+`
+
+// CreateCOA generates a transaction that creates a Cadence-Owned Account (COA) in the signer's account,
+// if it does not have one yet, and funds it with amount FLOW from the signer's vault.
+//
+// A COA is a resource controlling an EVM address. It is stored at /storage/evm and published at /public/evm,
+// where the EVMCall and EVMDeploy transactions expect it.
+//
+// The amount is a decimal UFix64 string; "0.0" creates the COA without funding it.
+func CreateCOA(amount string, signer flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	code, err := resolveImports(network, createCOATemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create CreateCOA transaction: %w", err)
+	}
+
+	value, err := cadence.NewUFix64(amount)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create CreateCOA transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(value)).
+		AddAuthorizer(signer), nil
+}
+
+// FundEVMAddress generates a transaction that deposits FLOW from the signer's vault into an EVM address,
+// such as the address of a COA or of an externally-owned account.
+func FundEVMAddress(to common.Address, amount string, from flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	code, err := resolveImports(network, fundEVMAddressTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create FundEVMAddress transaction: %w", err)
+	}
+
+	value, err := cadence.NewUFix64(amount)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create FundEVMAddress transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.String(evmAddressString(to)))).
+		AddRawArgument(flow.MustEncodeArgument(value)).
+		AddAuthorizer(from), nil
+}
+
+// EVMCall generates a transaction that calls an EVM contract from the signer's COA.
+//
+// The data is the ABI-encoded call and value is the amount of attoFLOW sent with the call,
+// or nil for none. The transaction fails if the EVM call does not succeed.
+func EVMCall(
+	to common.Address,
+	data []byte,
+	gasLimit uint64,
+	value *big.Int,
+	signer flow.Address,
+	network flow.ChainID,
+) (*flow.Transaction, error) {
+	code, err := resolveImports(network, evmCallTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create EVMCall transaction: %w", err)
+	}
+
+	attoflow, err := attoflowValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create EVMCall transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.String(evmAddressString(to)))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.String(hex.EncodeToString(data)))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewUInt64(gasLimit))).
+		AddRawArgument(flow.MustEncodeArgument(attoflow)).
+		AddAuthorizer(signer), nil
+}
+
+// EVMDeploy generates a transaction that deploys an EVM contract from the signer's COA.
+//
+// The bytecode includes the ABI-encoded constructor arguments. The address of the deployed contract
+// is reported by the EVM.TransactionExecuted event of the transaction.
+func EVMDeploy(
+	bytecode []byte,
+	gasLimit uint64,
+	value *big.Int,
+	signer flow.Address,
+	network flow.ChainID,
+) (*flow.Transaction, error) {
+	code, err := resolveImports(network, evmDeployTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create EVMDeploy transaction: %w", err)
+	}
+
+	attoflow, err := attoflowValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create EVMDeploy transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.String(hex.EncodeToString(bytecode)))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewUInt64(gasLimit))).
+		AddRawArgument(flow.MustEncodeArgument(attoflow)).
+		AddAuthorizer(signer), nil
+}
+
+// GetCOAAddress generates a script that returns the EVM address of an account's COA, or nil if it has none.
+//
+// Decode the result with DecodeCOAAddress.
+func GetCOAAddress(address flow.Address, network flow.ChainID) (Script, error) {
+	code, err := resolveImports(network, getCOAAddressTemplate)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create GetCOAAddress script: %w", err)
+	}
+
+	return Script{
+		Code:      code,
+		Arguments: []cadence.Value{cadence.NewAddress(address)},
+	}, nil
+}
+
+// DecodeCOAAddress decodes the result of a GetCOAAddress script.
+//
+// Nil is returned if the account has no COA.
+func DecodeCOAAddress(value cadence.Value) (*common.Address, error) {
+	value = unwrapOptional(value)
+	if value == nil {
+		return nil, nil
+	}
+
+	s, ok := value.(cadence.String)
+	if !ok || !common.IsHexAddress(string(s)) {
+		return nil, fmt.Errorf("cannot decode COA address: unexpected value %v", value)
+	}
+
+	address := common.HexToAddress(string(s))
+	return &address, nil
+}
+
+// evmAddressString formats an address the way EVM.addressFromString expects it.
+func evmAddressString(address common.Address) string {
+	return hex.EncodeToString(address.Bytes())
+}
+
+func attoflowValue(value *big.Int) (cadence.UInt, error) {
+	if value == nil {
+		return cadence.NewUInt(0), nil
+	}
+	return cadence.NewUIntFromBig(value)
+}
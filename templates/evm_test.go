@@ -0,0 +1,134 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+var evmAddress = common.HexToAddress("0x000000000000000000000002f239A6A1DA2F5e6A")
+
+func TestCreateCOA(t *testing.T) {
+	signer := flow.HexToAddress("01")
+
+	tx, err := templates.CreateCOA("1.5", signer, flow.Mainnet)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(tx.Script), "import EVM from 0xe467b9dd11fa00df\n")
+	requireParses(t, tx.Script)
+
+	amount, err := tx.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.UFix64(1_50000000), amount)
+	assert.Equal(t, []flow.Address{signer}, tx.Authorizers)
+}
+
+func TestFundEVMAddress(t *testing.T) {
+	from := flow.HexToAddress("01")
+
+	tx, err := templates.FundEVMAddress(evmAddress, "2.0", from, flow.Testnet)
+	require.NoError(t, err)
+	requireParses(t, tx.Script)
+
+	to, err := tx.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.String("000000000000000000000002f239a6a1da2f5e6a"), to)
+	assert.Equal(t, []flow.Address{from}, tx.Authorizers)
+}
+
+func TestEVMCall(t *testing.T) {
+	signer := flow.HexToAddress("01")
+
+	tx, err := templates.EVMCall(evmAddress, []byte{0xa9, 0x05, 0x9c, 0xbb}, 300_000, big.NewInt(1_000_000_000_000_000_000), signer, flow.Emulator)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(tx.Script), "import EVM from 0xf8d6e0586b0a20c7\n")
+	requireParses(t, tx.Script)
+
+	data, err := tx.Argument(1)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.String("a9059cbb"), data)
+
+	gasLimit, err := tx.Argument(2)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewUInt64(300_000), gasLimit)
+
+	value, err := tx.Argument(3)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewUInt(1_000_000_000_000_000_000), value)
+
+	t.Run("No value", func(t *testing.T) {
+		tx, err := templates.EVMCall(evmAddress, nil, 21_000, nil, signer, flow.Emulator)
+		require.NoError(t, err)
+
+		value, err := tx.Argument(3)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewUInt(0), value)
+	})
+
+	t.Run("Negative value", func(t *testing.T) {
+		_, err := templates.EVMCall(evmAddress, nil, 21_000, big.NewInt(-1), signer, flow.Emulator)
+		assert.Error(t, err)
+	})
+}
+
+func TestEVMDeploy(t *testing.T) {
+	signer := flow.HexToAddress("01")
+
+	tx, err := templates.EVMDeploy([]byte{0x60, 0x80}, 1_000_000, nil, signer, flow.Mainnet)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(tx.Script), "self.coa.deploy(")
+	requireParses(t, tx.Script)
+
+	bytecode, err := tx.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.String("6080"), bytecode)
+	assert.Equal(t, []flow.Address{signer}, tx.Authorizers)
+}
+
+func TestGetCOAAddress(t *testing.T) {
+	address := flow.HexToAddress("01")
+
+	script, err := templates.GetCOAAddress(address, flow.Mainnet)
+	require.NoError(t, err)
+	requireParses(t, script.Code)
+	assert.Equal(t, []cadence.Value{cadence.NewAddress(address)}, script.Arguments)
+
+	decoded, err := templates.DecodeCOAAddress(cadence.NewOptional(cadence.String("000000000000000000000002f239a6a1da2f5e6a")))
+	require.NoError(t, err)
+	require.NotNil(t, decoded)
+	assert.Equal(t, evmAddress, *decoded)
+
+	decoded, err = templates.DecodeCOAAddress(cadence.NewOptional(nil))
+	require.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	_, err = templates.DecodeCOAAddress(cadence.String("not an address"))
+	assert.Error(t, err)
+}
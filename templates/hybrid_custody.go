@@ -0,0 +1,369 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
+)
+
+// HybridCustody describes a deployment of the HybridCustody contracts, which let a parent
+// account manage linked child accounts.
+//
+// The HybridCustody, CapabilityFactory, CapabilityFilter and CapabilityDelegator contracts
+// are deployed to the same account.
+type HybridCustody struct {
+	Address flow.Address
+}
+
+// HybridCustodyForChain returns the HybridCustody deployment of Mainnet or Testnet.
+//
+// Other networks must deploy the contracts themselves; describe them with a HybridCustody value.
+func HybridCustodyForChain(network flow.ChainID) (HybridCustody, error) {
+	switch network {
+	case flow.Mainnet:
+		return HybridCustody{Address: flow.HexToAddress("d8a7e05a7ac670c0")}, nil
+	case flow.Testnet:
+		return HybridCustody{Address: flow.HexToAddress("294e44e1ec6993c6")}, nil
+	default:
+		return HybridCustody{}, fmt.Errorf("%w: HybridCustody is not deployed on %s", contracts.ErrUnsupportedChain, network)
+	}
+}
+
+func (h HybridCustody) code(template string, network flow.ChainID) ([]byte, error) {
+	return resolveImports(network, template,
+		contracts.Contract{Name: "HybridCustody", Address: h.Address},
+		contracts.Contract{Name: "CapabilityFactory", Address: h.Address},
+		contracts.Contract{Name: "CapabilityFilter", Address: h.Address},
+	)
+}
+
+// ParentAccount is a parent of a child account, decoded from the result of a GetParentAccounts script.
+type ParentAccount struct {
+	Address flow.Address
+	// Redeemed is true once the parent has claimed the child account capability.
+	Redeemed bool
+}
+
+const createChildAccountTemplate = `import Crypto
+import "FungibleToken"
+import "FlowToken"
+import "ViewResolver"
+import "HybridCustody"
+
+transaction(key: Crypto.KeyListEntry, amount: UFix64) {
+    prepare(parent: auth(BorrowValue) &Account) {
+        let child = Account(payer: parent)
+        child.keys.add(publicKey: key.publicKey, hashAlgorithm: key.hashAlgorithm, weight: key.weight)
+
+        let vault = parent.storage.borrow<auth(FungibleToken.Withdraw) &FlowToken.Vault>(from: /storage/flowTokenVault)
+            ?? panic("Could not borrow a reference to the parent's vault")
+        let receiver = child.capabilities.borrow<&{FungibleToken.Receiver}>(/public/flowTokenReceiver)
+            ?? panic("Could not borrow a receiver reference to the child's vault")
+        receiver.deposit(from: <-vault.withdraw(amount: amount))
+
+        let accountCap = child.capabilities.account.issue<auth(Storage, Contracts, Keys, Inbox, Capabilities) &Account>()
+        let owned <- HybridCustody.createOwnedAccount(acct: accountCap)
+        child.storage.save(<-owned, to: HybridCustody.OwnedAccountStoragePath)
+
+        let ownedCap = child.capabilities.storage.issue<&{HybridCustody.BorrowableAccount, HybridCustody.OwnedAccountPublic, ViewResolver.Resolver}>(HybridCustody.OwnedAccountStoragePath)
+        child.capabilities.publish(ownedCap, at: HybridCustody.OwnedAccountPublicPath)
+    }
+}
+`
+
+const publishToParentTemplate = `import "HybridCustody"
+import "CapabilityFactory"
+import "CapabilityFilter"
+
+transaction(parent: Address, factoryAddress: Address, filterAddress: Address) {
+    prepare(child: auth(BorrowValue) &Account) {
+        let owned = child.storage.borrow<auth(HybridCustody.Owner) &HybridCustody.OwnedAccount>(from: HybridCustody.OwnedAccountStoragePath)
+            ?? panic("The signer is not set up as an owned account")
+
+        let factory = getAccount(factoryAddress).capabilities.get<&CapabilityFactory.Manager>(CapabilityFactory.PublicPath)
+        assert(factory.check(), message: "The capability factory is not configured")
+
+        let filter = getAccount(filterAddress).capabilities.get<&{CapabilityFilter.Filter}>(CapabilityFilter.PublicPath)
+        assert(filter.check(), message: "The capability filter is not configured")
+
+        owned.publishToParent(parentAddress: parent, factory: factory, filter: filter)
+    }
+}
+`
+
+const claimChildAccountTemplate = `import "ViewResolver"
+import "HybridCustody"
+
+transaction(child: Address) {
+    prepare(parent: auth(Storage, Capabilities, Inbox) &Account) {
+        if parent.storage.borrow<&HybridCustody.Manager>(from: HybridCustody.ManagerStoragePath) == nil {
+            let manager <- HybridCustody.createManager(filter: nil)
+            parent.storage.save(<-manager, to: HybridCustody.ManagerStoragePath)
+
+            parent.capabilities.unpublish(HybridCustody.ManagerPublicPath)
+            let managerCap = parent.capabilities.storage.issue<&{HybridCustody.ManagerPublic}>(HybridCustody.ManagerStoragePath)
+            parent.capabilities.publish(managerCap, at: HybridCustody.ManagerPublicPath)
+        }
+
+        let inboxName = HybridCustody.getChildAccountIdentifier(parent.address)
+        let childCap = parent.inbox.claim<auth(HybridCustody.Child) &{HybridCustody.AccountPrivate, HybridCustody.AccountPublic, ViewResolver.Resolver}>(inboxName, provider: child)
+            ?? panic("No child account capability was published to the signer")
+
+        let manager = parent.storage.borrow<auth(HybridCustody.Manage) &HybridCustody.Manager>(from: HybridCustody.ManagerStoragePath)
+            ?? panic("Could not borrow a reference to the signer's manager")
+        manager.addAccount(cap: childCap)
+    }
+}
+`
+
+const removeChildAccountTemplate = `import "HybridCustody"
+
+transaction(child: Address) {
+    prepare(parent: auth(BorrowValue) &Account) {
+        let manager = parent.storage.borrow<auth(HybridCustody.Manage) &HybridCustody.Manager>(from: HybridCustody.ManagerStoragePath)
+            ?? panic("Could not borrow a reference to the signer's manager")
+
+        manager.removeChild(addr: child)
+    }
+}
+`
+
+const removeParentAccountTemplate = `import "HybridCustody"
+
+transaction(parent: Address) {
+    prepare(child: auth(BorrowValue) &Account) {
+        let owned = child.storage.borrow<auth(HybridCustody.Owner) &HybridCustody.OwnedAccount>(from: HybridCustody.OwnedAccountStoragePath)
+            ?? panic("The signer is not set up as an owned account")
+
+        if !owned.removeParent(parent: parent) {
+            panic("The account is not linked to the given parent")
+        }
+    }
+}
+`
+
+const getChildAccountsTemplate = `import "HybridCustody"
+
+access(all) fun main(parent: Address): [Address] {
+    if let manager = getAccount(parent).capabilities.borrow<&{HybridCustody.ManagerPublic}>(HybridCustody.ManagerPublicPath) {
+        return manager.getChildAddresses()
+    }
+    return []
+}
+`
+
+const getParentAccountsTemplate = `import "HybridCustody"
+
+access(all) fun main(child: Address): {Address: Bool} {
+    if let owned = getAccount(child).capabilities.borrow<&{HybridCustody.OwnedAccountPublic}>(HybridCustody.OwnedAccountPublicPath) {
+        return owned.getParentStatuses()
+    }
+    return {}
+}
+`
+
+// CreateChildAccount generates a transaction that creates a new account funded by the parent
+// and sets it up as a HybridCustody owned account.
+//
+// The parent pays for the account, transfers amount FLOW to it and is added as the transaction
+// authorizer. The holder of key then links the child to a parent with PublishToParent.
+func CreateChildAccount(
+	custody HybridCustody,
+	key *flow.AccountKey,
+	amount string,
+	parent flow.Address,
+	network flow.ChainID,
+) (*flow.Transaction, error) {
+	code, err := custody.code(createChildAccountTemplate, network)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create CreateChildAccount transaction: %w", err)
+	}
+
+	cadenceKey, err := AccountKeyToCadenceCryptoKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create CreateChildAccount transaction: %w", err)
+	}
+
+	value, err := cadence.NewUFix64(amount)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create CreateChildAccount transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadenceKey)).
+		AddRawArgument(flow.MustEncodeArgument(value)).
+		AddAuthorizer(parent), nil
+}
+
+// PublishToParent generates a transaction that publishes a capability on the child account
+// to the inbox of a parent, restricted by the given capability factory and filter.
+//
+// The factory and filter addresses are the accounts their managers are published at.
+// The child is added as the transaction authorizer.
+func PublishToParent(
+	custody HybridCustody,
+	parent flow.Address,
+	factory flow.Address,
+	filter flow.Address,
+	child flow.Address,
+	network flow.ChainID,
+) (*flow.Transaction, error) {
+	code, err := custody.code(publishToParentTemplate, network)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create PublishToParent transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewAddress(parent))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewAddress(factory))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewAddress(filter))).
+		AddAuthorizer(child), nil
+}
+
+// ClaimChildAccount generates a transaction that claims a child account capability published
+// with PublishToParent and adds it to the parent's manager.
+//
+// A manager is created if the parent does not have one yet. The parent is added as the transaction authorizer.
+func ClaimChildAccount(custody HybridCustody, child flow.Address, parent flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	code, err := custody.code(claimChildAccountTemplate, network)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create ClaimChildAccount transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewAddress(child))).
+		AddAuthorizer(parent), nil
+}
+
+// RemoveChildAccount generates a transaction that unlinks a child account from the parent's manager.
+//
+// The parent is added as the transaction authorizer.
+func RemoveChildAccount(custody HybridCustody, child flow.Address, parent flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	code, err := custody.code(removeChildAccountTemplate, network)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create RemoveChildAccount transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewAddress(child))).
+		AddAuthorizer(parent), nil
+}
+
+// RemoveParentAccount generates a transaction that revokes a parent's access to the child account.
+//
+// The child is added as the transaction authorizer.
+func RemoveParentAccount(custody HybridCustody, parent flow.Address, child flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	code, err := custody.code(removeParentAccountTemplate, network)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create RemoveParentAccount transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewAddress(parent))).
+		AddAuthorizer(child), nil
+}
+
+// GetChildAccounts generates a script that returns the addresses of the child accounts of a parent.
+//
+// Decode the result with DecodeAddresses.
+func GetChildAccounts(custody HybridCustody, parent flow.Address, network flow.ChainID) (Script, error) {
+	code, err := custody.code(getChildAccountsTemplate, network)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create GetChildAccounts script: %w", err)
+	}
+
+	return Script{
+		Code:      code,
+		Arguments: []cadence.Value{cadence.NewAddress(parent)},
+	}, nil
+}
+
+// GetParentAccounts generates a script that returns the parents of a child account.
+//
+// Decode the result with DecodeParentAccounts.
+func GetParentAccounts(custody HybridCustody, child flow.Address, network flow.ChainID) (Script, error) {
+	code, err := custody.code(getParentAccountsTemplate, network)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create GetParentAccounts script: %w", err)
+	}
+
+	return Script{
+		Code:      code,
+		Arguments: []cadence.Value{cadence.NewAddress(child)},
+	}, nil
+}
+
+// DecodeAddresses decodes a script result of type [Address].
+func DecodeAddresses(value cadence.Value) ([]flow.Address, error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("cannot decode addresses: expected array, got %s", typeName(value))
+	}
+
+	addresses := make([]flow.Address, len(array.Values))
+	for i, element := range array.Values {
+		address, ok := element.(cadence.Address)
+		if !ok {
+			return nil, fmt.Errorf("cannot decode addresses: expected Address at index %d, got %s", i, typeName(element))
+		}
+		addresses[i] = flow.Address(address)
+	}
+
+	return addresses, nil
+}
+
+// DecodeParentAccounts decodes the result of a GetParentAccounts script.
+//
+// The parents are sorted by address.
+func DecodeParentAccounts(value cadence.Value) ([]ParentAccount, error) {
+	dictionary, ok := value.(cadence.Dictionary)
+	if !ok {
+		return nil, fmt.Errorf("cannot decode parent accounts: expected dictionary, got %s", typeName(value))
+	}
+
+	parents := make([]ParentAccount, len(dictionary.Pairs))
+	for i, pair := range dictionary.Pairs {
+		address, ok := pair.Key.(cadence.Address)
+		if !ok {
+			return nil, fmt.Errorf("cannot decode parent accounts: expected Address key, got %s", typeName(pair.Key))
+		}
+		redeemed, ok := pair.Value.(cadence.Bool)
+		if !ok {
+			return nil, fmt.Errorf("cannot decode parent accounts: expected Bool value, got %s", typeName(pair.Value))
+		}
+		parents[i] = ParentAccount{Address: flow.Address(address), Redeemed: bool(redeemed)}
+	}
+
+	sort.Slice(parents, func(i, j int) bool {
+		return parents[i].Address.Hex() < parents[j].Address.Hex()
+	})
+
+	return parents, nil
+}
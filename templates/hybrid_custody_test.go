@@ -0,0 +1,173 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestHybridCustodyForChain(t *testing.T) {
+	custody, err := templates.HybridCustodyForChain(flow.Mainnet)
+	require.NoError(t, err)
+	assert.Equal(t, flow.HexToAddress("d8a7e05a7ac670c0"), custody.Address)
+
+	_, err = templates.HybridCustodyForChain(flow.Emulator)
+	assert.ErrorIs(t, err, contracts.ErrUnsupportedChain)
+}
+
+func TestHybridCustodyTransactions(t *testing.T) {
+	custody, err := templates.HybridCustodyForChain(flow.Testnet)
+	require.NoError(t, err)
+
+	parent := flow.HexToAddress("01")
+	child := flow.HexToAddress("02")
+
+	t.Run("CreateChildAccount", func(t *testing.T) {
+		tx, err := templates.CreateChildAccount(custody, test.AccountKeyGenerator().New(), "1.0", parent, flow.Testnet)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(tx.Script), "import HybridCustody from 0x294e44e1ec6993c6\n")
+		assert.Contains(t, string(tx.Script), "import FlowToken from 0x7e60df042a9c0868\n")
+		requireParses(t, tx.Script)
+
+		amount, err := tx.Argument(1)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.UFix64(1_00000000), amount)
+		assert.Equal(t, []flow.Address{parent}, tx.Authorizers)
+
+		_, err = templates.CreateChildAccount(custody, test.AccountKeyGenerator().New(), "1", parent, flow.Testnet)
+		assert.Error(t, err)
+	})
+
+	t.Run("PublishToParent", func(t *testing.T) {
+		factory := flow.HexToAddress("03")
+		filter := flow.HexToAddress("04")
+
+		tx, err := templates.PublishToParent(custody, parent, factory, filter, child, flow.Testnet)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(tx.Script), "import CapabilityFactory from 0x294e44e1ec6993c6\n")
+		requireParses(t, tx.Script)
+
+		for i, address := range []flow.Address{parent, factory, filter} {
+			argument, err := tx.Argument(i)
+			require.NoError(t, err)
+			assert.Equal(t, cadence.NewAddress(address), argument)
+		}
+		assert.Equal(t, []flow.Address{child}, tx.Authorizers)
+	})
+
+	t.Run("ClaimChildAccount", func(t *testing.T) {
+		tx, err := templates.ClaimChildAccount(custody, child, parent, flow.Testnet)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(tx.Script), "manager.addAccount(cap: childCap)")
+		requireParses(t, tx.Script)
+		assert.Equal(t, []flow.Address{parent}, tx.Authorizers)
+	})
+
+	t.Run("RemoveChildAccount", func(t *testing.T) {
+		tx, err := templates.RemoveChildAccount(custody, child, parent, flow.Testnet)
+		require.NoError(t, err)
+
+		requireParses(t, tx.Script)
+		argument, err := tx.Argument(0)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewAddress(child), argument)
+		assert.Equal(t, []flow.Address{parent}, tx.Authorizers)
+	})
+
+	t.Run("RemoveParentAccount", func(t *testing.T) {
+		tx, err := templates.RemoveParentAccount(custody, parent, child, flow.Testnet)
+		require.NoError(t, err)
+
+		requireParses(t, tx.Script)
+		argument, err := tx.Argument(0)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewAddress(parent), argument)
+		assert.Equal(t, []flow.Address{child}, tx.Authorizers)
+	})
+
+	t.Run("Custom deployment", func(t *testing.T) {
+		local := templates.HybridCustody{Address: flow.HexToAddress("f3fcd2c1a78f5eee")}
+
+		tx, err := templates.ClaimChildAccount(local, child, parent, flow.Emulator)
+		require.NoError(t, err)
+		assert.Contains(t, string(tx.Script), "import HybridCustody from 0xf3fcd2c1a78f5eee\n")
+		assert.Contains(t, string(tx.Script), "import ViewResolver from 0xf8d6e0586b0a20c7\n")
+	})
+}
+
+func TestHybridCustodyScripts(t *testing.T) {
+	custody, err := templates.HybridCustodyForChain(flow.Mainnet)
+	require.NoError(t, err)
+
+	address := flow.HexToAddress("01")
+
+	script, err := templates.GetChildAccounts(custody, address, flow.Mainnet)
+	require.NoError(t, err)
+	assert.Contains(t, string(script.Code), "return manager.getChildAddresses()")
+	requireParses(t, script.Code)
+	assert.Equal(t, []cadence.Value{cadence.NewAddress(address)}, script.Arguments)
+
+	script, err = templates.GetParentAccounts(custody, address, flow.Mainnet)
+	require.NoError(t, err)
+	assert.Contains(t, string(script.Code), "return owned.getParentStatuses()")
+	requireParses(t, script.Code)
+	assert.Equal(t, []cadence.Value{cadence.NewAddress(address)}, script.Arguments)
+}
+
+func TestDecodeAddresses(t *testing.T) {
+	a := flow.HexToAddress("01")
+	b := flow.HexToAddress("02")
+
+	addresses, err := templates.DecodeAddresses(cadence.NewArray([]cadence.Value{cadence.NewAddress(a), cadence.NewAddress(b)}))
+	require.NoError(t, err)
+	assert.Equal(t, []flow.Address{a, b}, addresses)
+
+	_, err = templates.DecodeAddresses(cadence.NewArray([]cadence.Value{cadence.String("01")}))
+	assert.Error(t, err)
+}
+
+func TestDecodeParentAccounts(t *testing.T) {
+	a := flow.HexToAddress("01")
+	b := flow.HexToAddress("02")
+
+	parents, err := templates.DecodeParentAccounts(cadence.NewDictionary([]cadence.KeyValuePair{
+		{Key: cadence.NewAddress(b), Value: cadence.NewBool(false)},
+		{Key: cadence.NewAddress(a), Value: cadence.NewBool(true)},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []templates.ParentAccount{
+		{Address: a, Redeemed: true},
+		{Address: b, Redeemed: false},
+	}, parents)
+
+	_, err = templates.DecodeParentAccounts(cadence.NewArray(nil))
+	assert.Error(t, err)
+}
@@ -0,0 +1,274 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
+)
+
+// A NonFungibleToken describes an NFT contract and the paths its collections are stored and published at.
+//
+// Paths are given as identifiers, without their domain: "exampleNFTCollection" rather than
+// "/storage/exampleNFTCollection".
+type NonFungibleToken struct {
+	// Contract is the NFT contract, which must define NFT and Collection resources
+	// and a createEmptyCollection function.
+	Contract contracts.Contract
+	// StoragePath is the storage path of the collection.
+	StoragePath string
+	// PublicPath is the public path of the collection capability.
+	PublicPath string
+}
+
+// code fills in a template with the NFT contract and paths and resolves its imports for the network.
+func (n NonFungibleToken) code(template string, network flow.ChainID) ([]byte, error) {
+	if err := checkIdentifiers(n.Contract.Name, n.StoragePath, n.PublicPath); err != nil {
+		return nil, err
+	}
+
+	replacer := strings.NewReplacer(
+		"{{NFT}}", n.Contract.Name,
+		"{{StoragePath}}", n.StoragePath,
+		"{{PublicPath}}", n.PublicPath,
+	)
+
+	return resolveImports(network, replacer.Replace(template), n.Contract)
+}
+
+const setupNFTCollectionTemplate = `import "NonFungibleToken"
+import "{{NFT}}"
+
+transaction {
+    prepare(signer: auth(BorrowValue, SaveValue, IssueStorageCapabilityController, PublishCapability, UnpublishCapability) &Account) {
+        if signer.storage.borrow<&{{NFT}}.Collection>(from: /storage/{{StoragePath}}) == nil {
+            let collection <- {{NFT}}.createEmptyCollection(nftType: Type<@{{NFT}}.NFT>())
+            signer.storage.save(<-collection, to: /storage/{{StoragePath}})
+        }
+
+        signer.capabilities.unpublish(/public/{{PublicPath}})
+        let collectionCap = signer.capabilities.storage.issue<&{{NFT}}.Collection>(/storage/{{StoragePath}})
+        signer.capabilities.publish(collectionCap, at: /public/{{PublicPath}})
+    }
+}
+`
+
+const transferNFTTemplate = `import "NonFungibleToken"
+import "{{NFT}}"
+
+transaction(to: Address, id: UInt64) {
+    let collection: auth(NonFungibleToken.Withdraw) &{{NFT}}.Collection
+
+    prepare(signer: auth(BorrowValue) &Account) {
+        self.collection = signer.storage.borrow<auth(NonFungibleToken.Withdraw) &{{NFT}}.Collection>(from: /storage/{{StoragePath}})
+            ?? panic("Could not borrow a reference to the signer's collection")
+    }
+
+    execute {
+        let receiver = getAccount(to).capabilities.borrow<&{NonFungibleToken.Receiver}>(/public/{{PublicPath}})
+            ?? panic("Could not borrow a receiver reference to the recipient's collection")
+
+        receiver.deposit(token: <-self.collection.withdraw(withdrawID: id))
+    }
+}
+`
+
+const batchTransferNFTsTemplate = `import "NonFungibleToken"
+import "{{NFT}}"
+
+transaction(to: Address, ids: [UInt64]) {
+    let collection: auth(NonFungibleToken.Withdraw) &{{NFT}}.Collection
+
+    prepare(signer: auth(BorrowValue) &Account) {
+        self.collection = signer.storage.borrow<auth(NonFungibleToken.Withdraw) &{{NFT}}.Collection>(from: /storage/{{StoragePath}})
+            ?? panic("Could not borrow a reference to the signer's collection")
+    }
+
+    execute {
+        let receiver = getAccount(to).capabilities.borrow<&{NonFungibleToken.Receiver}>(/public/{{PublicPath}})
+            ?? panic("Could not borrow a receiver reference to the recipient's collection")
+
+        for id in ids {
+            receiver.deposit(token: <-self.collection.withdraw(withdrawID: id))
+        }
+    }
+}
+`
+
+const burnNFTTemplate = `import "NonFungibleToken"
+import "Burner"
+import "{{NFT}}"
+
+transaction(id: UInt64) {
+    prepare(signer: auth(BorrowValue) &Account) {
+        let collection = signer.storage.borrow<auth(NonFungibleToken.Withdraw) &{{NFT}}.Collection>(from: /storage/{{StoragePath}})
+            ?? panic("Could not borrow a reference to the signer's collection")
+
+        Burner.burn(<-collection.withdraw(withdrawID: id))
+    }
+}
+`
+
+const getNFTIDsTemplate = `import "NonFungibleToken"
+
+access(all) fun main(address: Address): [UInt64] {
+    let collection = getAccount(address).capabilities.borrow<&{NonFungibleToken.Collection}>(/public/{{PublicPath}})
+        ?? panic("Could not borrow a reference to the account's collection")
+
+    return collection.getIDs()
+}
+`
+
+// getNFTViewTemplate returns a script resolving a MetadataViews view of an NFT with the given helper function.
+func getNFTViewTemplate(view string) string {
+	return `import "NonFungibleToken"
+import "MetadataViews"
+
+access(all) fun main(address: Address, id: UInt64): MetadataViews.` + view + `? {
+    let collection = getAccount(address).capabilities.borrow<&{NonFungibleToken.Collection}>(/public/{{PublicPath}})
+        ?? panic("Could not borrow a reference to the account's collection")
+
+    let nft = collection.borrowNFT(id)
+        ?? panic("The collection does not contain an NFT with the given ID")
+
+    return MetadataViews.get` + view + `(nft)
+}
+`
+}
+
+// SetupNFTCollection generates a transaction that stores an empty collection in the signer's account
+// and publishes a capability to it.
+//
+// An existing collection is kept; its capability is republished.
+func SetupNFTCollection(nft NonFungibleToken, account flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	code, err := nft.code(setupNFTCollectionTemplate, network)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create SetupNFTCollection transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddAuthorizer(account), nil
+}
+
+// TransferNFT generates a transaction that transfers an NFT between the collections of two accounts.
+//
+// The sender is added as the transaction authorizer and therefore must sign the resulting transaction.
+func TransferNFT(nft NonFungibleToken, id uint64, to flow.Address, from flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	code, err := nft.code(transferNFTTemplate, network)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create TransferNFT transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewAddress(to))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewUInt64(id))).
+		AddAuthorizer(from), nil
+}
+
+// BatchTransferNFTs generates a transaction that transfers several NFTs between the collections of two accounts.
+//
+// The sender is added as the transaction authorizer and therefore must sign the resulting transaction.
+func BatchTransferNFTs(nft NonFungibleToken, ids []uint64, to flow.Address, from flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	code, err := nft.code(batchTransferNFTsTemplate, network)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create BatchTransferNFTs transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewAddress(to))).
+		AddRawArgument(flow.MustEncodeArgument(uint64Array(ids))).
+		AddAuthorizer(from), nil
+}
+
+// BurnNFT generates a transaction that withdraws an NFT from the owner's collection and destroys it.
+func BurnNFT(nft NonFungibleToken, id uint64, owner flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	code, err := nft.code(burnNFTTemplate, network)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create BurnNFT transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewUInt64(id))).
+		AddAuthorizer(owner), nil
+}
+
+// GetNFTIDs generates a script that returns the IDs of the NFTs in an account's collection.
+//
+// Decode the result with DecodeNFTIDs.
+func GetNFTIDs(nft NonFungibleToken, address flow.Address, network flow.ChainID) (Script, error) {
+	code, err := nft.code(getNFTIDsTemplate, network)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create GetNFTIDs script: %w", err)
+	}
+
+	return Script{
+		Code:      code,
+		Arguments: []cadence.Value{cadence.NewAddress(address)},
+	}, nil
+}
+
+// GetNFTDisplay generates a script that returns the MetadataViews.Display view of an NFT.
+//
+// Decode the result with DecodeNFTDisplay.
+func GetNFTDisplay(nft NonFungibleToken, address flow.Address, id uint64, network flow.ChainID) (Script, error) {
+	return nftViewScript(nft, "Display", address, id, network)
+}
+
+// GetNFTRoyalties generates a script that returns the MetadataViews.Royalties view of an NFT.
+//
+// Decode the result with DecodeNFTRoyalties.
+func GetNFTRoyalties(nft NonFungibleToken, address flow.Address, id uint64, network flow.ChainID) (Script, error) {
+	return nftViewScript(nft, "Royalties", address, id, network)
+}
+
+// GetNFTEditions generates a script that returns the MetadataViews.Editions view of an NFT.
+//
+// Decode the result with DecodeNFTEditions.
+func GetNFTEditions(nft NonFungibleToken, address flow.Address, id uint64, network flow.ChainID) (Script, error) {
+	return nftViewScript(nft, "Editions", address, id, network)
+}
+
+func nftViewScript(nft NonFungibleToken, view string, address flow.Address, id uint64, network flow.ChainID) (Script, error) {
+	code, err := nft.code(getNFTViewTemplate(view), network)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create GetNFT%s script: %w", view, err)
+	}
+
+	return Script{
+		Code:      code,
+		Arguments: []cadence.Value{cadence.NewAddress(address), cadence.NewUInt64(id)},
+	}, nil
+}
+
+func uint64Array(values []uint64) cadence.Array {
+	array := make([]cadence.Value, len(values))
+	for i, value := range values {
+		array[i] = cadence.NewUInt64(value)
+	}
+	return cadence.NewArray(array).WithType(cadence.NewVariableSizedArrayType(cadence.UInt64Type))
+}
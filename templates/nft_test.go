@@ -0,0 +1,308 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+var exampleNFT = templates.NonFungibleToken{
+	Contract: contracts.Contract{
+		Name:    "ExampleNFT",
+		Address: flow.HexToAddress("0x0b2a3299cc857e29"),
+	},
+	StoragePath: "exampleNFTCollection",
+	PublicPath:  "exampleNFTCollection",
+}
+
+func TestSetupNFTCollection(t *testing.T) {
+	account := flow.HexToAddress("01")
+
+	tx, err := templates.SetupNFTCollection(exampleNFT, account, flow.Mainnet)
+	require.NoError(t, err)
+
+	expected := `import NonFungibleToken from 0x1d7e57aa55817448
+import ExampleNFT from 0x0b2a3299cc857e29
+
+transaction {
+    prepare(signer: auth(BorrowValue, SaveValue, IssueStorageCapabilityController, PublishCapability, UnpublishCapability) &Account) {
+        if signer.storage.borrow<&ExampleNFT.Collection>(from: /storage/exampleNFTCollection) == nil {
+            let collection <- ExampleNFT.createEmptyCollection(nftType: Type<@ExampleNFT.NFT>())
+            signer.storage.save(<-collection, to: /storage/exampleNFTCollection)
+        }
+
+        signer.capabilities.unpublish(/public/exampleNFTCollection)
+        let collectionCap = signer.capabilities.storage.issue<&ExampleNFT.Collection>(/storage/exampleNFTCollection)
+        signer.capabilities.publish(collectionCap, at: /public/exampleNFTCollection)
+    }
+}
+`
+	assert.Equal(t, expected, string(tx.Script))
+	requireParses(t, tx.Script)
+	assert.Empty(t, tx.Arguments)
+	assert.Equal(t, []flow.Address{account}, tx.Authorizers)
+}
+
+func TestTransferNFT(t *testing.T) {
+	to := flow.HexToAddress("01")
+	from := flow.HexToAddress("02")
+
+	tx, err := templates.TransferNFT(exampleNFT, 42, to, from, flow.Testnet)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(tx.Script), "import NonFungibleToken from 0x631e88ae7f1d7c20\n")
+	assert.Contains(t, string(tx.Script), "receiver.deposit(token: <-self.collection.withdraw(withdrawID: id))")
+	requireParses(t, tx.Script)
+
+	recipient, err := tx.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewAddress(to), recipient)
+
+	id, err := tx.Argument(1)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewUInt64(42), id)
+
+	assert.Equal(t, []flow.Address{from}, tx.Authorizers)
+}
+
+func TestBatchTransferNFTs(t *testing.T) {
+	to := flow.HexToAddress("01")
+	from := flow.HexToAddress("02")
+
+	tx, err := templates.BatchTransferNFTs(exampleNFT, []uint64{1, 2, 3}, to, from, flow.Emulator)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(tx.Script), "import NonFungibleToken from 0xf8d6e0586b0a20c7\n")
+	assert.Contains(t, string(tx.Script), "for id in ids {")
+	requireParses(t, tx.Script)
+
+	ids, err := tx.Argument(1)
+	require.NoError(t, err)
+	decoded, err := templates.DecodeNFTIDs(ids)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, decoded)
+
+	assert.Equal(t, []flow.Address{from}, tx.Authorizers)
+}
+
+func TestBurnNFT(t *testing.T) {
+	owner := flow.HexToAddress("01")
+
+	tx, err := templates.BurnNFT(exampleNFT, 7, owner, flow.Mainnet)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(tx.Script), "import Burner from 0xf233dcee88fe0abe\n")
+	assert.Contains(t, string(tx.Script), "Burner.burn(<-collection.withdraw(withdrawID: id))")
+	requireParses(t, tx.Script)
+
+	id, err := tx.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewUInt64(7), id)
+	assert.Equal(t, []flow.Address{owner}, tx.Authorizers)
+}
+
+func TestNFTInvalidPaths(t *testing.T) {
+	nft := exampleNFT
+	nft.StoragePath = "/storage/exampleNFTCollection"
+
+	_, err := templates.SetupNFTCollection(nft, flow.HexToAddress("01"), flow.Mainnet)
+	assert.Error(t, err)
+
+	_, err = templates.GetNFTIDs(nft, flow.HexToAddress("01"), flow.Mainnet)
+	assert.Error(t, err)
+}
+
+func TestNFTScripts(t *testing.T) {
+	address := flow.HexToAddress("01")
+
+	t.Run("IDs", func(t *testing.T) {
+		script, err := templates.GetNFTIDs(exampleNFT, address, flow.Mainnet)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(script.Code), "access(all) fun main(address: Address): [UInt64] {")
+		requireParses(t, script.Code)
+		assert.Equal(t, []cadence.Value{cadence.NewAddress(address)}, script.Arguments)
+	})
+
+	views := map[string]func(templates.NonFungibleToken, flow.Address, uint64, flow.ChainID) (templates.Script, error){
+		"Display":   templates.GetNFTDisplay,
+		"Royalties": templates.GetNFTRoyalties,
+		"Editions":  templates.GetNFTEditions,
+	}
+
+	for view, build := range views {
+		t.Run(view, func(t *testing.T) {
+			script, err := build(exampleNFT, address, 42, flow.Mainnet)
+			require.NoError(t, err)
+
+			code := string(script.Code)
+			assert.Contains(t, code, "import MetadataViews from 0x1d7e57aa55817448\n")
+			assert.Contains(t, code, "): MetadataViews."+view+"? {")
+			assert.Contains(t, code, "return MetadataViews.get"+view+"(nft)")
+			requireParses(t, script.Code)
+			assert.Equal(t, []cadence.Value{cadence.NewAddress(address), cadence.NewUInt64(42)}, script.Arguments)
+		})
+	}
+}
+
+var metadataViewsLocation = common.NewAddressLocation(nil, common.Address{0x1d, 0x7e, 0x57, 0xaa, 0x55, 0x81, 0x74, 0x48}, "MetadataViews")
+
+func newView(identifier string, fields map[string]cadence.Value) cadence.Struct {
+	return newStruct(metadataViewsLocation, "MetadataViews."+identifier, fields)
+}
+
+// newStruct creates a struct value the way it is imported from a script result.
+func newStruct(location common.Location, qualifiedIdentifier string, fields map[string]cadence.Value) cadence.Struct {
+	values := make([]cadence.Value, 0, len(fields))
+	typeFields := make([]cadence.Field, 0, len(fields))
+	for name, value := range fields {
+		values = append(values, value)
+		typeFields = append(typeFields, cadence.Field{Identifier: name, Type: cadence.AnyStructType})
+	}
+
+	return cadence.NewStruct(values).WithType(
+		cadence.NewStructType(location, qualifiedIdentifier, typeFields, nil),
+	)
+}
+
+func TestDecodeNFTIDs(t *testing.T) {
+	ids, err := templates.DecodeNFTIDs(cadence.NewArray([]cadence.Value{cadence.NewUInt64(3), cadence.NewUInt64(1)}))
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{3, 1}, ids)
+
+	_, err = templates.DecodeNFTIDs(cadence.NewArray([]cadence.Value{cadence.String("1")}))
+	assert.Error(t, err)
+
+	_, err = templates.DecodeNFTIDs(cadence.String("1"))
+	assert.Error(t, err)
+}
+
+func TestDecodeNFTDisplay(t *testing.T) {
+	t.Run("HTTP thumbnail", func(t *testing.T) {
+		value := cadence.NewOptional(newView("Display", map[string]cadence.Value{
+			"name":        cadence.String("Example"),
+			"description": cadence.String("An example NFT"),
+			"thumbnail": newView("HTTPFile", map[string]cadence.Value{
+				"url": cadence.String("https://example.com/1.png"),
+			}),
+		}))
+
+		display, err := templates.DecodeNFTDisplay(value)
+		require.NoError(t, err)
+		assert.Equal(t, &templates.NFTDisplay{
+			Name:        "Example",
+			Description: "An example NFT",
+			Thumbnail:   "https://example.com/1.png",
+		}, display)
+	})
+
+	t.Run("IPFS thumbnail", func(t *testing.T) {
+		value := newView("Display", map[string]cadence.Value{
+			"name":        cadence.String("Example"),
+			"description": cadence.String(""),
+			"thumbnail": newView("IPFSFile", map[string]cadence.Value{
+				"cid":  cadence.String("bafy"),
+				"path": cadence.NewOptional(cadence.String("1.png")),
+			}),
+		})
+
+		display, err := templates.DecodeNFTDisplay(value)
+		require.NoError(t, err)
+		assert.Equal(t, "ipfs://bafy/1.png", display.Thumbnail)
+	})
+
+	t.Run("Not resolved", func(t *testing.T) {
+		display, err := templates.DecodeNFTDisplay(cadence.NewOptional(nil))
+		require.NoError(t, err)
+		assert.Nil(t, display)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := templates.DecodeNFTDisplay(newView("Display", map[string]cadence.Value{
+			"name": cadence.String("Example"),
+		}))
+		assert.Error(t, err)
+	})
+}
+
+func TestDecodeNFTRoyalties(t *testing.T) {
+	receiver := flow.HexToAddress("0x0b2a3299cc857e29")
+
+	value := cadence.NewOptional(newView("Royalties", map[string]cadence.Value{
+		"cutInfos": cadence.NewArray([]cadence.Value{
+			newView("Royalty", map[string]cadence.Value{
+				"receiver":    cadence.NewCapability(1, cadence.NewAddress(receiver), nil),
+				"cut":         cadence.UFix64(5_000000),
+				"description": cadence.String("Creator royalty"),
+			}),
+		}),
+	}))
+
+	royalties, err := templates.DecodeNFTRoyalties(value)
+	require.NoError(t, err)
+	assert.Equal(t, []templates.NFTRoyalty{{
+		Receiver:    receiver,
+		Cut:         cadence.UFix64(5_000000),
+		Description: "Creator royalty",
+	}}, royalties)
+
+	royalties, err = templates.DecodeNFTRoyalties(cadence.NewOptional(nil))
+	require.NoError(t, err)
+	assert.Empty(t, royalties)
+}
+
+func TestDecodeNFTEditions(t *testing.T) {
+	value := newView("Editions", map[string]cadence.Value{
+		"infoList": cadence.NewArray([]cadence.Value{
+			newView("Edition", map[string]cadence.Value{
+				"name":   cadence.NewOptional(cadence.String("Series 1")),
+				"number": cadence.NewUInt64(3),
+				"max":    cadence.NewOptional(cadence.NewUInt64(100)),
+			}),
+			newView("Edition", map[string]cadence.Value{
+				"name":   cadence.NewOptional(nil),
+				"number": cadence.NewUInt64(1),
+				"max":    cadence.NewOptional(nil),
+			}),
+		}),
+	})
+
+	editions, err := templates.DecodeNFTEditions(value)
+	require.NoError(t, err)
+	require.Len(t, editions, 2)
+
+	require.NotNil(t, editions[0].Name)
+	assert.Equal(t, "Series 1", *editions[0].Name)
+	assert.Equal(t, uint64(3), editions[0].Number)
+	require.NotNil(t, editions[0].Max)
+	assert.Equal(t, uint64(100), *editions[0].Max)
+
+	assert.Nil(t, editions[1].Name)
+	assert.Equal(t, uint64(1), editions[1].Number)
+	assert.Nil(t, editions[1].Max)
+}
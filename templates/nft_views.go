@@ -0,0 +1,243 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// NFTDisplay is the decoded MetadataViews.Display view of an NFT.
+type NFTDisplay struct {
+	Name        string
+	Description string
+	// Thumbnail is the URL of an HTTPFile thumbnail, or an ipfs:// URL for an IPFSFile thumbnail.
+	Thumbnail string
+}
+
+// NFTRoyalty is a single entry of the decoded MetadataViews.Royalties view of an NFT.
+type NFTRoyalty struct {
+	// Receiver is the address of the account the royalty receiver capability belongs to.
+	Receiver    flow.Address
+	Cut         cadence.UFix64
+	Description string
+}
+
+// NFTEdition is a single entry of the decoded MetadataViews.Editions view of an NFT.
+type NFTEdition struct {
+	Name   *string
+	Number uint64
+	Max    *uint64
+}
+
+// DecodeNFTIDs decodes the result of a GetNFTIDs script.
+func DecodeNFTIDs(value cadence.Value) ([]uint64, error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("cannot decode NFT IDs: expected array, got %s", typeName(value))
+	}
+
+	ids := make([]uint64, len(array.Values))
+	for i, element := range array.Values {
+		id, ok := element.(cadence.UInt64)
+		if !ok {
+			return nil, fmt.Errorf("cannot decode NFT IDs: expected UInt64 at index %d, got %s", i, typeName(element))
+		}
+		ids[i] = uint64(id)
+	}
+
+	return ids, nil
+}
+
+// DecodeNFTDisplay decodes the result of a GetNFTDisplay script.
+//
+// A nil display is returned if the NFT does not resolve the view.
+func DecodeNFTDisplay(value cadence.Value) (*NFTDisplay, error) {
+	fields, ok, err := viewFields(value, "Display")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	name, ok := fields["name"].(cadence.String)
+	if !ok {
+		return nil, fmt.Errorf("cannot decode Display: missing name")
+	}
+	description, ok := fields["description"].(cadence.String)
+	if !ok {
+		return nil, fmt.Errorf("cannot decode Display: missing description")
+	}
+	thumbnail, err := decodeFileURL(fields["thumbnail"])
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode Display: %w", err)
+	}
+
+	return &NFTDisplay{
+		Name:        string(name),
+		Description: string(description),
+		Thumbnail:   thumbnail,
+	}, nil
+}
+
+// DecodeNFTRoyalties decodes the result of a GetNFTRoyalties script.
+//
+// No royalties are returned if the NFT does not resolve the view.
+func DecodeNFTRoyalties(value cadence.Value) ([]NFTRoyalty, error) {
+	fields, ok, err := viewFields(value, "Royalties")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	cutInfos, ok := fields["cutInfos"].(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("cannot decode Royalties: missing cutInfos")
+	}
+
+	royalties := make([]NFTRoyalty, len(cutInfos.Values))
+	for i, element := range cutInfos.Values {
+		royalty, ok := element.(cadence.Struct)
+		if !ok {
+			return nil, fmt.Errorf("cannot decode Royalties: expected struct at index %d, got %s", i, typeName(element))
+		}
+		fields := cadence.FieldsMappedByName(royalty)
+
+		receiver, ok := fields["receiver"].(cadence.Capability)
+		if !ok {
+			return nil, fmt.Errorf("cannot decode Royalties: missing receiver at index %d", i)
+		}
+		cut, ok := fields["cut"].(cadence.UFix64)
+		if !ok {
+			return nil, fmt.Errorf("cannot decode Royalties: missing cut at index %d", i)
+		}
+		description, ok := fields["description"].(cadence.String)
+		if !ok {
+			return nil, fmt.Errorf("cannot decode Royalties: missing description at index %d", i)
+		}
+
+		royalties[i] = NFTRoyalty{
+			Receiver:    flow.BytesToAddress(receiver.Address.Bytes()),
+			Cut:         cut,
+			Description: string(description),
+		}
+	}
+
+	return royalties, nil
+}
+
+// DecodeNFTEditions decodes the result of a GetNFTEditions script.
+//
+// No editions are returned if the NFT does not resolve the view.
+func DecodeNFTEditions(value cadence.Value) ([]NFTEdition, error) {
+	fields, ok, err := viewFields(value, "Editions")
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	infoList, ok := fields["infoList"].(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("cannot decode Editions: missing infoList")
+	}
+
+	editions := make([]NFTEdition, len(infoList.Values))
+	for i, element := range infoList.Values {
+		edition, ok := element.(cadence.Struct)
+		if !ok {
+			return nil, fmt.Errorf("cannot decode Editions: expected struct at index %d, got %s", i, typeName(element))
+		}
+		fields := cadence.FieldsMappedByName(edition)
+
+		number, ok := fields["number"].(cadence.UInt64)
+		if !ok {
+			return nil, fmt.Errorf("cannot decode Editions: missing number at index %d", i)
+		}
+		editions[i].Number = uint64(number)
+
+		if name, ok := unwrapOptional(fields["name"]).(cadence.String); ok {
+			s := string(name)
+			editions[i].Name = &s
+		}
+		if max, ok := unwrapOptional(fields["max"]).(cadence.UInt64); ok {
+			m := uint64(max)
+			editions[i].Max = &m
+		}
+	}
+
+	return editions, nil
+}
+
+// viewFields unwraps an optional view struct and returns its fields by name.
+//
+// It reports false if the view is nil.
+func viewFields(value cadence.Value, view string) (map[string]cadence.Value, bool, error) {
+	value = unwrapOptional(value)
+	if value == nil {
+		return nil, false, nil
+	}
+
+	composite, ok := value.(cadence.Struct)
+	if !ok {
+		return nil, false, fmt.Errorf("cannot decode %s: expected struct, got %s", view, typeName(value))
+	}
+
+	return cadence.FieldsMappedByName(composite), true, nil
+}
+
+// decodeFileURL returns the URL of a MetadataViews.HTTPFile or MetadataViews.IPFSFile.
+func decodeFileURL(value cadence.Value) (string, error) {
+	file, ok := value.(cadence.Struct)
+	if !ok {
+		return "", fmt.Errorf("expected file struct, got %s", typeName(value))
+	}
+	fields := cadence.FieldsMappedByName(file)
+
+	if url, ok := fields["url"].(cadence.String); ok {
+		return string(url), nil
+	}
+
+	cid, ok := fields["cid"].(cadence.String)
+	if !ok {
+		return "", fmt.Errorf("unsupported file type %s", typeName(value))
+	}
+	url := "ipfs://" + string(cid)
+	if path, ok := unwrapOptional(fields["path"]).(cadence.String); ok {
+		url += "/" + string(path)
+	}
+
+	return url, nil
+}
+
+func unwrapOptional(value cadence.Value) cadence.Value {
+	for {
+		optional, ok := value.(cadence.Optional)
+		if !ok {
+			return value
+		}
+		value = optional.Value
+	}
+}
+
+// typeName describes a value in error messages. Composite values may lack a type, so the Go type is used.
+func typeName(value cadence.Value) string {
+	if value == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%T", value)
+}
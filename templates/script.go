@@ -0,0 +1,67 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
+	"github.com/onflow/flow-go-sdk/imports"
+)
+
+// A Script is a read-only Cadence script together with its arguments.
+//
+// The fields match the parameters of the ExecuteScript methods of the access clients.
+type Script struct {
+	Code      []byte
+	Arguments []cadence.Value
+}
+
+// coreContracts resolves the imports of the templates that use core contracts.
+var coreContracts = contracts.ImportRegistry()
+
+// resolveImports resolves the imports of a template for the given network, using the core contract
+// addresses and the given additional contracts.
+func resolveImports(network flow.ChainID, code string, additional ...contracts.Contract) ([]byte, error) {
+	registry := coreContracts
+	if len(additional) > 0 {
+		registry = contracts.ImportRegistry()
+		for _, contract := range additional {
+			registry.Register(network, contract.Name, contract.Address)
+		}
+	}
+
+	return imports.NewResolver(network, registry).Resolve([]byte(code))
+}
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// checkIdentifiers returns an error if a value spliced into a template is not a valid Cadence identifier.
+func checkIdentifiers(identifiers ...string) error {
+	for _, identifier := range identifiers {
+		if !identifierPattern.MatchString(identifier) {
+			return fmt.Errorf("invalid Cadence identifier %q", identifier)
+		}
+	}
+	return nil
+}
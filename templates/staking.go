@@ -0,0 +1,449 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// NodeRole is the role of a staked node in the Flow protocol.
+type NodeRole uint8
+
+// List of node roles, as defined by the FlowIDTableStaking contract.
+const (
+	NodeRoleCollection   NodeRole = 1
+	NodeRoleConsensus    NodeRole = 2
+	NodeRoleExecution    NodeRole = 3
+	NodeRoleVerification NodeRole = 4
+	NodeRoleAccess       NodeRole = 5
+)
+
+// String returns the name of the role.
+func (r NodeRole) String() string {
+	switch r {
+	case NodeRoleCollection:
+		return "collection"
+	case NodeRoleConsensus:
+		return "consensus"
+	case NodeRoleExecution:
+		return "execution"
+	case NodeRoleVerification:
+		return "verification"
+	case NodeRoleAccess:
+		return "access"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(r))
+	}
+}
+
+// A NodeRegistration holds the parameters of a new staked node.
+type NodeRegistration struct {
+	// ID is the hex-encoded node ID.
+	ID   string
+	Role NodeRole
+	// NetworkingAddress is the host and port of the node, for example "node.example.com:3569".
+	NetworkingAddress string
+	// NetworkingKey, StakingKey and StakingKeyPoP are hex-encoded.
+	NetworkingKey string
+	StakingKey    string
+	StakingKeyPoP string
+	// Amount is the decimal UFix64 amount of FLOW to stake, for example "135000.0".
+	Amount string
+	// MachineAccountKeys are added to the machine account that the staking collection creates
+	// for collection and consensus nodes. They are ignored for other roles.
+	MachineAccountKeys []*flow.AccountKey
+}
+
+// NodeInfo is the decoded FlowIDTableStaking.NodeInfo of a staked node.
+type NodeInfo struct {
+	ID                       string         `cadence:"id"`
+	Role                     NodeRole       `cadence:"role"`
+	NetworkingAddress        string         `cadence:"networkingAddress"`
+	NetworkingKey            string         `cadence:"networkingKey"`
+	StakingKey               string         `cadence:"stakingKey"`
+	TokensStaked             cadence.UFix64 `cadence:"tokensStaked"`
+	TokensCommitted          cadence.UFix64 `cadence:"tokensCommitted"`
+	TokensUnstaking          cadence.UFix64 `cadence:"tokensUnstaking"`
+	TokensUnstaked           cadence.UFix64 `cadence:"tokensUnstaked"`
+	TokensRewarded           cadence.UFix64 `cadence:"tokensRewarded"`
+	Delegators               []uint32       `cadence:"delegators"`
+	DelegatorIDCounter       uint32         `cadence:"delegatorIDCounter"`
+	TokensRequestedToUnstake cadence.UFix64 `cadence:"tokensRequestedToUnstake"`
+	InitialWeight            uint64         `cadence:"initialWeight"`
+}
+
+// DelegatorInfo is the decoded FlowIDTableStaking.DelegatorInfo of a delegator.
+type DelegatorInfo struct {
+	ID                       uint32         `cadence:"id"`
+	NodeID                   string         `cadence:"nodeID"`
+	TokensCommitted          cadence.UFix64 `cadence:"tokensCommitted"`
+	TokensStaked             cadence.UFix64 `cadence:"tokensStaked"`
+	TokensUnstaking          cadence.UFix64 `cadence:"tokensUnstaking"`
+	TokensRewarded           cadence.UFix64 `cadence:"tokensRewarded"`
+	TokensUnstaked           cadence.UFix64 `cadence:"tokensUnstaked"`
+	TokensRequestedToUnstake cadence.UFix64 `cadence:"tokensRequestedToUnstake"`
+}
+
+const borrowStakingCollection = `        self.stakingCollectionRef = account.storage.borrow<auth(FlowStakingCollection.CollectionOwner) &FlowStakingCollection.StakingCollection>(from: FlowStakingCollection.StakingCollectionStoragePath)
+            ?? panic("Could not borrow a reference to the signer's staking collection")
+`
+
+const registerNodeTemplate = `import Crypto
+import "FlowStakingCollection"
+
+transaction(
+    id: String,
+    role: UInt8,
+    networkingAddress: String,
+    networkingKey: String,
+    stakingKey: String,
+    stakingKeyPoP: String,
+    amount: UFix64,
+    machineAccountKeys: [Crypto.KeyListEntry]
+) {
+    let stakingCollectionRef: auth(FlowStakingCollection.CollectionOwner) &FlowStakingCollection.StakingCollection
+
+    prepare(account: auth(BorrowValue) &Account) {
+` + borrowStakingCollection + `
+        if let machineAccount = self.stakingCollectionRef.registerNode(
+            id: id,
+            role: role,
+            networkingAddress: networkingAddress,
+            networkingKey: networkingKey,
+            stakingKey: stakingKey,
+            stakingKeyPoP: stakingKeyPoP,
+            amount: amount,
+            payer: account
+        ) {
+            if machineAccountKeys.length == 0 {
+                panic("Collection and consensus nodes require at least one machine account key")
+            }
+
+            for key in machineAccountKeys {
+                machineAccount.keys.add(publicKey: key.publicKey, hashAlgorithm: key.hashAlgorithm, weight: key.weight)
+            }
+        }
+    }
+}
+`
+
+const registerDelegatorTemplate = `import "FlowStakingCollection"
+
+transaction(nodeID: String, amount: UFix64) {
+    let stakingCollectionRef: auth(FlowStakingCollection.CollectionOwner) &FlowStakingCollection.StakingCollection
+
+    prepare(account: auth(BorrowValue) &Account) {
+` + borrowStakingCollection + `    }
+
+    execute {
+        self.stakingCollectionRef.registerDelegator(nodeID: nodeID, amount: amount)
+    }
+}
+`
+
+// stakeOperationTemplate returns a transaction calling a staking collection function that
+// applies to either a node or one of its delegators.
+func stakeOperationTemplate(function string) string {
+	return `import "FlowStakingCollection"
+
+transaction(nodeID: String, delegatorID: UInt32?, amount: UFix64) {
+    let stakingCollectionRef: auth(FlowStakingCollection.CollectionOwner) &FlowStakingCollection.StakingCollection
+
+    prepare(account: auth(BorrowValue) &Account) {
+` + borrowStakingCollection + `    }
+
+    execute {
+        self.stakingCollectionRef.` + function + `(nodeID: nodeID, delegatorID: delegatorID, amount: amount)
+    }
+}
+`
+}
+
+const getNodeInfoTemplate = `import "FlowIDTableStaking"
+
+access(all) fun main(nodeID: String): FlowIDTableStaking.NodeInfo {
+    return FlowIDTableStaking.NodeInfo(nodeID: nodeID)
+}
+`
+
+const getDelegatorInfoTemplate = `import "FlowIDTableStaking"
+
+access(all) fun main(nodeID: String, delegatorID: UInt32): FlowIDTableStaking.DelegatorInfo {
+    return FlowIDTableStaking.DelegatorInfo(nodeID: nodeID, delegatorID: delegatorID)
+}
+`
+
+const getAllNodeInfoTemplate = `import "FlowIDTableStaking"
+import "FlowStakingCollection"
+
+access(all) fun main(address: Address): [FlowIDTableStaking.NodeInfo] {
+    return FlowStakingCollection.getAllNodeInfo(address: address)
+}
+`
+
+const getAllDelegatorInfoTemplate = `import "FlowIDTableStaking"
+import "FlowStakingCollection"
+
+access(all) fun main(address: Address): [FlowIDTableStaking.DelegatorInfo] {
+    return FlowStakingCollection.getAllDelegatorInfo(address: address)
+}
+`
+
+// RegisterNode generates a transaction that registers a new node in the signer's staking collection
+// and stakes its initial tokens.
+//
+// The staker must have a staking collection and is added as the transaction authorizer.
+// The staking collection contract is only deployed on Mainnet and Testnet.
+func RegisterNode(node NodeRegistration, staker flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	code, err := resolveImports(network, registerNodeTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create RegisterNode transaction: %w", err)
+	}
+
+	amount, err := cadence.NewUFix64(node.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create RegisterNode transaction: invalid amount: %w", err)
+	}
+
+	keys := make([]cadence.Value, len(node.MachineAccountKeys))
+	for i, key := range node.MachineAccountKeys {
+		keys[i], err = AccountKeyToCadenceCryptoKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create RegisterNode transaction: %w", err)
+		}
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.String(node.ID))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewUInt8(uint8(node.Role)))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.String(node.NetworkingAddress))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.String(node.NetworkingKey))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.String(node.StakingKey))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.String(node.StakingKeyPoP))).
+		AddRawArgument(flow.MustEncodeArgument(amount)).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewArray(keys))).
+		AddAuthorizer(staker), nil
+}
+
+// RegisterDelegator generates a transaction that registers a new delegator to a node in the signer's
+// staking collection and delegates its initial tokens.
+func RegisterDelegator(nodeID string, amount string, staker flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	code, err := resolveImports(network, registerDelegatorTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create RegisterDelegator transaction: %w", err)
+	}
+
+	value, err := cadence.NewUFix64(amount)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create RegisterDelegator transaction: invalid amount: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.String(nodeID))).
+		AddRawArgument(flow.MustEncodeArgument(value)).
+		AddAuthorizer(staker), nil
+}
+
+// StakeNewTokens generates a transaction that stakes new tokens from the signer's staking collection.
+//
+// The tokens are staked for the node itself if delegatorID is nil, and for the given delegator otherwise.
+func StakeNewTokens(nodeID string, delegatorID *uint32, amount string, staker flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	return stakeOperation("StakeNewTokens", "stakeNewTokens", nodeID, delegatorID, amount, staker, network)
+}
+
+// DelegateNewTokens generates a transaction that delegates new tokens to a node through an existing delegator.
+//
+// Use RegisterDelegator to create the delegator first.
+func DelegateNewTokens(nodeID string, delegatorID uint32, amount string, staker flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	return stakeOperation("DelegateNewTokens", "stakeNewTokens", nodeID, &delegatorID, amount, staker, network)
+}
+
+// RequestUnstaking generates a transaction that requests staked tokens to be unstaked
+// at the end of the epoch.
+//
+// The tokens are unstaked for the node itself if delegatorID is nil, and for the given delegator otherwise.
+func RequestUnstaking(nodeID string, delegatorID *uint32, amount string, staker flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	return stakeOperation("RequestUnstaking", "requestUnstaking", nodeID, delegatorID, amount, staker, network)
+}
+
+// WithdrawRewardedTokens generates a transaction that withdraws rewarded tokens into the signer's staking collection.
+//
+// The rewards are withdrawn for the node itself if delegatorID is nil, and for the given delegator otherwise.
+func WithdrawRewardedTokens(nodeID string, delegatorID *uint32, amount string, staker flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	return stakeOperation("WithdrawRewardedTokens", "withdrawRewardedTokens", nodeID, delegatorID, amount, staker, network)
+}
+
+func stakeOperation(
+	name string,
+	function string,
+	nodeID string,
+	delegatorID *uint32,
+	amount string,
+	staker flow.Address,
+	network flow.ChainID,
+) (*flow.Transaction, error) {
+	code, err := resolveImports(network, stakeOperationTemplate(function))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create %s transaction: %w", name, err)
+	}
+
+	value, err := cadence.NewUFix64(amount)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create %s transaction: invalid amount: %w", name, err)
+	}
+
+	delegator := cadence.NewOptional(nil)
+	if delegatorID != nil {
+		delegator = cadence.NewOptional(cadence.NewUInt32(*delegatorID))
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.String(nodeID))).
+		AddRawArgument(flow.MustEncodeArgument(delegator)).
+		AddRawArgument(flow.MustEncodeArgument(value)).
+		AddAuthorizer(staker), nil
+}
+
+// GetNodeInfo generates a script that returns the staking information of a node.
+//
+// Decode the result with DecodeNodeInfo.
+func GetNodeInfo(nodeID string, network flow.ChainID) (Script, error) {
+	code, err := resolveImports(network, getNodeInfoTemplate)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create GetNodeInfo script: %w", err)
+	}
+
+	return Script{
+		Code:      code,
+		Arguments: []cadence.Value{cadence.String(nodeID)},
+	}, nil
+}
+
+// GetDelegatorInfo generates a script that returns the staking information of a delegator.
+//
+// Decode the result with DecodeDelegatorInfo.
+func GetDelegatorInfo(nodeID string, delegatorID uint32, network flow.ChainID) (Script, error) {
+	code, err := resolveImports(network, getDelegatorInfoTemplate)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create GetDelegatorInfo script: %w", err)
+	}
+
+	return Script{
+		Code:      code,
+		Arguments: []cadence.Value{cadence.String(nodeID), cadence.NewUInt32(delegatorID)},
+	}, nil
+}
+
+// GetAllNodeInfo generates a script that returns the staking information of all nodes
+// in an account's staking collection.
+//
+// Decode the result with DecodeAllNodeInfo.
+func GetAllNodeInfo(address flow.Address, network flow.ChainID) (Script, error) {
+	code, err := resolveImports(network, getAllNodeInfoTemplate)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create GetAllNodeInfo script: %w", err)
+	}
+
+	return Script{
+		Code:      code,
+		Arguments: []cadence.Value{cadence.NewAddress(address)},
+	}, nil
+}
+
+// GetAllDelegatorInfo generates a script that returns the staking information of all delegators
+// in an account's staking collection.
+//
+// Decode the result with DecodeAllDelegatorInfo.
+func GetAllDelegatorInfo(address flow.Address, network flow.ChainID) (Script, error) {
+	code, err := resolveImports(network, getAllDelegatorInfoTemplate)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create GetAllDelegatorInfo script: %w", err)
+	}
+
+	return Script{
+		Code:      code,
+		Arguments: []cadence.Value{cadence.NewAddress(address)},
+	}, nil
+}
+
+// DecodeNodeInfo decodes the result of a GetNodeInfo script.
+func DecodeNodeInfo(value cadence.Value) (*NodeInfo, error) {
+	var info NodeInfo
+	if err := decodeStruct(value, &info); err != nil {
+		return nil, fmt.Errorf("cannot decode NodeInfo: %w", err)
+	}
+	return &info, nil
+}
+
+// DecodeDelegatorInfo decodes the result of a GetDelegatorInfo script.
+func DecodeDelegatorInfo(value cadence.Value) (*DelegatorInfo, error) {
+	var info DelegatorInfo
+	if err := decodeStruct(value, &info); err != nil {
+		return nil, fmt.Errorf("cannot decode DelegatorInfo: %w", err)
+	}
+	return &info, nil
+}
+
+// DecodeAllNodeInfo decodes the result of a GetAllNodeInfo script.
+func DecodeAllNodeInfo(value cadence.Value) ([]NodeInfo, error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("cannot decode NodeInfo list: expected array, got %s", typeName(value))
+	}
+
+	infos := make([]NodeInfo, len(array.Values))
+	for i, element := range array.Values {
+		if err := decodeStruct(element, &infos[i]); err != nil {
+			return nil, fmt.Errorf("cannot decode NodeInfo at index %d: %w", i, err)
+		}
+	}
+	return infos, nil
+}
+
+// DecodeAllDelegatorInfo decodes the result of a GetAllDelegatorInfo script.
+func DecodeAllDelegatorInfo(value cadence.Value) ([]DelegatorInfo, error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("cannot decode DelegatorInfo list: expected array, got %s", typeName(value))
+	}
+
+	infos := make([]DelegatorInfo, len(array.Values))
+	for i, element := range array.Values {
+		if err := decodeStruct(element, &infos[i]); err != nil {
+			return nil, fmt.Errorf("cannot decode DelegatorInfo at index %d: %w", i, err)
+		}
+	}
+	return infos, nil
+}
+
+// decodeStruct decodes a Cadence struct into a Go struct with cadence field tags.
+func decodeStruct(value cadence.Value, target any) error {
+	composite, ok := value.(cadence.Struct)
+	if !ok {
+		return fmt.Errorf("expected struct, got %s", typeName(value))
+	}
+	return cadence.DecodeFields(composite, target)
+}
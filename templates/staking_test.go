@@ -0,0 +1,265 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+const nodeID = "f3f4b0a4f5e5b8e6e0e27a1f0e3c2d6b6d1a0c9f8e7d6c5b4a39281706f5e4d3"
+
+func TestRegisterNode(t *testing.T) {
+	staker := flow.HexToAddress("01")
+	key := test.AccountKeyGenerator().New()
+
+	tx, err := templates.RegisterNode(templates.NodeRegistration{
+		ID:                 nodeID,
+		Role:               templates.NodeRoleCollection,
+		NetworkingAddress:  "collection.example.com:3569",
+		NetworkingKey:      "aa",
+		StakingKey:         "bb",
+		StakingKeyPoP:      "cc",
+		Amount:             "250000.0",
+		MachineAccountKeys: []*flow.AccountKey{key},
+	}, staker, flow.Mainnet)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(tx.Script), "import Crypto\nimport FlowStakingCollection from 0x8d0e87b65159ae63\n")
+	requireParses(t, tx.Script)
+	require.Len(t, tx.Arguments, 8)
+
+	role, err := tx.Argument(1)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewUInt8(1), role)
+
+	amount, err := tx.Argument(6)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.UFix64(250000_00000000), amount)
+
+	keys, err := tx.Argument(7)
+	require.NoError(t, err)
+	assert.Len(t, keys.(cadence.Array).Values, 1)
+
+	assert.Equal(t, []flow.Address{staker}, tx.Authorizers)
+
+	t.Run("Invalid amount", func(t *testing.T) {
+		_, err := templates.RegisterNode(templates.NodeRegistration{ID: nodeID, Amount: "1"}, staker, flow.Mainnet)
+		assert.Error(t, err)
+	})
+}
+
+func TestRegisterDelegator(t *testing.T) {
+	staker := flow.HexToAddress("01")
+
+	tx, err := templates.RegisterDelegator(nodeID, "50.0", staker, flow.Testnet)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(tx.Script), "import FlowStakingCollection from 0x95e019a17d0e23d7\n")
+	assert.Contains(t, string(tx.Script), "self.stakingCollectionRef.registerDelegator(nodeID: nodeID, amount: amount)")
+	requireParses(t, tx.Script)
+
+	id, err := tx.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.String(nodeID), id)
+}
+
+func TestStakeOperations(t *testing.T) {
+	staker := flow.HexToAddress("01")
+	delegatorID := uint32(4)
+
+	tests := []struct {
+		name        string
+		build       func() (*flow.Transaction, error)
+		function    string
+		delegatorID cadence.Value
+	}{
+		{
+			name: "StakeNewTokens",
+			build: func() (*flow.Transaction, error) {
+				return templates.StakeNewTokens(nodeID, nil, "10.0", staker, flow.Mainnet)
+			},
+			function:    "stakeNewTokens",
+			delegatorID: cadence.NewOptional(nil),
+		},
+		{
+			name: "DelegateNewTokens",
+			build: func() (*flow.Transaction, error) {
+				return templates.DelegateNewTokens(nodeID, delegatorID, "10.0", staker, flow.Mainnet)
+			},
+			function:    "stakeNewTokens",
+			delegatorID: cadence.NewOptional(cadence.NewUInt32(4)),
+		},
+		{
+			name: "RequestUnstaking",
+			build: func() (*flow.Transaction, error) {
+				return templates.RequestUnstaking(nodeID, &delegatorID, "10.0", staker, flow.Mainnet)
+			},
+			function:    "requestUnstaking",
+			delegatorID: cadence.NewOptional(cadence.NewUInt32(4)),
+		},
+		{
+			name: "WithdrawRewardedTokens",
+			build: func() (*flow.Transaction, error) {
+				return templates.WithdrawRewardedTokens(nodeID, nil, "10.0", staker, flow.Mainnet)
+			},
+			function:    "withdrawRewardedTokens",
+			delegatorID: cadence.NewOptional(nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := tt.build()
+			require.NoError(t, err)
+
+			assert.Contains(t, string(tx.Script),
+				"self.stakingCollectionRef."+tt.function+"(nodeID: nodeID, delegatorID: delegatorID, amount: amount)")
+			requireParses(t, tx.Script)
+
+			delegator, err := tx.Argument(1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.delegatorID, delegator)
+
+			amount, err := tx.Argument(2)
+			require.NoError(t, err)
+			assert.Equal(t, cadence.UFix64(10_00000000), amount)
+
+			assert.Equal(t, []flow.Address{staker}, tx.Authorizers)
+		})
+	}
+
+	t.Run("Not deployed", func(t *testing.T) {
+		_, err := templates.StakeNewTokens(nodeID, nil, "10.0", staker, flow.Emulator)
+		assert.Error(t, err)
+	})
+}
+
+func TestStakingScripts(t *testing.T) {
+	script, err := templates.GetNodeInfo(nodeID, flow.Emulator)
+	require.NoError(t, err)
+	assert.Contains(t, string(script.Code), "import FlowIDTableStaking from 0xf8d6e0586b0a20c7\n")
+	requireParses(t, script.Code)
+	assert.Equal(t, []cadence.Value{cadence.String(nodeID)}, script.Arguments)
+
+	script, err = templates.GetDelegatorInfo(nodeID, 2, flow.Mainnet)
+	require.NoError(t, err)
+	assert.Contains(t, string(script.Code), "import FlowIDTableStaking from 0x8624b52f9ddcd04a\n")
+	requireParses(t, script.Code)
+	assert.Equal(t, []cadence.Value{cadence.String(nodeID), cadence.NewUInt32(2)}, script.Arguments)
+
+	address := flow.HexToAddress("01")
+
+	script, err = templates.GetAllNodeInfo(address, flow.Mainnet)
+	require.NoError(t, err)
+	assert.Contains(t, string(script.Code), "return FlowStakingCollection.getAllNodeInfo(address: address)")
+	requireParses(t, script.Code)
+
+	script, err = templates.GetAllDelegatorInfo(address, flow.Mainnet)
+	require.NoError(t, err)
+	assert.Contains(t, string(script.Code), "return FlowStakingCollection.getAllDelegatorInfo(address: address)")
+	requireParses(t, script.Code)
+}
+
+func stakingLocation() common.Location {
+	core := contracts.MustForChain(flow.Mainnet)
+	return common.NewAddressLocation(nil, common.Address(core.FlowIDTableStaking.Address), contracts.FlowIDTableStaking)
+}
+
+func TestDecodeNodeInfo(t *testing.T) {
+	value := newStruct(stakingLocation(), "FlowIDTableStaking.NodeInfo", map[string]cadence.Value{
+		"id":                       cadence.String(nodeID),
+		"role":                     cadence.NewUInt8(2),
+		"networkingAddress":        cadence.String("consensus.example.com:3569"),
+		"networkingKey":            cadence.String("aa"),
+		"stakingKey":               cadence.String("bb"),
+		"tokensStaked":             cadence.UFix64(500000_00000000),
+		"tokensCommitted":          cadence.UFix64(0),
+		"tokensUnstaking":          cadence.UFix64(0),
+		"tokensUnstaked":           cadence.UFix64(0),
+		"tokensRewarded":           cadence.UFix64(12_50000000),
+		"delegators":               cadence.NewArray([]cadence.Value{cadence.NewUInt32(1), cadence.NewUInt32(2)}),
+		"delegatorIDCounter":       cadence.NewUInt32(2),
+		"tokensRequestedToUnstake": cadence.UFix64(0),
+		"initialWeight":            cadence.NewUInt64(100),
+	})
+
+	info, err := templates.DecodeNodeInfo(value)
+	require.NoError(t, err)
+	assert.Equal(t, &templates.NodeInfo{
+		ID:                 nodeID,
+		Role:               templates.NodeRoleConsensus,
+		NetworkingAddress:  "consensus.example.com:3569",
+		NetworkingKey:      "aa",
+		StakingKey:         "bb",
+		TokensStaked:       cadence.UFix64(500000_00000000),
+		TokensRewarded:     cadence.UFix64(12_50000000),
+		Delegators:         []uint32{1, 2},
+		DelegatorIDCounter: 2,
+		InitialWeight:      100,
+	}, info)
+	assert.Equal(t, "consensus", info.Role.String())
+
+	infos, err := templates.DecodeAllNodeInfo(cadence.NewArray([]cadence.Value{value}))
+	require.NoError(t, err)
+	assert.Equal(t, []templates.NodeInfo{*info}, infos)
+
+	_, err = templates.DecodeNodeInfo(newStruct(stakingLocation(), "FlowIDTableStaking.NodeInfo", map[string]cadence.Value{
+		"id": cadence.String(nodeID),
+	}))
+	assert.Error(t, err)
+}
+
+func TestDecodeDelegatorInfo(t *testing.T) {
+	value := newStruct(stakingLocation(), "FlowIDTableStaking.DelegatorInfo", map[string]cadence.Value{
+		"id":                       cadence.NewUInt32(3),
+		"nodeID":                   cadence.String(nodeID),
+		"tokensCommitted":          cadence.UFix64(1_00000000),
+		"tokensStaked":             cadence.UFix64(50_00000000),
+		"tokensUnstaking":          cadence.UFix64(0),
+		"tokensRewarded":           cadence.UFix64(0),
+		"tokensUnstaked":           cadence.UFix64(0),
+		"tokensRequestedToUnstake": cadence.UFix64(0),
+	})
+
+	info, err := templates.DecodeDelegatorInfo(value)
+	require.NoError(t, err)
+	assert.Equal(t, &templates.DelegatorInfo{
+		ID:              3,
+		NodeID:          nodeID,
+		TokensCommitted: cadence.UFix64(1_00000000),
+		TokensStaked:    cadence.UFix64(50_00000000),
+	}, info)
+
+	infos, err := templates.DecodeAllDelegatorInfo(cadence.NewArray([]cadence.Value{value}))
+	require.NoError(t, err)
+	assert.Equal(t, []templates.DelegatorInfo{*info}, infos)
+
+	_, err = templates.DecodeAllDelegatorInfo(value)
+	assert.Error(t, err)
+}
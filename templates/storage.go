@@ -0,0 +1,123 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+const getStorageInfoTemplate = `import "FlowStorageFees"
+
+access(all) struct StorageInfo {
+    access(all) let address: Address
+    access(all) let used: UInt64
+    access(all) let capacity: UInt64
+    access(all) let balance: UFix64
+    access(all) let megabytesPerFLOW: UFix64
+    access(all) let minimumReservation: UFix64
+
+    init(address: Address) {
+        let account = getAccount(address)
+        self.address = address
+        self.used = account.storage.used
+        self.capacity = account.storage.capacity
+        self.balance = account.balance
+        self.megabytesPerFLOW = FlowStorageFees.storageMegaBytesPerReservedFLOW
+        self.minimumReservation = FlowStorageFees.minimumStorageReservation
+    }
+}
+
+access(all) fun main(addresses: [Address]): [StorageInfo] {
+    let infos: [StorageInfo] = []
+    for address in addresses {
+        infos.append(StorageInfo(address: address))
+    }
+    return infos
+}
+`
+
+const topUpStorageTemplate = `import "FungibleToken"
+import "FlowToken"
+import "FlowStorageFees"
+
+transaction(address: Address, capacity: UInt64) {
+    prepare(payer: auth(BorrowValue) &Account) {
+        let megabytes = FlowStorageFees.convertUInt64StorageBytesToUFix64Megabytes(capacity)
+        var required = FlowStorageFees.storageCapacityToFlow(megabytes)
+        if required < FlowStorageFees.minimumStorageReservation {
+            required = FlowStorageFees.minimumStorageReservation
+        }
+
+        let balance = getAccount(address).balance
+        if balance >= required {
+            return
+        }
+
+        let vault = payer.storage.borrow<auth(FungibleToken.Withdraw) &FlowToken.Vault>(from: /storage/flowTokenVault)
+            ?? panic("Could not borrow a reference to the payer's vault")
+        let receiver = getAccount(address).capabilities.borrow<&{FungibleToken.Receiver}>(/public/flowTokenReceiver)
+            ?? panic("Could not borrow a receiver reference to the account's vault")
+
+        receiver.deposit(from: <-vault.withdraw(amount: required - balance))
+    }
+}
+`
+
+// GetStorageInfo generates a script that returns the storage usage, capacity and FLOW balance of
+// each of the given accounts, together with the storage fee parameters of the network.
+//
+// The storage package decodes and batches these scripts.
+func GetStorageInfo(addresses []flow.Address, network flow.ChainID) (Script, error) {
+	code, err := resolveImports(network, getStorageInfoTemplate)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create GetStorageInfo script: %w", err)
+	}
+
+	values := make([]cadence.Value, len(addresses))
+	for i, address := range addresses {
+		values[i] = cadence.NewAddress(address)
+	}
+
+	return Script{
+		Code:      code,
+		Arguments: []cadence.Value{cadence.NewArray(values)},
+	}, nil
+}
+
+// TopUpStorage generates a transaction that deposits enough FLOW from the payer into an account
+// for its storage capacity to reach the given number of bytes.
+//
+// The deposit is computed when the transaction executes, so nothing is transferred if the account
+// already has the capacity. The payer is added as the transaction authorizer.
+func TopUpStorage(address flow.Address, capacity uint64, payer flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	code, err := resolveImports(network, topUpStorageTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create TopUpStorage transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewAddress(address))).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewUInt64(capacity))).
+		AddAuthorizer(payer), nil
+}
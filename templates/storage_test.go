@@ -0,0 +1,65 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+func TestGetStorageInfo(t *testing.T) {
+	addresses := []flow.Address{flow.HexToAddress("01"), flow.HexToAddress("02")}
+
+	script, err := templates.GetStorageInfo(addresses, flow.Mainnet)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(script.Code), "import FlowStorageFees from 0xe467b9dd11fa00df\n")
+	requireParses(t, script.Code)
+	assert.Equal(t, []cadence.Value{
+		cadence.NewArray([]cadence.Value{cadence.NewAddress(addresses[0]), cadence.NewAddress(addresses[1])}),
+	}, script.Arguments)
+}
+
+func TestTopUpStorage(t *testing.T) {
+	address := flow.HexToAddress("01")
+	payer := flow.HexToAddress("02")
+
+	tx, err := templates.TopUpStorage(address, 10_000_000, payer, flow.Testnet)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(tx.Script), "import FlowStorageFees from 0x8c5303eaa26202d6\n")
+	assert.Contains(t, string(tx.Script), "receiver.deposit(from: <-vault.withdraw(amount: required - balance))")
+	requireParses(t, tx.Script)
+
+	target, err := tx.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewAddress(address), target)
+
+	capacity, err := tx.Argument(1)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewUInt64(10_000_000), capacity)
+
+	assert.Equal(t, []flow.Address{payer}, tx.Authorizers)
+}
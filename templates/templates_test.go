@@ -0,0 +1,155 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+// TestCanonicalArguments checks that every transaction template encodes its arguments canonically,
+// so the transactions it generates have stable IDs.
+func TestCanonicalArguments(t *testing.T) {
+	first := flow.HexToAddress("01")
+	second := flow.HexToAddress("02")
+	key := test.AccountKeyGenerator().New()
+	delegatorID := uint32(1)
+
+	token, err := templates.FlowToken(flow.Testnet)
+	require.NoError(t, err)
+	custody, err := templates.HybridCustodyForChain(flow.Testnet)
+	require.NoError(t, err)
+
+	contract := templates.Contract{Name: "Example", Source: "access(all) contract Example {}"}
+
+	builders := map[string]func() (*flow.Transaction, error){
+		"CreateAccount": func() (*flow.Transaction, error) {
+			return templates.CreateAccount([]*flow.AccountKey{key}, []templates.Contract{contract}, first)
+		},
+		"CreateAccountAndFund": func() (*flow.Transaction, error) {
+			return templates.CreateAccountAndFund([]*flow.AccountKey{key}, nil, first, "1.0", flow.Testnet)
+		},
+		"UpdateAccountContract": func() (*flow.Transaction, error) {
+			return templates.UpdateAccountContract(first, contract), nil
+		},
+		"AddAccountContract": func() (*flow.Transaction, error) {
+			return templates.AddAccountContract(first, contract), nil
+		},
+		"AddAccountKey": func() (*flow.Transaction, error) {
+			return templates.AddAccountKey(first, key)
+		},
+		"RemoveAccountKey": func() (*flow.Transaction, error) {
+			return templates.RemoveAccountKey(first, 1), nil
+		},
+		"RotateAccountKeys": func() (*flow.Transaction, error) {
+			return templates.RotateAccountKeys(first, []*flow.AccountKey{key}, []uint32{0})
+		},
+		"RemoveAccountContract": func() (*flow.Transaction, error) {
+			return templates.RemoveAccountContract(first, contract.Name), nil
+		},
+		"TransferFlow": func() (*flow.Transaction, error) {
+			return templates.TransferFlow("1.0", first, second, flow.Testnet)
+		},
+		"TransferFungibleToken": func() (*flow.Transaction, error) {
+			return templates.TransferFungibleToken(token, "1.0", first, second, flow.Testnet)
+		},
+		"TransferNFT": func() (*flow.Transaction, error) {
+			return templates.TransferNFT(exampleNFT, 1, first, second, flow.Testnet)
+		},
+		"BatchTransferNFTs": func() (*flow.Transaction, error) {
+			return templates.BatchTransferNFTs(exampleNFT, []uint64{1, 2}, first, second, flow.Testnet)
+		},
+		"BurnNFT": func() (*flow.Transaction, error) {
+			return templates.BurnNFT(exampleNFT, 1, first, flow.Testnet)
+		},
+		"RegisterNode": func() (*flow.Transaction, error) {
+			return templates.RegisterNode(templates.NodeRegistration{
+				ID:                 nodeID,
+				Role:               templates.NodeRoleCollection,
+				NetworkingAddress:  "collection.example.com:3569",
+				NetworkingKey:      "aa",
+				StakingKey:         "bb",
+				StakingKeyPoP:      "cc",
+				Amount:             "1.0",
+				MachineAccountKeys: []*flow.AccountKey{key},
+			}, first, flow.Testnet)
+		},
+		"RegisterDelegator": func() (*flow.Transaction, error) {
+			return templates.RegisterDelegator(nodeID, "1.0", first, flow.Testnet)
+		},
+		"StakeNewTokens": func() (*flow.Transaction, error) {
+			return templates.StakeNewTokens(nodeID, &delegatorID, "1.0", first, flow.Testnet)
+		},
+		"DelegateNewTokens": func() (*flow.Transaction, error) {
+			return templates.DelegateNewTokens(nodeID, delegatorID, "1.0", first, flow.Testnet)
+		},
+		"RequestUnstaking": func() (*flow.Transaction, error) {
+			return templates.RequestUnstaking(nodeID, nil, "1.0", first, flow.Testnet)
+		},
+		"WithdrawRewardedTokens": func() (*flow.Transaction, error) {
+			return templates.WithdrawRewardedTokens(nodeID, nil, "1.0", first, flow.Testnet)
+		},
+		"CreateChildAccount": func() (*flow.Transaction, error) {
+			return templates.CreateChildAccount(custody, key, "1.0", first, flow.Testnet)
+		},
+		"PublishToParent": func() (*flow.Transaction, error) {
+			return templates.PublishToParent(custody, first, second, second, first, flow.Testnet)
+		},
+		"ClaimChildAccount": func() (*flow.Transaction, error) {
+			return templates.ClaimChildAccount(custody, second, first, flow.Testnet)
+		},
+		"RemoveChildAccount": func() (*flow.Transaction, error) {
+			return templates.RemoveChildAccount(custody, second, first, flow.Testnet)
+		},
+		"RemoveParentAccount": func() (*flow.Transaction, error) {
+			return templates.RemoveParentAccount(custody, first, second, flow.Testnet)
+		},
+		"TopUpStorage": func() (*flow.Transaction, error) {
+			return templates.TopUpStorage(first, 1_000_000, second, flow.Testnet)
+		},
+		"CreateCOA": func() (*flow.Transaction, error) {
+			return templates.CreateCOA("1.0", first, flow.Testnet)
+		},
+		"FundEVMAddress": func() (*flow.Transaction, error) {
+			return templates.FundEVMAddress(evmAddress, "1.0", first, flow.Testnet)
+		},
+		"EVMCall": func() (*flow.Transaction, error) {
+			return templates.EVMCall(evmAddress, []byte{1, 2}, 100_000, big.NewInt(1), first, flow.Testnet)
+		},
+		"EVMDeploy": func() (*flow.Transaction, error) {
+			return templates.EVMDeploy([]byte{1, 2}, 100_000, big.NewInt(1), first, flow.Testnet)
+		},
+	}
+
+	for name, build := range builders {
+		t.Run(name, func(t *testing.T) {
+			tx, err := build()
+			require.NoError(t, err)
+			require.NotEmpty(t, tx.Arguments)
+			assert.Empty(t, tx.NonCanonicalArguments())
+		})
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
+)
+
+// A FungibleToken describes a fungible token contract and the paths its vaults are stored and published at.
+//
+// Paths are given as identifiers, without their domain: "flowTokenVault" rather than "/storage/flowTokenVault".
+type FungibleToken struct {
+	// Contract is the token contract, which must define a Vault resource.
+	Contract contracts.Contract
+	// VaultPath is the storage path of the vault.
+	VaultPath string
+	// ReceiverPath is the public path of the vault's FungibleToken.Receiver capability.
+	ReceiverPath string
+	// BalancePath is the public path of the vault's FungibleToken.Balance capability.
+	BalancePath string
+}
+
+// FlowToken returns the FLOW token of the given network.
+func FlowToken(network flow.ChainID) (FungibleToken, error) {
+	core, err := contracts.ForChain(network)
+	if err != nil {
+		return FungibleToken{}, err
+	}
+
+	return FungibleToken{
+		Contract:     core.FlowToken,
+		VaultPath:    "flowTokenVault",
+		ReceiverPath: "flowTokenReceiver",
+		BalancePath:  "flowTokenBalance",
+	}, nil
+}
+
+func (t FungibleToken) replacer() (*strings.Replacer, error) {
+	if err := checkIdentifiers(t.Contract.Name, t.VaultPath, t.ReceiverPath, t.BalancePath); err != nil {
+		return nil, err
+	}
+
+	return strings.NewReplacer(
+		"{{Token}}", t.Contract.Name,
+		"{{VaultPath}}", t.VaultPath,
+		"{{ReceiverPath}}", t.ReceiverPath,
+		"{{BalancePath}}", t.BalancePath,
+	), nil
+}
+
+// code fills in a template with the token contract and paths and resolves its imports for the network.
+func (t FungibleToken) code(template string, network flow.ChainID) ([]byte, error) {
+	replacer, err := t.replacer()
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveImports(network, replacer.Replace(template), t.Contract)
+}
+
+const transferFungibleTokenTemplate = `import "FungibleToken"
+import "{{Token}}"
+
+transaction(amount: UFix64, to: Address) {
+    let sentVault: @{FungibleToken.Vault}
+
+    prepare(signer: auth(BorrowValue) &Account) {
+        let vault = signer.storage.borrow<auth(FungibleToken.Withdraw) &{{Token}}.Vault>(from: /storage/{{VaultPath}})
+            ?? panic("Could not borrow a reference to the signer's vault")
+
+        self.sentVault <- vault.withdraw(amount: amount)
+    }
+
+    execute {
+        let receiver = getAccount(to).capabilities.borrow<&{FungibleToken.Receiver}>(/public/{{ReceiverPath}})
+            ?? panic("Could not borrow a receiver reference to the recipient's vault")
+
+        receiver.deposit(from: <-self.sentVault)
+    }
+}
+`
+
+const fungibleTokenBalanceTemplate = `import "FungibleToken"
+
+access(all) fun main(address: Address): UFix64 {
+    let vault = getAccount(address).capabilities.borrow<&{FungibleToken.Balance}>(/public/{{BalancePath}})
+        ?? panic("Could not borrow a balance reference to the account's vault")
+
+    return vault.balance
+}
+`
+
+const hasFungibleTokenReceiverTemplate = `import "FungibleToken"
+
+access(all) fun main(address: Address): Bool {
+    return getAccount(address).capabilities.borrow<&{FungibleToken.Receiver}>(/public/{{ReceiverPath}}) != nil
+}
+`
+
+// TransferFlow generates a transaction that transfers FLOW tokens.
+//
+// The amount is a decimal UFix64 string, for example "10.5". The sender is added as the transaction
+// authorizer and therefore must sign the resulting transaction.
+func TransferFlow(amount string, to flow.Address, from flow.Address, network flow.ChainID) (*flow.Transaction, error) {
+	token, err := FlowToken(network)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create TransferFlow transaction: %w", err)
+	}
+
+	return TransferFungibleToken(token, amount, to, from, network)
+}
+
+// TransferFungibleToken generates a transaction that transfers fungible tokens between the vaults of two accounts.
+//
+// The amount is a decimal UFix64 string, for example "10.5". The sender is added as the transaction
+// authorizer and therefore must sign the resulting transaction.
+func TransferFungibleToken(
+	token FungibleToken,
+	amount string,
+	to flow.Address,
+	from flow.Address,
+	network flow.ChainID,
+) (*flow.Transaction, error) {
+	code, err := token.code(transferFungibleTokenTemplate, network)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create TransferFungibleToken transaction: %w", err)
+	}
+
+	value, err := cadence.NewUFix64(amount)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create TransferFungibleToken transaction: %w", err)
+	}
+
+	return flow.NewTransaction().
+		SetScript(code).
+		AddRawArgument(flow.MustEncodeArgument(value)).
+		AddRawArgument(flow.MustEncodeArgument(cadence.NewAddress(to))).
+		AddAuthorizer(from), nil
+}
+
+// GetFlowBalance generates a script that returns the FLOW balance of an account as a UFix64.
+func GetFlowBalance(address flow.Address, network flow.ChainID) (Script, error) {
+	token, err := FlowToken(network)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create GetFlowBalance script: %w", err)
+	}
+
+	return GetFungibleTokenBalance(token, address, network)
+}
+
+// GetFungibleTokenBalance generates a script that returns the token balance of an account as a UFix64.
+//
+// The script fails if the account has not published a balance capability for the token.
+func GetFungibleTokenBalance(token FungibleToken, address flow.Address, network flow.ChainID) (Script, error) {
+	code, err := token.code(fungibleTokenBalanceTemplate, network)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create GetFungibleTokenBalance script: %w", err)
+	}
+
+	return Script{
+		Code:      code,
+		Arguments: []cadence.Value{cadence.NewAddress(address)},
+	}, nil
+}
+
+// HasFlowReceiver generates a script that returns whether an account can receive FLOW tokens.
+func HasFlowReceiver(address flow.Address, network flow.ChainID) (Script, error) {
+	token, err := FlowToken(network)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create HasFlowReceiver script: %w", err)
+	}
+
+	return HasFungibleTokenReceiver(token, address, network)
+}
+
+// HasFungibleTokenReceiver generates a script that returns whether an account has published a receiver
+// capability for the token, as a Bool.
+func HasFungibleTokenReceiver(token FungibleToken, address flow.Address, network flow.ChainID) (Script, error) {
+	code, err := token.code(hasFungibleTokenReceiverTemplate, network)
+	if err != nil {
+		return Script{}, fmt.Errorf("cannot create HasFungibleTokenReceiver script: %w", err)
+	}
+
+	return Script{
+		Code:      code,
+		Arguments: []cadence.Value{cadence.NewAddress(address)},
+	}, nil
+}
@@ -0,0 +1,160 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/contracts"
+	"github.com/onflow/flow-go-sdk/templates"
+)
+
+// requireParses checks that generated Cadence code is syntactically valid.
+func requireParses(t *testing.T, code []byte) {
+	_, err := parser.ParseProgram(nil, code, parser.Config{})
+	require.NoError(t, err)
+}
+
+func TestTransferFlow(t *testing.T) {
+	to := flow.HexToAddress("01")
+	from := flow.HexToAddress("02")
+
+	tx, err := templates.TransferFlow("10.5", to, from, flow.Mainnet)
+	require.NoError(t, err)
+
+	expected := `import FungibleToken from 0xf233dcee88fe0abe
+import FlowToken from 0x1654653399040a61
+
+transaction(amount: UFix64, to: Address) {
+    let sentVault: @{FungibleToken.Vault}
+
+    prepare(signer: auth(BorrowValue) &Account) {
+        let vault = signer.storage.borrow<auth(FungibleToken.Withdraw) &FlowToken.Vault>(from: /storage/flowTokenVault)
+            ?? panic("Could not borrow a reference to the signer's vault")
+
+        self.sentVault <- vault.withdraw(amount: amount)
+    }
+
+    execute {
+        let receiver = getAccount(to).capabilities.borrow<&{FungibleToken.Receiver}>(/public/flowTokenReceiver)
+            ?? panic("Could not borrow a receiver reference to the recipient's vault")
+
+        receiver.deposit(from: <-self.sentVault)
+    }
+}
+`
+	assert.Equal(t, expected, string(tx.Script))
+	requireParses(t, tx.Script)
+
+	amount, err := tx.Argument(0)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.UFix64(10_50000000), amount)
+
+	recipient, err := tx.Argument(1)
+	require.NoError(t, err)
+	assert.Equal(t, cadence.NewAddress(to), recipient)
+
+	assert.Equal(t, []flow.Address{from}, tx.Authorizers)
+
+	t.Run("Testnet", func(t *testing.T) {
+		tx, err := templates.TransferFlow("1.0", to, from, flow.Testnet)
+		require.NoError(t, err)
+		assert.Contains(t, string(tx.Script), "import FlowToken from 0x7e60df042a9c0868")
+		assert.Contains(t, string(tx.Script), "import FungibleToken from 0x9a0766d93b6608b7")
+	})
+
+	t.Run("Invalid amount", func(t *testing.T) {
+		_, err := templates.TransferFlow("ten", to, from, flow.Mainnet)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unsupported network", func(t *testing.T) {
+		_, err := templates.TransferFlow("1.0", to, from, flow.MonotonicEmulator)
+		assert.ErrorIs(t, err, contracts.ErrUnsupportedChain)
+	})
+}
+
+func TestTransferFungibleToken(t *testing.T) {
+	token := templates.FungibleToken{
+		Contract:     contracts.Contract{Name: "ExampleToken", Address: flow.HexToAddress("0x0123456789abcdef")},
+		VaultPath:    "exampleTokenVault",
+		ReceiverPath: "exampleTokenReceiver",
+		BalancePath:  "exampleTokenBalance",
+	}
+
+	tx, err := templates.TransferFungibleToken(token, "3.0", flow.HexToAddress("01"), flow.HexToAddress("02"), flow.Testnet)
+	require.NoError(t, err)
+	requireParses(t, tx.Script)
+
+	script := string(tx.Script)
+	assert.Contains(t, script, "import ExampleToken from 0x0123456789abcdef")
+	assert.Contains(t, script, "&ExampleToken.Vault>(from: /storage/exampleTokenVault)")
+	assert.Contains(t, script, "(/public/exampleTokenReceiver)")
+
+	t.Run("Invalid path", func(t *testing.T) {
+		invalid := token
+		invalid.VaultPath = "vault)\n}"
+
+		_, err := templates.TransferFungibleToken(invalid, "3.0", flow.HexToAddress("01"), flow.HexToAddress("02"), flow.Testnet)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetFlowBalance(t *testing.T) {
+	address := flow.HexToAddress("01")
+
+	script, err := templates.GetFlowBalance(address, flow.Emulator)
+	require.NoError(t, err)
+
+	expected := `import FungibleToken from 0xee82856bf20e2aa6
+
+access(all) fun main(address: Address): UFix64 {
+    let vault = getAccount(address).capabilities.borrow<&{FungibleToken.Balance}>(/public/flowTokenBalance)
+        ?? panic("Could not borrow a balance reference to the account's vault")
+
+    return vault.balance
+}
+`
+	assert.Equal(t, expected, string(script.Code))
+	assert.Equal(t, []cadence.Value{cadence.NewAddress(address)}, script.Arguments)
+	requireParses(t, script.Code)
+}
+
+func TestHasFlowReceiver(t *testing.T) {
+	address := flow.HexToAddress("01")
+
+	script, err := templates.HasFlowReceiver(address, flow.Mainnet)
+	require.NoError(t, err)
+
+	expected := `import FungibleToken from 0xf233dcee88fe0abe
+
+access(all) fun main(address: Address): Bool {
+    return getAccount(address).capabilities.borrow<&{FungibleToken.Receiver}>(/public/flowTokenReceiver) != nil
+}
+`
+	assert.Equal(t, expected, string(script.Code))
+	assert.Equal(t, []cadence.Value{cadence.NewAddress(address)}, script.Arguments)
+	requireParses(t, script.Code)
+}
@@ -20,6 +20,7 @@ package flow
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"sort"
@@ -134,9 +135,33 @@ func (t *Transaction) SetScript(script []byte) *Transaction {
 	return t
 }
 
+// An ImportResolver rewrites the contract imports of Cadence code for a target network.
+//
+// It is implemented by imports.Resolver.
+type ImportResolver interface {
+	Resolve(code []byte) ([]byte, error)
+}
+
+// SetResolvedScript resolves the imports of script with the given resolver and sets the result
+// as the Cadence script for this transaction.
+//
+// The script is left unchanged if an import cannot be resolved.
+func (t *Transaction) SetResolvedScript(script []byte, resolver ImportResolver) error {
+	resolved, err := resolver.Resolve(script)
+	if err != nil {
+		return err
+	}
+
+	t.SetScript(resolved)
+
+	return nil
+}
+
 // AddArgument adds a Cadence argument to this transaction.
+//
+// The argument is stored in its canonical JSON-CDC encoding.
 func (t *Transaction) AddArgument(arg cadence.Value) error {
-	encodedArg, err := jsoncdc.Encode(arg)
+	encodedArg, err := encodeCanonicalArgument(arg)
 	if err != nil {
 		return fmt.Errorf("failed to encode argument: %w", err)
 	}
@@ -146,6 +171,9 @@ func (t *Transaction) AddArgument(arg cadence.Value) error {
 }
 
 // AddRawArgument adds a raw JSON-CDC encoded argument to this transaction.
+//
+// The argument is stored and signed as given; call NormalizeArguments before signing to convert
+// it to the canonical encoding.
 func (t *Transaction) AddRawArgument(arg []byte) *Transaction {
 	t.Arguments = append(t.Arguments, arg)
 	return t
@@ -300,17 +328,7 @@ func (t *Transaction) refreshSignerIndex() {
 //
 // This function returns an error if the signature cannot be generated.
 func (t *Transaction) SignPayload(address Address, keyIndex uint32, signer crypto.Signer) error {
-	message := t.PayloadMessage()
-	message = append(TransactionDomainTag[:], message...)
-	sig, err := signer.Sign(message)
-	if err != nil {
-		// TODO: wrap error
-		return err
-	}
-
-	t.AddPayloadSignature(address, keyIndex, sig)
-
-	return nil
+	return t.SignPayloadWithContext(context.Background(), address, keyIndex, signer)
 }
 
 // SignEnvelope signs the full transaction (TransactionDomainTag + payload + payload signatures) with the specified account key.
@@ -320,15 +338,38 @@ func (t *Transaction) SignPayload(address Address, keyIndex uint32, signer crypt
 //
 // This function returns an error if the signature cannot be generated.
 func (t *Transaction) SignEnvelope(address Address, keyIndex uint32, signer crypto.Signer) error {
-	message := t.EnvelopeMessage()
+	return t.SignEnvelopeWithContext(context.Background(), address, keyIndex, signer)
+}
+
+// SignPayloadWithContext signs the transaction payload like SignPayload, binding the signature to ctx.
+//
+// If the signer implements crypto.ContextSigner, ctx is passed to it so that remote signers honour
+// its deadline and cancellation. Other signers are only invoked if ctx is not done yet.
+func (t *Transaction) SignPayloadWithContext(ctx context.Context, address Address, keyIndex uint32, signer crypto.Signer) error {
+	return t.sign(ctx, t.PayloadMessage(), signer, func(sig []byte) {
+		t.AddPayloadSignature(address, keyIndex, sig)
+	})
+}
+
+// SignEnvelopeWithContext signs the full transaction like SignEnvelope, binding the signature to ctx.
+//
+// If the signer implements crypto.ContextSigner, ctx is passed to it so that remote signers honour
+// its deadline and cancellation. Other signers are only invoked if ctx is not done yet.
+func (t *Transaction) SignEnvelopeWithContext(ctx context.Context, address Address, keyIndex uint32, signer crypto.Signer) error {
+	return t.sign(ctx, t.EnvelopeMessage(), signer, func(sig []byte) {
+		t.AddEnvelopeSignature(address, keyIndex, sig)
+	})
+}
+
+// sign signs a message prefixed with TransactionDomainTag and passes the signature to add.
+func (t *Transaction) sign(ctx context.Context, message []byte, signer crypto.Signer, add func(sig []byte)) error {
 	message = append(TransactionDomainTag[:], message...)
-	sig, err := signer.Sign(message)
+	sig, err := crypto.SignWithContext(ctx, signer, message)
 	if err != nil {
-		// TODO: wrap error
 		return err
 	}
 
-	t.AddEnvelopeSignature(address, keyIndex, sig)
+	add(sig)
 
 	return nil
 }
@@ -0,0 +1,146 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+// ErrTransactionArgumentsSigned indicates that the arguments of a signed transaction cannot be normalized
+// without invalidating its signatures.
+var ErrTransactionArgumentsSigned = errors.New("transaction arguments are not canonical and the transaction is already signed")
+
+// CanonicalArgument returns the canonical JSON-CDC encoding of a transaction argument.
+//
+// The canonical encoding is the output of the Cadence JSON-CDC encoder without a trailing newline.
+// Two arguments holding the same Cadence value always have the same canonical encoding, regardless
+// of the encoder that produced them, so transactions built from canonical arguments have stable IDs.
+//
+// This function returns an error if the argument is not valid JSON-CDC.
+func CanonicalArgument(arg []byte, options ...jsoncdc.Option) ([]byte, error) {
+	value, err := jsoncdc.Decode(nil, arg, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode argument: %w", err)
+	}
+
+	encoded, err := encodeCanonicalArgument(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode argument: %w", err)
+	}
+
+	return encoded, nil
+}
+
+// IsCanonicalArgument reports whether a transaction argument is in its canonical JSON-CDC encoding.
+//
+// Arguments that are not valid JSON-CDC are never canonical.
+func IsCanonicalArgument(arg []byte, options ...jsoncdc.Option) bool {
+	canonical, err := CanonicalArgument(arg, options...)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(arg, canonical)
+}
+
+// EncodeArgument returns the canonical JSON-CDC encoding of a Cadence value, for use as a raw
+// transaction argument.
+func EncodeArgument(value cadence.Value) ([]byte, error) {
+	return encodeCanonicalArgument(value)
+}
+
+// MustEncodeArgument returns the canonical JSON-CDC encoding of a Cadence value, and panics if the
+// value cannot be encoded.
+func MustEncodeArgument(value cadence.Value) []byte {
+	encoded, err := encodeCanonicalArgument(value)
+	if err != nil {
+		panic(err)
+	}
+	return encoded
+}
+
+func encodeCanonicalArgument(value cadence.Value) ([]byte, error) {
+	encoded, err := jsoncdc.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(encoded, []byte("\n")), nil
+}
+
+// NonCanonicalArguments returns the indices of the transaction arguments that are not in their
+// canonical JSON-CDC encoding.
+//
+// This is useful to detect decoded transactions whose ID depends on the encoder that produced them.
+func (t *Transaction) NonCanonicalArguments(options ...jsoncdc.Option) []int {
+	var indices []int
+
+	for i, arg := range t.Arguments {
+		if !IsCanonicalArgument(arg, options...) {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// NormalizeArguments converts all transaction arguments to their canonical JSON-CDC encoding.
+//
+// Signing never rewrites arguments, so NormalizeArguments must be called explicitly before the
+// transaction is signed.
+//
+// Normalizing changes the transaction ID if any argument was not canonical. It is therefore refused
+// with ErrTransactionArgumentsSigned if the transaction already carries signatures and an argument
+// would change.
+//
+// This function returns an error if an argument is not valid JSON-CDC.
+func (t *Transaction) NormalizeArguments(options ...jsoncdc.Option) error {
+	normalized := make([][]byte, len(t.Arguments))
+	changed := false
+
+	for i, arg := range t.Arguments {
+		canonical, err := CanonicalArgument(arg, options...)
+		if err != nil {
+			return fmt.Errorf("failed to normalize argument at index %d: %w", i, err)
+		}
+
+		if !bytes.Equal(arg, canonical) {
+			changed = true
+		}
+
+		normalized[i] = canonical
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if len(t.PayloadSignatures) > 0 || len(t.EnvelopeSignatures) > 0 {
+		return ErrTransactionArgumentsSigned
+	}
+
+	t.Arguments = normalized
+
+	return nil
+}
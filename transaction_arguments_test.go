@@ -0,0 +1,122 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestCanonicalArgument(t *testing.T) {
+	canonical := []byte(`{"value":"foo","type":"String"}`)
+
+	t.Run("Equivalent encodings", func(t *testing.T) {
+		for _, arg := range [][]byte{
+			canonical,
+			jsoncdc.MustEncode(cadence.String("foo")),
+			[]byte(`{"type":"String","value":"foo"}`),
+			[]byte(" {\n  \"type\": \"String\",\n  \"value\": \"foo\"\n}\n"),
+		} {
+			actual, err := flow.CanonicalArgument(arg)
+			require.NoError(t, err)
+			assert.Equal(t, canonical, actual)
+		}
+	})
+
+	t.Run("Is canonical", func(t *testing.T) {
+		assert.True(t, flow.IsCanonicalArgument(canonical))
+		assert.False(t, flow.IsCanonicalArgument([]byte(`{"type":"String","value":"foo"}`)))
+		assert.False(t, flow.IsCanonicalArgument([]byte("not json-cdc")))
+	})
+
+	t.Run("Invalid argument", func(t *testing.T) {
+		_, err := flow.CanonicalArgument([]byte("not json-cdc"))
+		assert.Error(t, err)
+	})
+
+	t.Run("Encode argument", func(t *testing.T) {
+		encoded, err := flow.EncodeArgument(cadence.String("foo"))
+		require.NoError(t, err)
+		assert.Equal(t, canonical, encoded)
+		assert.Equal(t, canonical, flow.MustEncodeArgument(cadence.String("foo")))
+	})
+}
+
+func TestTransaction_NormalizeArguments(t *testing.T) {
+	newTx := func(arg []byte) *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			SetProposalKey(flow.HexToAddress("01"), 0, 0).
+			SetPayer(flow.HexToAddress("01")).
+			AddRawArgument(arg)
+	}
+
+	t.Run("Stable ID", func(t *testing.T) {
+		a := newTx([]byte(`{"type":"String","value":"foo"}`))
+		b := newTx([]byte(`{"value":"foo","type":"String"}`))
+
+		assert.Equal(t, []int{0}, a.NonCanonicalArguments())
+		assert.Empty(t, b.NonCanonicalArguments())
+		assert.NotEqual(t, a.ID(), b.ID())
+
+		require.NoError(t, a.NormalizeArguments())
+		assert.Empty(t, a.NonCanonicalArguments())
+		assert.Equal(t, a.ID(), b.ID())
+	})
+
+	t.Run("AddArgument is canonical", func(t *testing.T) {
+		tx := flow.NewTransaction()
+		require.NoError(t, tx.AddArgument(cadence.NewInt(42)))
+
+		assert.Empty(t, tx.NonCanonicalArguments())
+	})
+
+	t.Run("Signing keeps raw arguments", func(t *testing.T) {
+		_, signer := test.AccountKeyGenerator().NewWithSigner()
+
+		tx := newTx([]byte("not json-cdc"))
+		payload := tx.PayloadMessage()
+		require.NoError(t, tx.SignEnvelope(tx.Payer, 0, signer))
+
+		assert.Equal(t, payload, tx.PayloadMessage())
+		assert.Equal(t, []byte("not json-cdc"), tx.Arguments[0])
+	})
+
+	t.Run("Signed transaction", func(t *testing.T) {
+		tx := newTx([]byte(`{"type":"String","value":"foo"}`))
+		tx.AddEnvelopeSignature(tx.Payer, 0, []byte{1})
+
+		err := tx.NormalizeArguments()
+		assert.ErrorIs(t, err, flow.ErrTransactionArgumentsSigned)
+		assert.Equal(t, []int{0}, tx.NonCanonicalArguments())
+	})
+
+	t.Run("Invalid argument", func(t *testing.T) {
+		tx := newTx([]byte("not json-cdc"))
+
+		assert.Error(t, tx.NormalizeArguments())
+	})
+}
@@ -0,0 +1,186 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// An FCLVoucher is the signable representation of a transaction used by the Flow Client Library (FCL).
+//
+// Wallets and FCL signing services exchange transactions in this shape. Unlike the Access REST API
+// representation, the script is plain text, arguments are embedded JSON-CDC values, addresses are
+// prefixed with 0x and signatures are hex encoded.
+type FCLVoucher struct {
+	Cadence      string            `json:"cadence"`
+	RefBlock     string            `json:"refBlock"`
+	ComputeLimit uint64            `json:"computeLimit"`
+	Arguments    []json.RawMessage `json:"arguments"`
+	ProposalKey  FCLProposalKey    `json:"proposalKey"`
+	Payer        string            `json:"payer"`
+	Authorizers  []string          `json:"authorizers"`
+	PayloadSigs  []FCLSignature    `json:"payloadSigs"`
+	EnvelopeSigs []FCLSignature    `json:"envelopeSigs"`
+}
+
+// An FCLProposalKey is the proposal key of an FCLVoucher.
+type FCLProposalKey struct {
+	Address     string `json:"address"`
+	KeyID       uint32 `json:"keyId"`
+	SequenceNum uint64 `json:"sequenceNum"`
+}
+
+// An FCLSignature is a payload or envelope signature of an FCLVoucher.
+//
+// FCL includes an entry without signature for each required signer that has not signed yet.
+type FCLSignature struct {
+	Address string `json:"address"`
+	KeyID   uint32 `json:"keyId"`
+	Sig     string `json:"sig"`
+}
+
+// NewFCLVoucher converts a transaction to its FCL voucher representation.
+//
+// Arguments are copied byte for byte. Encoding the voucher as JSON compacts them, so the transaction
+// ID only survives a JSON round trip of the voucher if all arguments are in their canonical JSON-CDC
+// encoding; call Transaction.NormalizeArguments before signing to ensure this.
+func NewFCLVoucher(tx *Transaction) (*FCLVoucher, error) {
+	arguments := make([]json.RawMessage, len(tx.Arguments))
+	for i, arg := range tx.Arguments {
+		if !json.Valid(arg) {
+			return nil, fmt.Errorf("argument %d is not valid JSON", i)
+		}
+		arguments[i] = json.RawMessage(arg)
+	}
+
+	authorizers := make([]string, len(tx.Authorizers))
+	for i, authorizer := range tx.Authorizers {
+		authorizers[i] = authorizer.HexWithPrefix()
+	}
+
+	return &FCLVoucher{
+		Cadence:      string(tx.Script),
+		RefBlock:     tx.ReferenceBlockID.Hex(),
+		ComputeLimit: tx.GasLimit,
+		Arguments:    arguments,
+		ProposalKey: FCLProposalKey{
+			Address:     tx.ProposalKey.Address.HexWithPrefix(),
+			KeyID:       tx.ProposalKey.KeyIndex,
+			SequenceNum: tx.ProposalKey.SequenceNumber,
+		},
+		Payer:        tx.Payer.HexWithPrefix(),
+		Authorizers:  authorizers,
+		PayloadSigs:  signaturesToFCL(tx.PayloadSignatures),
+		EnvelopeSigs: signaturesToFCL(tx.EnvelopeSignatures),
+	}, nil
+}
+
+// Transaction converts the voucher to a transaction.
+//
+// Arguments are used byte for byte as they appear in the voucher, so that signatures made over them
+// remain valid; they are not normalized. Signature entries without a signature are skipped.
+func (v *FCLVoucher) Transaction() (*Transaction, error) {
+	refBlock, err := hexToID(v.RefBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode reference block ID: %w", err)
+	}
+
+	proposer, err := hexToAddress(v.ProposalKey.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode proposal key address: %w", err)
+	}
+
+	payer, err := hexToAddress(v.Payer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode payer: %w", err)
+	}
+
+	tx := NewTransaction().
+		SetScript([]byte(v.Cadence)).
+		SetReferenceBlockID(refBlock).
+		SetComputeLimit(v.ComputeLimit).
+		SetProposalKey(proposer, v.ProposalKey.KeyID, v.ProposalKey.SequenceNum).
+		SetPayer(payer)
+
+	for _, arg := range v.Arguments {
+		tx.AddRawArgument([]byte(arg))
+	}
+
+	for i, authorizer := range v.Authorizers {
+		address, err := hexToAddress(authorizer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode authorizer %d: %w", i, err)
+		}
+		tx.AddAuthorizer(address)
+	}
+
+	for i, sig := range v.PayloadSigs {
+		address, signature, err := sig.decode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode payload signature %d: %w", i, err)
+		}
+		if signature != nil {
+			tx.AddPayloadSignature(address, sig.KeyID, signature)
+		}
+	}
+
+	for i, sig := range v.EnvelopeSigs {
+		address, signature, err := sig.decode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode envelope signature %d: %w", i, err)
+		}
+		if signature != nil {
+			tx.AddEnvelopeSignature(address, sig.KeyID, signature)
+		}
+	}
+
+	return tx, nil
+}
+
+func (s FCLSignature) decode() (Address, []byte, error) {
+	address, err := hexToAddress(s.Address)
+	if err != nil {
+		return EmptyAddress, nil, err
+	}
+
+	if s.Sig == "" {
+		return address, nil, nil
+	}
+
+	signature, err := hex.DecodeString(s.Sig)
+	if err != nil {
+		return EmptyAddress, nil, err
+	}
+
+	return address, signature, nil
+}
+
+func signaturesToFCL(signatures []TransactionSignature) []FCLSignature {
+	result := make([]FCLSignature, len(signatures))
+	for i, sig := range signatures {
+		result[i] = FCLSignature{
+			Address: sig.Address.HexWithPrefix(),
+			KeyID:   sig.KeyIndex,
+			Sig:     hex.EncodeToString(sig.Signature),
+		}
+	}
+	return result
+}
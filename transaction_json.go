@@ -0,0 +1,310 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// transactionJSON is the JSON representation of a transaction used by the Access REST API.
+type transactionJSON struct {
+	ID                 string                     `json:"id,omitempty"`
+	Script             string                     `json:"script"`
+	Arguments          []string                   `json:"arguments"`
+	ReferenceBlockID   string                     `json:"reference_block_id"`
+	GasLimit           string                     `json:"gas_limit"`
+	Payer              string                     `json:"payer"`
+	ProposalKey        proposalKeyJSON            `json:"proposal_key"`
+	Authorizers        []string                   `json:"authorizers"`
+	PayloadSignatures  []transactionSignatureJSON `json:"payload_signatures"`
+	EnvelopeSignatures []transactionSignatureJSON `json:"envelope_signatures"`
+}
+
+type proposalKeyJSON struct {
+	Address        string `json:"address"`
+	KeyIndex       string `json:"key_index"`
+	SequenceNumber string `json:"sequence_number"`
+}
+
+type transactionSignatureJSON struct {
+	Address   string `json:"address"`
+	KeyIndex  string `json:"key_index"`
+	Signature string `json:"signature"`
+}
+
+// MarshalJSON returns the JSON representation of the transaction, as used by the Access REST API.
+//
+// The script and arguments are base64 encoded, addresses and identifiers are hex encoded
+// and integers are encoded as strings.
+func (t Transaction) MarshalJSON() ([]byte, error) {
+	arguments := make([]string, len(t.Arguments))
+	for i, arg := range t.Arguments {
+		arguments[i] = base64.StdEncoding.EncodeToString(arg)
+	}
+
+	authorizers := make([]string, len(t.Authorizers))
+	for i, authorizer := range t.Authorizers {
+		authorizers[i] = authorizer.Hex()
+	}
+
+	return json.Marshal(transactionJSON{
+		ID:                 t.ID().Hex(),
+		Script:             base64.StdEncoding.EncodeToString(t.Script),
+		Arguments:          arguments,
+		ReferenceBlockID:   t.ReferenceBlockID.Hex(),
+		GasLimit:           strconv.FormatUint(t.GasLimit, 10),
+		Payer:              t.Payer.Hex(),
+		ProposalKey:        t.ProposalKey.toJSON(),
+		Authorizers:        authorizers,
+		PayloadSignatures:  signaturesToJSON(t.PayloadSignatures),
+		EnvelopeSignatures: signaturesToJSON(t.EnvelopeSignatures),
+	})
+}
+
+// UnmarshalJSON decodes a transaction from its Access REST API JSON representation.
+//
+// The transaction ID, if present, is ignored; it is always derived from the decoded transaction.
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	var temp transactionJSON
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+
+	script, err := base64.StdEncoding.DecodeString(temp.Script)
+	if err != nil {
+		return fmt.Errorf("failed to decode script: %w", err)
+	}
+	if len(script) == 0 {
+		script = nil
+	}
+
+	var arguments [][]byte
+	for i, arg := range temp.Arguments {
+		decoded, err := base64.StdEncoding.DecodeString(arg)
+		if err != nil {
+			return fmt.Errorf("failed to decode argument %d: %w", i, err)
+		}
+		arguments = append(arguments, decoded)
+	}
+
+	referenceBlockID, err := hexToID(temp.ReferenceBlockID)
+	if err != nil {
+		return fmt.Errorf("failed to decode reference block ID: %w", err)
+	}
+
+	gasLimit, err := strconv.ParseUint(temp.GasLimit, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to decode gas limit: %w", err)
+	}
+
+	payer, err := hexToAddress(temp.Payer)
+	if err != nil {
+		return fmt.Errorf("failed to decode payer: %w", err)
+	}
+
+	proposalKey, err := temp.ProposalKey.toProposalKey()
+	if err != nil {
+		return err
+	}
+
+	var authorizers []Address
+	for i, authorizer := range temp.Authorizers {
+		address, err := hexToAddress(authorizer)
+		if err != nil {
+			return fmt.Errorf("failed to decode authorizer %d: %w", i, err)
+		}
+		authorizers = append(authorizers, address)
+	}
+
+	payloadSignatures, err := signaturesFromJSON(temp.PayloadSignatures)
+	if err != nil {
+		return fmt.Errorf("failed to decode payload signatures: %w", err)
+	}
+
+	envelopeSignatures, err := signaturesFromJSON(temp.EnvelopeSignatures)
+	if err != nil {
+		return fmt.Errorf("failed to decode envelope signatures: %w", err)
+	}
+
+	*t = Transaction{
+		Script:             script,
+		Arguments:          arguments,
+		ReferenceBlockID:   referenceBlockID,
+		GasLimit:           gasLimit,
+		ProposalKey:        proposalKey,
+		Payer:              payer,
+		Authorizers:        authorizers,
+		PayloadSignatures:  payloadSignatures,
+		EnvelopeSignatures: envelopeSignatures,
+	}
+	t.refreshSignerIndex()
+
+	return nil
+}
+
+func (p ProposalKey) toJSON() proposalKeyJSON {
+	return proposalKeyJSON{
+		Address:        p.Address.Hex(),
+		KeyIndex:       strconv.FormatUint(uint64(p.KeyIndex), 10),
+		SequenceNumber: strconv.FormatUint(p.SequenceNumber, 10),
+	}
+}
+
+func (p proposalKeyJSON) toProposalKey() (ProposalKey, error) {
+	address, err := hexToAddress(p.Address)
+	if err != nil {
+		return ProposalKey{}, fmt.Errorf("failed to decode proposal key address: %w", err)
+	}
+
+	keyIndex, err := strconv.ParseUint(p.KeyIndex, 10, 32)
+	if err != nil {
+		return ProposalKey{}, fmt.Errorf("failed to decode proposal key index: %w", err)
+	}
+
+	sequenceNumber, err := strconv.ParseUint(p.SequenceNumber, 10, 64)
+	if err != nil {
+		return ProposalKey{}, fmt.Errorf("failed to decode proposal key sequence number: %w", err)
+	}
+
+	return ProposalKey{
+		Address:        address,
+		KeyIndex:       uint32(keyIndex),
+		SequenceNumber: sequenceNumber,
+	}, nil
+}
+
+// MarshalJSON returns the JSON representation of the proposal key, as used by the Access REST API.
+func (p ProposalKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.toJSON())
+}
+
+// UnmarshalJSON decodes a proposal key from its Access REST API JSON representation.
+func (p *ProposalKey) UnmarshalJSON(data []byte) error {
+	var temp proposalKeyJSON
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+
+	*p, err = temp.toProposalKey()
+	return err
+}
+
+func (s TransactionSignature) toJSON() transactionSignatureJSON {
+	return transactionSignatureJSON{
+		Address:   s.Address.Hex(),
+		KeyIndex:  strconv.FormatUint(uint64(s.KeyIndex), 10),
+		Signature: base64.StdEncoding.EncodeToString(s.Signature),
+	}
+}
+
+func (s transactionSignatureJSON) toSignature() (TransactionSignature, error) {
+	address, err := hexToAddress(s.Address)
+	if err != nil {
+		return TransactionSignature{}, fmt.Errorf("failed to decode signature address: %w", err)
+	}
+
+	keyIndex, err := strconv.ParseUint(s.KeyIndex, 10, 32)
+	if err != nil {
+		return TransactionSignature{}, fmt.Errorf("failed to decode signature key index: %w", err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(s.Signature)
+	if err != nil {
+		return TransactionSignature{}, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	return TransactionSignature{
+		Address:     address,
+		SignerIndex: -1,
+		KeyIndex:    uint32(keyIndex),
+		Signature:   signature,
+	}, nil
+}
+
+// MarshalJSON returns the JSON representation of the signature, as used by the Access REST API.
+//
+// The signer index is not included, since it depends on the transaction the signature belongs to.
+func (s TransactionSignature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.toJSON())
+}
+
+// UnmarshalJSON decodes a signature from its Access REST API JSON representation.
+//
+// The signer index is set to -1 until the signature is added to a transaction.
+func (s *TransactionSignature) UnmarshalJSON(data []byte) error {
+	var temp transactionSignatureJSON
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+
+	*s, err = temp.toSignature()
+	return err
+}
+
+func signaturesToJSON(signatures []TransactionSignature) []transactionSignatureJSON {
+	result := make([]transactionSignatureJSON, len(signatures))
+	for i, sig := range signatures {
+		result[i] = sig.toJSON()
+	}
+	return result
+}
+
+func signaturesFromJSON(signatures []transactionSignatureJSON) ([]TransactionSignature, error) {
+	var result []TransactionSignature
+	for i, sig := range signatures {
+		s, err := sig.toSignature()
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// hexToAddress strictly decodes a hex encoded address, with or without 0x prefix.
+func hexToAddress(h string) (Address, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(h, "0x"))
+	if err != nil {
+		return EmptyAddress, err
+	}
+	if len(b) > AddressLength {
+		return EmptyAddress, fmt.Errorf("address %s is longer than %d bytes", h, AddressLength)
+	}
+	return BytesToAddress(b), nil
+}
+
+// hexToID strictly decodes a hex encoded identifier.
+func hexToID(h string) (Identifier, error) {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return EmptyID, err
+	}
+	if len(b) != len(EmptyID) {
+		return EmptyID, fmt.Errorf("identifier %s is not %d bytes long", h, len(EmptyID))
+	}
+	return BytesToID(b), nil
+}
@@ -0,0 +1,222 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestTransaction_JSON(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		tx := test.TransactionGenerator().New()
+
+		data, err := json.Marshal(tx)
+		require.NoError(t, err)
+
+		var decoded flow.Transaction
+		require.NoError(t, json.Unmarshal(data, &decoded))
+
+		assert.Equal(t, tx.ID(), decoded.ID())
+		assert.Equal(t, tx.PayloadSignatures, decoded.PayloadSignatures)
+		assert.Equal(t, tx.EnvelopeSignatures, decoded.EnvelopeSignatures)
+	})
+
+	t.Run("REST API format", func(t *testing.T) {
+		addresses := test.AddressGenerator()
+		proposer, payer := addresses.New(), addresses.New()
+		refBlock := test.IdentifierGenerator().New()
+
+		tx := flow.NewTransaction().
+			SetScript([]byte("transaction {}")).
+			AddRawArgument([]byte(`{"type":"Int","value":"1"}`)).
+			SetReferenceBlockID(refBlock).
+			SetComputeLimit(100).
+			SetProposalKey(proposer, 2, 7).
+			SetPayer(payer).
+			AddAuthorizer(proposer).
+			AddPayloadSignature(proposer, 2, []byte{1, 2}).
+			AddEnvelopeSignature(payer, 0, []byte{3, 4})
+
+		data, err := json.Marshal(tx)
+		require.NoError(t, err)
+
+		expected := `{
+			"id": "` + tx.ID().Hex() + `",
+			"script": "dHJhbnNhY3Rpb24ge30=",
+			"arguments": ["eyJ0eXBlIjoiSW50IiwidmFsdWUiOiIxIn0="],
+			"reference_block_id": "` + refBlock.Hex() + `",
+			"gas_limit": "100",
+			"payer": "` + payer.Hex() + `",
+			"proposal_key": {"address": "` + proposer.Hex() + `", "key_index": "2", "sequence_number": "7"},
+			"authorizers": ["` + proposer.Hex() + `"],
+			"payload_signatures": [{"address": "` + proposer.Hex() + `", "key_index": "2", "signature": "AQI="}],
+			"envelope_signatures": [{"address": "` + payer.Hex() + `", "key_index": "0", "signature": "AwQ="}]
+		}`
+		assert.JSONEq(t, expected, string(data))
+
+		var decoded flow.Transaction
+		require.NoError(t, json.Unmarshal([]byte(expected), &decoded))
+		assert.Equal(t, tx.ID(), decoded.ID())
+		assert.Equal(t, 0, decoded.PayloadSignatures[0].SignerIndex)
+		assert.Equal(t, 1, decoded.EnvelopeSignatures[0].SignerIndex)
+	})
+
+	t.Run("Invalid fields", func(t *testing.T) {
+		data, err := json.Marshal(test.TransactionGenerator().New())
+		require.NoError(t, err)
+
+		for field, value := range map[string]any{
+			"script":             "not base64!",
+			"reference_block_id": "1234",
+			"gas_limit":          "-1",
+			"payer":              "zz",
+			"proposal_key":       map[string]string{"address": "01", "key_index": "x", "sequence_number": "1"},
+		} {
+			var raw map[string]any
+			require.NoError(t, json.Unmarshal(data, &raw))
+			raw[field] = value
+			invalid, err := json.Marshal(raw)
+			require.NoError(t, err)
+
+			var decoded flow.Transaction
+			assert.Error(t, json.Unmarshal(invalid, &decoded), field)
+		}
+	})
+}
+
+func TestFCLVoucher(t *testing.T) {
+	tx := test.TransactionGenerator().New()
+
+	voucher, err := flow.NewFCLVoucher(tx)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(tx.Script), voucher.Cadence)
+	assert.Equal(t, tx.Payer.HexWithPrefix(), voucher.Payer)
+	assert.Equal(t, tx.ProposalKey.KeyIndex, voucher.ProposalKey.KeyID)
+	require.Len(t, voucher.Arguments, len(tx.Arguments))
+
+	data, err := json.Marshal(voucher)
+	require.NoError(t, err)
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	// arguments are embedded as JSON-CDC values rather than encoded strings
+	assert.IsType(t, map[string]any{}, raw["arguments"].([]any)[0])
+
+	var decodedVoucher flow.FCLVoucher
+	require.NoError(t, json.Unmarshal(data, &decodedVoucher))
+
+	decoded, err := decodedVoucher.Transaction()
+	require.NoError(t, err)
+	assert.Equal(t, tx.ID(), decoded.ID())
+
+	t.Run("Unsigned entries are skipped", func(t *testing.T) {
+		voucher := flow.FCLVoucher{
+			Cadence:      "transaction {}",
+			RefBlock:     tx.ReferenceBlockID.Hex(),
+			ComputeLimit: 9999,
+			ProposalKey:  flow.FCLProposalKey{Address: tx.Payer.HexWithPrefix()},
+			Payer:        tx.Payer.HexWithPrefix(),
+			EnvelopeSigs: []flow.FCLSignature{{Address: tx.Payer.HexWithPrefix()}},
+		}
+
+		decoded, err := voucher.Transaction()
+		require.NoError(t, err)
+		assert.Empty(t, decoded.EnvelopeSignatures)
+	})
+
+	t.Run("Arguments", func(t *testing.T) {
+		addresses := test.AddressGenerator()
+		payer := addresses.New()
+
+		newTransaction := func(arguments ...[]byte) *flow.Transaction {
+			tx := flow.NewTransaction().
+				SetScript([]byte("transaction(a: String, b: Int) {}")).
+				SetReferenceBlockID(test.IdentifierGenerator().New()).
+				SetProposalKey(payer, 0, 1).
+				SetPayer(payer)
+			for _, arg := range arguments {
+				tx.AddRawArgument(arg)
+			}
+			return tx
+		}
+
+		roundTrip := func(t *testing.T, tx *flow.Transaction) *flow.Transaction {
+			voucher, err := flow.NewFCLVoucher(tx)
+			require.NoError(t, err)
+
+			data, err := json.Marshal(voucher)
+			require.NoError(t, err)
+
+			var decodedVoucher flow.FCLVoucher
+			require.NoError(t, json.Unmarshal(data, &decodedVoucher))
+
+			decoded, err := decodedVoucher.Transaction()
+			require.NoError(t, err)
+			return decoded
+		}
+
+		t.Run("Canonical arguments round trip", func(t *testing.T) {
+			tx := newTransaction(
+				flow.MustEncodeArgument(cadence.String("<a & b>")),
+				flow.MustEncodeArgument(cadence.NewInt(42)),
+			)
+
+			decoded := roundTrip(t, tx)
+			assert.Equal(t, tx.Arguments, decoded.Arguments)
+			assert.Equal(t, tx.ID(), decoded.ID())
+		})
+
+		t.Run("Non-canonical arguments are compacted", func(t *testing.T) {
+			tx := newTransaction([]byte(`{"type": "Int", "value": "42"}` + "\n"))
+
+			decoded := roundTrip(t, tx)
+			assert.Equal(t, []byte(`{"type":"Int","value":"42"}`), decoded.Arguments[0])
+			assert.NotEqual(t, tx.ID(), decoded.ID())
+
+			require.NoError(t, tx.NormalizeArguments())
+			assert.Equal(t, tx.ID(), roundTrip(t, tx).ID())
+		})
+
+		t.Run("Voucher arguments are used as is", func(t *testing.T) {
+			data := `{
+				"cadence": "transaction(b: Int) {}",
+				"refBlock": "` + test.IdentifierGenerator().New().Hex() + `",
+				"proposalKey": {"address": "` + payer.HexWithPrefix() + `"},
+				"payer": "` + payer.HexWithPrefix() + `",
+				"arguments": [{"type": "Int", "value": "42"}]
+			}`
+
+			var voucher flow.FCLVoucher
+			require.NoError(t, json.Unmarshal([]byte(data), &voucher))
+
+			decoded, err := voucher.Transaction()
+			require.NoError(t, err)
+			assert.Equal(t, [][]byte{[]byte(`{"type": "Int", "value": "42"}`)}, decoded.Arguments)
+		})
+	})
+}
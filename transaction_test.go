@@ -19,6 +19,7 @@
 package flow_test
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"testing"
@@ -29,6 +30,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/onflow/flow-go-sdk/test"
 )
 
@@ -510,6 +512,50 @@ func TestTransaction_AbleToReconstructTransaction(t *testing.T) {
 	})
 }
 
+func TestTransaction_SignWithContext(t *testing.T) {
+	_, signer := test.AccountKeyGenerator().NewWithSigner()
+	address := flow.HexToAddress("01")
+
+	newTx := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			SetProposalKey(address, 0, 0).
+			SetPayer(address)
+	}
+
+	t.Run("Valid signatures", func(t *testing.T) {
+		tx := newTx()
+		require.NoError(t, tx.SignPayloadWithContext(context.Background(), address, 1, signer))
+		require.NoError(t, tx.SignEnvelopeWithContext(context.Background(), address, 0, signer))
+
+		require.Len(t, tx.PayloadSignatures, 1)
+		require.Len(t, tx.EnvelopeSignatures, 1)
+
+		hasher := crypto.NewSHA3_256()
+
+		payload := append(flow.TransactionDomainTag[:], tx.PayloadMessage()...)
+		valid, err := signer.PublicKey().Verify(tx.PayloadSignatures[0].Signature, payload, hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		envelope := append(flow.TransactionDomainTag[:], tx.EnvelopeMessage()...)
+		valid, err = signer.PublicKey().Verify(tx.EnvelopeSignatures[0].Signature, envelope, hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		tx := newTx()
+		assert.ErrorIs(t, tx.SignPayloadWithContext(ctx, address, 1, signer), context.Canceled)
+		assert.ErrorIs(t, tx.SignEnvelopeWithContext(ctx, address, 0, signer), context.Canceled)
+		assert.Empty(t, tx.PayloadSignatures)
+		assert.Empty(t, tx.EnvelopeSignatures)
+	})
+}
+
 func TestTransaction_SignatureOrdering(t *testing.T) {
 	tx := flow.NewTransaction()
 
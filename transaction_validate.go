@@ -0,0 +1,254 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+// DefaultMaxTransactionComputeLimit is the default maximum compute limit accepted by Transaction.Validate.
+const DefaultMaxTransactionComputeLimit uint64 = 9999
+
+// DefaultMaxTransactionAuthorizers is the default maximum number of authorizers accepted by Transaction.Validate.
+const DefaultMaxTransactionAuthorizers = 100
+
+// List of problems reported by Transaction.Validate.
+var (
+	ErrTransactionEmptyScript              = errors.New("script is empty")
+	ErrTransactionMissingReferenceBlock    = errors.New("reference block ID is not set")
+	ErrTransactionMissingPayer             = errors.New("payer is not set")
+	ErrTransactionMissingProposalKey       = errors.New("proposal key is not set")
+	ErrTransactionInvalidArgument          = errors.New("argument is not valid JSON-CDC")
+	ErrTransactionComputeLimitExceeded     = errors.New("compute limit exceeds maximum")
+	ErrTransactionTooManyAuthorizers       = errors.New("too many authorizers")
+	ErrTransactionDuplicateSignature       = errors.New("duplicate signature")
+	ErrTransactionUnexpectedSignature      = errors.New("signature from account that is not a signer")
+	ErrTransactionMisplacedSignature       = errors.New("signature in the wrong signature set")
+	ErrTransactionMissingPayloadSignature  = errors.New("missing payload signature")
+	ErrTransactionMissingEnvelopeSignature = errors.New("missing envelope signature")
+)
+
+// TransactionValidationOptions configures the checks performed by Transaction.Validate.
+type TransactionValidationOptions struct {
+	// MaxComputeLimit is the maximum accepted compute limit.
+	//
+	// DefaultMaxTransactionComputeLimit is used if zero.
+	MaxComputeLimit uint64
+
+	// MaxAuthorizers is the maximum accepted number of authorizers.
+	//
+	// DefaultMaxTransactionAuthorizers is used if zero.
+	MaxAuthorizers int
+
+	// AllowMissingSignatures skips the checks for missing payload and envelope signatures,
+	// for example when validating a transaction before it is signed.
+	AllowMissingSignatures bool
+}
+
+// A TransactionValidationError lists every problem found by Transaction.Validate.
+type TransactionValidationError struct {
+	Errs []error
+}
+
+func (e *TransactionValidationError) Error() string {
+	messages := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		messages[i] = err.Error()
+	}
+
+	return fmt.Sprintf("invalid transaction: %s", strings.Join(messages, "; "))
+}
+
+// Unwrap returns the individual problems, so that they can be matched with errors.Is.
+func (e *TransactionValidationError) Unwrap() []error {
+	return e.Errs
+}
+
+// Validate performs pre-flight checks on this transaction, without contacting the network.
+//
+// A transaction is reported as invalid for the following reasons:
+// - The script is empty
+// - The reference block ID, payer or proposal key is not set
+// - An argument cannot be decoded as JSON-CDC
+// - The compute limit or number of authorizers exceed the configured maximum
+// - The same account key appears more than once in the payload or envelope signatures
+// - A signature was produced by an account that is not a signer of the transaction
+// - The payer signed the payload, or an account other than the payer signed the envelope
+// - A signer role is missing its payload or envelope signature
+//
+// All problems are reported together in a *TransactionValidationError.
+func (t *Transaction) Validate(opts TransactionValidationOptions) error {
+	maxComputeLimit := opts.MaxComputeLimit
+	if maxComputeLimit == 0 {
+		maxComputeLimit = DefaultMaxTransactionComputeLimit
+	}
+
+	maxAuthorizers := opts.MaxAuthorizers
+	if maxAuthorizers == 0 {
+		maxAuthorizers = DefaultMaxTransactionAuthorizers
+	}
+
+	var errs []error
+
+	if len(t.Script) == 0 {
+		errs = append(errs, ErrTransactionEmptyScript)
+	}
+
+	if t.ReferenceBlockID == EmptyID {
+		errs = append(errs, ErrTransactionMissingReferenceBlock)
+	}
+
+	if t.Payer == EmptyAddress {
+		errs = append(errs, ErrTransactionMissingPayer)
+	}
+
+	if t.ProposalKey.Address == EmptyAddress {
+		errs = append(errs, ErrTransactionMissingProposalKey)
+	}
+
+	for i, arg := range t.Arguments {
+		_, err := jsoncdc.Decode(nil, arg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: argument %d: %s", ErrTransactionInvalidArgument, i, err))
+		}
+	}
+
+	if t.GasLimit > maxComputeLimit {
+		errs = append(errs, fmt.Errorf("%w: %d > %d", ErrTransactionComputeLimitExceeded, t.GasLimit, maxComputeLimit))
+	}
+
+	if len(t.Authorizers) > maxAuthorizers {
+		errs = append(errs, fmt.Errorf("%w: %d > %d", ErrTransactionTooManyAuthorizers, len(t.Authorizers), maxAuthorizers))
+	}
+
+	errs = append(errs, t.validateSignatures(opts.AllowMissingSignatures)...)
+
+	if len(errs) > 0 {
+		return &TransactionValidationError{Errs: errs}
+	}
+
+	return nil
+}
+
+type signatureKey struct {
+	address  Address
+	keyIndex uint32
+}
+
+func (t *Transaction) validateSignatures(allowMissing bool) []error {
+	var errs []error
+
+	signers := t.signerMap()
+	payloadSigners := make(map[Address]struct{})
+	envelopeSigners := make(map[Address]struct{})
+	proposalKeySigned := false
+
+	check := func(kind string, sigs []TransactionSignature, envelope bool, signed map[Address]struct{}) {
+		seen := make(map[signatureKey]struct{})
+
+		for _, sig := range sigs {
+			key := signatureKey{address: sig.Address, keyIndex: sig.KeyIndex}
+			if _, duplicate := seen[key]; duplicate {
+				errs = append(errs, fmt.Errorf("%w: %s signature by key %d of account %s", ErrTransactionDuplicateSignature, kind, sig.KeyIndex, sig.Address))
+			}
+			seen[key] = struct{}{}
+
+			if _, ok := signers[sig.Address]; !ok {
+				errs = append(errs, fmt.Errorf("%w: %s signature by account %s", ErrTransactionUnexpectedSignature, kind, sig.Address))
+				continue
+			}
+
+			// The payer signs the envelope only, every other signer signs the payload only.
+			if (sig.Address == t.Payer) != envelope {
+				errs = append(errs, fmt.Errorf("%w: %s signature by %s %s", ErrTransactionMisplacedSignature, kind, t.signerRoles(sig.Address), sig.Address))
+				continue
+			}
+
+			signed[sig.Address] = struct{}{}
+
+			if sig.Address == t.ProposalKey.Address && sig.KeyIndex == t.ProposalKey.KeyIndex {
+				proposalKeySigned = true
+			}
+		}
+	}
+
+	check("payload", t.PayloadSignatures, false, payloadSigners)
+	check("envelope", t.EnvelopeSignatures, true, envelopeSigners)
+
+	if allowMissing {
+		return errs
+	}
+
+	// The payer signs the envelope, every other signer signs the payload.
+	for _, signer := range t.signerList() {
+		if signer == t.Payer {
+			if _, ok := envelopeSigners[signer]; !ok {
+				errs = append(errs, fmt.Errorf("%w: payer %s", ErrTransactionMissingEnvelopeSignature, signer))
+			}
+			continue
+		}
+
+		if _, ok := payloadSigners[signer]; !ok {
+			errs = append(errs, fmt.Errorf("%w: %s %s", ErrTransactionMissingPayloadSignature, t.signerRoles(signer), signer))
+		}
+	}
+
+	// The proposer must additionally sign with the proposal key itself. Only report this
+	// if the proposer signed at all, to avoid reporting the same problem twice.
+	if t.ProposalKey.Address != EmptyAddress && !proposalKeySigned {
+		err := ErrTransactionMissingPayloadSignature
+		signed := payloadSigners
+		if t.ProposalKey.Address == t.Payer {
+			err = ErrTransactionMissingEnvelopeSignature
+			signed = envelopeSigners
+		}
+
+		if _, ok := signed[t.ProposalKey.Address]; ok {
+			errs = append(errs, fmt.Errorf("%w: proposal key %d of account %s", err, t.ProposalKey.KeyIndex, t.ProposalKey.Address))
+		}
+	}
+
+	return errs
+}
+
+// signerRoles returns a description of the roles the given account fulfills in this transaction.
+func (t *Transaction) signerRoles(address Address) string {
+	var roles []string
+
+	if address == t.ProposalKey.Address {
+		roles = append(roles, "proposer")
+	}
+
+	if address == t.Payer {
+		roles = append(roles, "payer")
+	}
+
+	for _, authorizer := range t.Authorizers {
+		if address == authorizer {
+			roles = append(roles, "authorizer")
+			break
+		}
+	}
+
+	return strings.Join(roles, "/")
+}
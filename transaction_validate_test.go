@@ -0,0 +1,173 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flow_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/test"
+)
+
+func TestTransaction_Validate(t *testing.T) {
+	addresses := test.AddressGenerator()
+	proposer := addresses.New()
+	payer := addresses.New()
+	authorizer := addresses.New()
+
+	newTransaction := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			AddRawArgument(jsoncdc.MustEncode(cadence.String("hello"))).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(proposer, 3, 42).
+			SetPayer(payer).
+			AddAuthorizer(authorizer)
+	}
+
+	sign := func(tx *flow.Transaction) *flow.Transaction {
+		tx.AddPayloadSignature(proposer, 3, []byte{1})
+		tx.AddPayloadSignature(authorizer, 0, []byte{2})
+		tx.AddEnvelopeSignature(payer, 0, []byte{3})
+		return tx
+	}
+
+	t.Run("Valid signed transaction", func(t *testing.T) {
+		err := sign(newTransaction()).Validate(flow.TransactionValidationOptions{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Valid unsigned transaction", func(t *testing.T) {
+		err := newTransaction().Validate(flow.TransactionValidationOptions{AllowMissingSignatures: true})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Reports every problem", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			AddRawArgument([]byte("not json-cdc")).
+			SetComputeLimit(10000)
+
+		err := tx.Validate(flow.TransactionValidationOptions{})
+		require.Error(t, err)
+
+		var validationErr *flow.TransactionValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Len(t, validationErr.Errs, 6)
+
+		assert.ErrorIs(t, err, flow.ErrTransactionEmptyScript)
+		assert.ErrorIs(t, err, flow.ErrTransactionMissingReferenceBlock)
+		assert.ErrorIs(t, err, flow.ErrTransactionMissingPayer)
+		assert.ErrorIs(t, err, flow.ErrTransactionMissingProposalKey)
+		assert.ErrorIs(t, err, flow.ErrTransactionInvalidArgument)
+		assert.ErrorIs(t, err, flow.ErrTransactionComputeLimitExceeded)
+	})
+
+	t.Run("Configurable limits", func(t *testing.T) {
+		tx := sign(newTransaction().AddAuthorizer(addresses.New()))
+		tx.AddPayloadSignature(tx.Authorizers[1], 0, []byte{4})
+
+		err := tx.Validate(flow.TransactionValidationOptions{MaxAuthorizers: 1, MaxComputeLimit: 100})
+		assert.ErrorIs(t, err, flow.ErrTransactionTooManyAuthorizers)
+		assert.ErrorIs(t, err, flow.ErrTransactionComputeLimitExceeded)
+	})
+
+	t.Run("Duplicate and unexpected signatures", func(t *testing.T) {
+		tx := sign(newTransaction())
+		tx.AddPayloadSignature(authorizer, 0, []byte{5})
+		tx.AddPayloadSignature(addresses.New(), 0, []byte{6})
+
+		err := tx.Validate(flow.TransactionValidationOptions{})
+		assert.ErrorIs(t, err, flow.ErrTransactionDuplicateSignature)
+		assert.ErrorIs(t, err, flow.ErrTransactionUnexpectedSignature)
+	})
+
+	t.Run("Duplicates are detected per signature set", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(proposer, 3, 42).
+			SetPayer(payer).
+			AddAuthorizer(payer)
+
+		tx.AddPayloadSignature(proposer, 3, []byte{1})
+		tx.AddEnvelopeSignature(payer, 0, []byte{2})
+		tx.AddEnvelopeSignature(payer, 0, []byte{3})
+
+		err := tx.Validate(flow.TransactionValidationOptions{})
+		assert.ErrorIs(t, err, flow.ErrTransactionDuplicateSignature)
+		assert.Contains(t, err.Error(), "envelope signature by key 0 of account "+payer.String())
+	})
+
+	t.Run("Misplaced signatures", func(t *testing.T) {
+		tx := newTransaction()
+		tx.AddPayloadSignature(payer, 0, []byte{1})
+		tx.AddEnvelopeSignature(proposer, 3, []byte{2})
+		tx.AddEnvelopeSignature(authorizer, 0, []byte{3})
+
+		err := tx.Validate(flow.TransactionValidationOptions{})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, flow.ErrTransactionMisplacedSignature)
+		assert.NotErrorIs(t, err, flow.ErrTransactionDuplicateSignature)
+		assert.Contains(t, err.Error(), "wrong signature set: payload signature by payer "+payer.String())
+		assert.Contains(t, err.Error(), "wrong signature set: envelope signature by proposer "+proposer.String())
+		assert.Contains(t, err.Error(), "wrong signature set: envelope signature by authorizer "+authorizer.String())
+
+		// misplaced signatures do not count for the set they should have been in
+		assert.Contains(t, err.Error(), "missing envelope signature: payer "+payer.String())
+		assert.Contains(t, err.Error(), "missing payload signature: proposer "+proposer.String())
+		assert.Contains(t, err.Error(), "missing payload signature: authorizer "+authorizer.String())
+
+		err = tx.Validate(flow.TransactionValidationOptions{AllowMissingSignatures: true})
+		assert.ErrorIs(t, err, flow.ErrTransactionMisplacedSignature)
+		assert.NotErrorIs(t, err, flow.ErrTransactionMissingPayloadSignature)
+	})
+
+	t.Run("Missing signatures per role", func(t *testing.T) {
+		tx := newTransaction()
+		tx.AddPayloadSignature(proposer, 1, []byte{1})
+
+		err := tx.Validate(flow.TransactionValidationOptions{})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, flow.ErrTransactionMissingEnvelopeSignature)
+		assert.ErrorIs(t, err, flow.ErrTransactionMissingPayloadSignature)
+		assert.Contains(t, err.Error(), "missing payload signature: authorizer "+authorizer.String())
+		assert.Contains(t, err.Error(), "missing envelope signature: payer "+payer.String())
+		assert.Contains(t, err.Error(), "missing payload signature: proposal key 3 of account "+proposer.String())
+	})
+
+	t.Run("Payer signs envelope for all its roles", func(t *testing.T) {
+		tx := flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(payer, 1, 0).
+			SetPayer(payer).
+			AddAuthorizer(payer)
+
+		assert.ErrorIs(t, tx.Validate(flow.TransactionValidationOptions{}), flow.ErrTransactionMissingEnvelopeSignature)
+
+		tx.AddEnvelopeSignature(payer, 1, []byte{1})
+		assert.NoError(t, tx.Validate(flow.TransactionValidationOptions{}))
+	})
+}
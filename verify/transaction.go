@@ -0,0 +1,346 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package verify checks signed Flow entities against on-chain state before they are submitted.
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// AccountClient is the subset of the Access API used to look up signer keys.
+//
+// It is implemented by access.Client.
+type AccountClient interface {
+	// GetAccountAtLatestBlock gets an account by address at the latest sealed block.
+	GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error)
+}
+
+// SignatureKind identifies the part of a transaction a signature was produced over.
+type SignatureKind int
+
+const (
+	// PayloadSignature is a signature over the transaction payload.
+	PayloadSignature SignatureKind = iota
+	// EnvelopeSignature is a signature over the transaction envelope.
+	EnvelopeSignature
+)
+
+// String returns the string representation of a signature kind.
+func (k SignatureKind) String() string {
+	return [...]string{"payload", "envelope"}[k]
+}
+
+var (
+	// ErrKeyNotFound is reported when a signature references a key index that does not exist on the account.
+	ErrKeyNotFound = errors.New("verify: account key not found")
+	// ErrKeyRevoked is reported when a signature was produced with a revoked key.
+	ErrKeyRevoked = errors.New("verify: account key is revoked")
+	// ErrInvalidSignature is reported when a signature does not verify against the account key.
+	ErrInvalidSignature = errors.New("verify: invalid signature")
+	// ErrInsufficientWeight is reported when the valid signatures of an account do not reach AccountKeyWeightThreshold.
+	ErrInsufficientWeight = errors.New("verify: insufficient key weight")
+	// ErrUnexpectedSigner is reported for envelope signatures by accounts other than the payer, and for
+	// payload signatures by accounts that are neither the proposer nor an authorizer, or that are the payer.
+	ErrUnexpectedSigner = errors.New("verify: signature by an account that is not expected to sign")
+	// ErrProposalKeyNotSigned is reported when the proposal key has no valid signature.
+	ErrProposalKeyNotSigned = errors.New("verify: proposal key has no valid signature")
+)
+
+// A SignatureResult is the outcome of verifying a single transaction signature.
+type SignatureResult struct {
+	Kind      SignatureKind
+	Signature flow.TransactionSignature
+	// Key is the on-chain account key the signature was checked against, or nil if it was not found.
+	Key *flow.AccountKey
+	// Err is nil if the signature is valid.
+	Err error
+}
+
+// Valid returns true if the signature is valid and was produced by a non-revoked key.
+func (r SignatureResult) Valid() bool {
+	return r.Err == nil
+}
+
+// A SignerResult is the outcome of verifying all signatures of a single signing account.
+type SignerResult struct {
+	Address flow.Address
+	// Kind is the part of the transaction this account must sign.
+	Kind SignatureKind
+	// Weight is the total weight of the distinct non-revoked keys with a valid signature of Kind.
+	Weight int
+	// Required is true if the account must reach AccountKeyWeightThreshold,
+	// which is the case for the payer and all authorizers.
+	Required bool
+}
+
+// Sufficient returns true if the account reached the required signature weight.
+func (r SignerResult) Sufficient() bool {
+	return !r.Required || r.Weight >= flow.AccountKeyWeightThreshold
+}
+
+// A TransactionResult is the outcome of verifying the signatures of a transaction.
+type TransactionResult struct {
+	Signatures []SignatureResult
+	// Signers lists the result for each signing account, in signer order (proposer, payer, authorizers).
+	Signers []SignerResult
+	// ProposalKeySigned is true if the proposal key produced a valid signature.
+	ProposalKeySigned bool
+}
+
+// Err returns an error listing every problem found, or nil if the transaction is fully and validly signed.
+//
+// A partially signed transaction reports the missing weight of the accounts that have not signed yet.
+func (r *TransactionResult) Err() error {
+	var errs []error
+
+	for _, sig := range r.Signatures {
+		if sig.Err != nil {
+			errs = append(errs, fmt.Errorf(
+				"%s signature by key %d of account %s: %w",
+				sig.Kind,
+				sig.Signature.KeyIndex,
+				sig.Signature.Address,
+				sig.Err,
+			))
+		}
+	}
+
+	for _, signer := range r.Signers {
+		if !signer.Sufficient() {
+			errs = append(errs, fmt.Errorf(
+				"%w: account %s has %s weight %d, requires %d",
+				ErrInsufficientWeight,
+				signer.Address,
+				signer.Kind,
+				signer.Weight,
+				flow.AccountKeyWeightThreshold,
+			))
+		}
+	}
+
+	if !r.ProposalKeySigned {
+		errs = append(errs, ErrProposalKeyNotSigned)
+	}
+
+	return errors.Join(errs...)
+}
+
+// A TransactionVerifier verifies transaction signatures against the keys stored on chain.
+type TransactionVerifier struct {
+	client AccountClient
+}
+
+// NewTransactionVerifier returns a verifier looking up account keys with the given client.
+func NewTransactionVerifier(client AccountClient) *TransactionVerifier {
+	return &TransactionVerifier{
+		client: client,
+	}
+}
+
+// Verify checks every payload and envelope signature of the transaction against the
+// on-chain key of its signer, and computes the signature weight of each signing account.
+//
+// Signatures placed in the wrong part of the transaction are reported with ErrUnexpectedSigner:
+// the payer signs the envelope only, and the proposer and authorizers sign the payload unless
+// they are also the payer. Such signatures do not count towards the weight of their account.
+//
+// An error is only returned if an account cannot be fetched. Invalid signatures and missing
+// weight are reported in the result, see TransactionResult.Err.
+func (v *TransactionVerifier) Verify(ctx context.Context, tx *flow.Transaction) (*TransactionResult, error) {
+	accounts := make(map[flow.Address]*flow.Account)
+
+	getAccount := func(address flow.Address) (*flow.Account, error) {
+		if account, ok := accounts[address]; ok {
+			return account, nil
+		}
+
+		account, err := v.client.GetAccountAtLatestBlock(ctx, address)
+		if err != nil {
+			return nil, fmt.Errorf("verify: failed to get account %s: %w", address, err)
+		}
+
+		accounts[address] = account
+		return account, nil
+	}
+
+	payloadMessage := append(flow.TransactionDomainTag[:], tx.PayloadMessage()...)
+	envelopeMessage := append(flow.TransactionDomainTag[:], tx.EnvelopeMessage()...)
+
+	result := &TransactionResult{}
+
+	type weightKey struct {
+		kind     SignatureKind
+		address  flow.Address
+		keyIndex uint32
+	}
+	weights := make(map[SignatureKind]map[flow.Address]int)
+	counted := make(map[weightKey]struct{})
+
+	kinds := make(map[flow.Address]SignatureKind)
+	for _, address := range signers(tx) {
+		kinds[address] = signatureKind(tx, address)
+	}
+
+	verifyAll := func(kind SignatureKind, signatures []flow.TransactionSignature, message []byte) error {
+		weights[kind] = make(map[flow.Address]int)
+
+		for _, sig := range signatures {
+			if expected, ok := kinds[sig.Address]; !ok || expected != kind {
+				result.Signatures = append(result.Signatures, SignatureResult{
+					Kind:      kind,
+					Signature: sig,
+					Err:       ErrUnexpectedSigner,
+				})
+				continue
+			}
+
+			account, err := getAccount(sig.Address)
+			if err != nil {
+				return err
+			}
+
+			key := findKey(account, sig.KeyIndex)
+			sigResult := SignatureResult{
+				Kind:      kind,
+				Signature: sig,
+				Key:       key,
+				Err:       verifySignature(key, sig.Signature, message),
+			}
+			result.Signatures = append(result.Signatures, sigResult)
+
+			if !sigResult.Valid() {
+				continue
+			}
+
+			if sig.Address == tx.ProposalKey.Address && sig.KeyIndex == tx.ProposalKey.KeyIndex {
+				result.ProposalKeySigned = true
+			}
+
+			wk := weightKey{kind: kind, address: sig.Address, keyIndex: sig.KeyIndex}
+			if _, ok := counted[wk]; ok {
+				continue
+			}
+			counted[wk] = struct{}{}
+			weights[kind][sig.Address] += key.Weight
+		}
+
+		return nil
+	}
+
+	err := verifyAll(PayloadSignature, tx.PayloadSignatures, payloadMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	err = verifyAll(EnvelopeSignature, tx.EnvelopeSignatures, envelopeMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizers := make(map[flow.Address]struct{}, len(tx.Authorizers))
+	for _, authorizer := range tx.Authorizers {
+		authorizers[authorizer] = struct{}{}
+	}
+
+	for _, address := range signers(tx) {
+		kind := kinds[address]
+		_, isAuthorizer := authorizers[address]
+
+		result.Signers = append(result.Signers, SignerResult{
+			Address:  address,
+			Kind:     kind,
+			Weight:   weights[kind][address],
+			Required: address == tx.Payer || isAuthorizer,
+		})
+	}
+
+	return result, nil
+}
+
+// signers returns the unique signing accounts of the transaction in signer order.
+func signers(tx *flow.Transaction) []flow.Address {
+	var addresses []flow.Address
+	seen := make(map[flow.Address]struct{})
+
+	add := func(address flow.Address) {
+		if address == flow.EmptyAddress {
+			return
+		}
+		if _, ok := seen[address]; ok {
+			return
+		}
+		seen[address] = struct{}{}
+		addresses = append(addresses, address)
+	}
+
+	add(tx.ProposalKey.Address)
+	add(tx.Payer)
+	for _, authorizer := range tx.Authorizers {
+		add(authorizer)
+	}
+
+	return addresses
+}
+
+// signatureKind returns the part of the transaction a signing account must sign.
+func signatureKind(tx *flow.Transaction, address flow.Address) SignatureKind {
+	if address == tx.Payer {
+		return EnvelopeSignature
+	}
+	return PayloadSignature
+}
+
+func findKey(account *flow.Account, index uint32) *flow.AccountKey {
+	for _, key := range account.Keys {
+		if key.Index == index {
+			return key
+		}
+	}
+	return nil
+}
+
+func verifySignature(key *flow.AccountKey, signature []byte, message []byte) error {
+	if key == nil {
+		return ErrKeyNotFound
+	}
+
+	if key.Revoked {
+		return ErrKeyRevoked
+	}
+
+	hasher, err := crypto.NewHasher(key.HashAlgo)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+
+	valid, err := key.PublicKey.Verify(signature, message, hasher)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
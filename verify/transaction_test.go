@@ -0,0 +1,227 @@
+/*
+ * Flow Go SDK
+ *
+ * Copyright Flow Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package verify_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/mocks"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/test"
+	"github.com/onflow/flow-go-sdk/verify"
+)
+
+type testAccount struct {
+	account *flow.Account
+	signers []crypto.Signer
+}
+
+func newTestAccount(keys *test.AccountKeys, address flow.Address, weights ...int) testAccount {
+	a := testAccount{
+		account: &flow.Account{Address: address},
+	}
+
+	for i, weight := range weights {
+		key, signer := keys.NewWithSigner()
+		key.Index = uint32(i)
+		key.Weight = weight
+		a.account.Keys = append(a.account.Keys, key)
+		a.signers = append(a.signers, signer)
+	}
+
+	return a
+}
+
+func newClient(accounts ...testAccount) *mocks.Client {
+	client := new(mocks.Client)
+	for _, a := range accounts {
+		client.
+			On("GetAccountAtLatestBlock", mock.Anything, a.account.Address).
+			Return(a.account, nil)
+	}
+	return client
+}
+
+func TestTransactionVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+	keys := test.AccountKeyGenerator()
+	addresses := test.AddressGenerator()
+
+	// the authorizer uses two half-weight keys
+	proposer := newTestAccount(keys, addresses.New(), flow.AccountKeyWeightThreshold)
+	authorizer := newTestAccount(keys, addresses.New(), flow.AccountKeyWeightThreshold/2, flow.AccountKeyWeightThreshold/2)
+	payer := newTestAccount(keys, addresses.New(), flow.AccountKeyWeightThreshold)
+
+	newTransaction := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript(test.GreetingScript).
+			SetReferenceBlockID(test.IdentifierGenerator().New()).
+			SetProposalKey(proposer.account.Address, 0, 0).
+			SetPayer(payer.account.Address).
+			AddAuthorizer(authorizer.account.Address)
+	}
+
+	signPayload := func(t *testing.T, tx *flow.Transaction) {
+		require.NoError(t, tx.SignPayload(proposer.account.Address, 0, proposer.signers[0]))
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 0, authorizer.signers[0]))
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 1, authorizer.signers[1]))
+	}
+
+	t.Run("Fully signed", func(t *testing.T) {
+		tx := newTransaction()
+		signPayload(t, tx)
+		require.NoError(t, tx.SignEnvelope(payer.account.Address, 0, payer.signers[0]))
+
+		verifier := verify.NewTransactionVerifier(newClient(proposer, authorizer, payer))
+		result, err := verifier.Verify(ctx, tx)
+		require.NoError(t, err)
+
+		assert.NoError(t, result.Err())
+		assert.True(t, result.ProposalKeySigned)
+		require.Len(t, result.Signers, 3)
+		assert.Equal(t, verify.SignerResult{
+			Address:  authorizer.account.Address,
+			Kind:     verify.PayloadSignature,
+			Weight:   flow.AccountKeyWeightThreshold,
+			Required: true,
+		}, result.Signers[2])
+		// the proposer has no weight requirement
+		assert.False(t, result.Signers[0].Required)
+	})
+
+	t.Run("Partially signed", func(t *testing.T) {
+		tx := newTransaction()
+		require.NoError(t, tx.SignPayload(proposer.account.Address, 0, proposer.signers[0]))
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 0, authorizer.signers[0]))
+
+		verifier := verify.NewTransactionVerifier(newClient(proposer, authorizer))
+		result, err := verifier.Verify(ctx, tx)
+		require.NoError(t, err)
+
+		err = result.Err()
+		assert.ErrorIs(t, err, verify.ErrInsufficientWeight)
+		assert.Contains(t, err.Error(), authorizer.account.Address.String()+" has payload weight 500")
+		assert.Contains(t, err.Error(), payer.account.Address.String()+" has envelope weight 0")
+	})
+
+	t.Run("Invalid and revoked signatures", func(t *testing.T) {
+		revoked := newTestAccount(keys, authorizer.account.Address, flow.AccountKeyWeightThreshold/2, flow.AccountKeyWeightThreshold/2)
+		revoked.account.Keys[1].Revoked = true
+
+		tx := newTransaction()
+		require.NoError(t, tx.SignPayload(proposer.account.Address, 0, proposer.signers[0]))
+		require.NoError(t, tx.SignPayload(revoked.account.Address, 0, payer.signers[0]))
+		require.NoError(t, tx.SignPayload(revoked.account.Address, 1, revoked.signers[1]))
+		tx.AddPayloadSignature(revoked.account.Address, 7, []byte{1, 2, 3})
+		require.NoError(t, tx.SignEnvelope(payer.account.Address, 0, payer.signers[0]))
+
+		verifier := verify.NewTransactionVerifier(newClient(proposer, revoked, payer))
+		result, err := verifier.Verify(ctx, tx)
+		require.NoError(t, err)
+
+		err = result.Err()
+		assert.ErrorIs(t, err, verify.ErrInvalidSignature)
+		assert.ErrorIs(t, err, verify.ErrKeyRevoked)
+		assert.ErrorIs(t, err, verify.ErrKeyNotFound)
+		assert.ErrorIs(t, err, verify.ErrInsufficientWeight)
+	})
+
+	t.Run("Proposal key not signed", func(t *testing.T) {
+		tx := newTransaction()
+		tx.SetProposalKey(payer.account.Address, 0, 0)
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 0, authorizer.signers[0]))
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 1, authorizer.signers[1]))
+
+		verifier := verify.NewTransactionVerifier(newClient(authorizer))
+		result, err := verifier.Verify(ctx, tx)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, result.Err(), verify.ErrProposalKeyNotSigned)
+	})
+
+	t.Run("Misplaced signatures", func(t *testing.T) {
+		outsider := newTestAccount(keys, addresses.New(), flow.AccountKeyWeightThreshold)
+
+		tx := newTransaction()
+		signPayload(t, tx)
+		require.NoError(t, tx.SignPayload(payer.account.Address, 0, payer.signers[0]))
+		require.NoError(t, tx.SignPayload(outsider.account.Address, 0, outsider.signers[0]))
+		require.NoError(t, tx.SignEnvelope(authorizer.account.Address, 0, authorizer.signers[0]))
+		require.NoError(t, tx.SignEnvelope(payer.account.Address, 0, payer.signers[0]))
+
+		client := newClient(proposer, authorizer, payer)
+		verifier := verify.NewTransactionVerifier(client)
+		result, err := verifier.Verify(ctx, tx)
+		require.NoError(t, err)
+
+		err = result.Err()
+		assert.ErrorIs(t, err, verify.ErrUnexpectedSigner)
+		assert.NotErrorIs(t, err, verify.ErrInsufficientWeight)
+		assert.Contains(t, err.Error(), "payload signature by key 0 of account "+payer.account.Address.String())
+		assert.Contains(t, err.Error(), "payload signature by key 0 of account "+outsider.account.Address.String())
+		assert.Contains(t, err.Error(), "envelope signature by key 0 of account "+authorizer.account.Address.String())
+
+		var unexpected int
+		for _, sig := range result.Signatures {
+			if sig.Err != nil {
+				assert.ErrorIs(t, sig.Err, verify.ErrUnexpectedSigner)
+				unexpected++
+			}
+		}
+		assert.Equal(t, 3, unexpected)
+
+		// the accounts of unexpected signers are not fetched
+		client.AssertNotCalled(t, "GetAccountAtLatestBlock", mock.Anything, outsider.account.Address)
+	})
+
+	t.Run("Payer as proposer signs the envelope only", func(t *testing.T) {
+		tx := newTransaction()
+		tx.SetProposalKey(payer.account.Address, 0, 0)
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 0, authorizer.signers[0]))
+		require.NoError(t, tx.SignPayload(authorizer.account.Address, 1, authorizer.signers[1]))
+		require.NoError(t, tx.SignEnvelope(payer.account.Address, 0, payer.signers[0]))
+
+		verifier := verify.NewTransactionVerifier(newClient(authorizer, payer))
+		result, err := verifier.Verify(ctx, tx)
+		require.NoError(t, err)
+
+		assert.NoError(t, result.Err())
+		assert.True(t, result.ProposalKeySigned)
+	})
+
+	t.Run("Signature over modified transaction", func(t *testing.T) {
+		tx := newTransaction()
+		signPayload(t, tx)
+		tx.SetComputeLimit(42)
+		require.NoError(t, tx.SignEnvelope(payer.account.Address, 0, payer.signers[0]))
+
+		verifier := verify.NewTransactionVerifier(newClient(proposer, authorizer, payer))
+		result, err := verifier.Verify(ctx, tx)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, result.Err(), verify.ErrInvalidSignature)
+		assert.False(t, result.ProposalKeySigned)
+	})
+}